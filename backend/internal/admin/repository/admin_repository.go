@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+)
+
+type AdminRepository interface {
+	FindByID(ctx context.Context, id uint) (*entities.Admin, error)
+	FindByEmail(ctx context.Context, email string) (*entities.Admin, error)
+	FindByType(ctx context.Context, adminType entities.AdminType) ([]*entities.Admin, error)
+	FindByTypeAndMessNo(ctx context.Context, adminType entities.AdminType, messNo uint) ([]*entities.Admin, error)
+}
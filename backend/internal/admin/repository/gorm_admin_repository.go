@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"gorm.io/gorm"
+)
+
+type GormAdminRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAdminRepository(db *gorm.DB) AdminRepository {
+	return &GormAdminRepository{db: db}
+}
+
+func (r *GormAdminRepository) FindByID(ctx context.Context, id uint) (*entities.Admin, error) {
+	var admin entities.Admin
+	if err := r.db.WithContext(ctx).First(&admin, id).Error; err != nil {
+		return nil, err
+	}
+	return &admin, nil
+}
+
+func (r *GormAdminRepository) FindByEmail(ctx context.Context, email string) (*entities.Admin, error) {
+	var admin entities.Admin
+	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&admin).Error; err != nil {
+		return nil, err
+	}
+	return &admin, nil
+}
+
+func (r *GormAdminRepository) FindByType(ctx context.Context, adminType entities.AdminType) ([]*entities.Admin, error) {
+	return r.find(r.db.WithContext(ctx).Where("admin_type = ?", adminType))
+}
+
+func (r *GormAdminRepository) FindByTypeAndMessNo(ctx context.Context, adminType entities.AdminType, messNo uint) ([]*entities.Admin, error) {
+	return r.find(r.db.WithContext(ctx).Where("admin_type = ? AND mess_no = ?", adminType, messNo))
+}
+
+func (r *GormAdminRepository) find(query *gorm.DB) ([]*entities.Admin, error) {
+	var adminValues []entities.Admin
+	if err := query.Order("id").Find(&adminValues).Error; err != nil {
+		return nil, err
+	}
+	admins := make([]*entities.Admin, len(adminValues))
+	for i := range adminValues {
+		admins[i] = &adminValues[i]
+	}
+	return admins, nil
+}
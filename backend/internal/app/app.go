@@ -1,47 +1,144 @@
 package app
 
 import (
+	"os"
+	"time"
+
 	"github.com/ePSA-eJya/Mess_Management/internal/database"
 	"github.com/gofiber/fiber/v2"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 	"gorm.io/gorm"
 
+	GrpcAttendanceHandler "github.com/ePSA-eJya/Mess_Management/internal/attendance/handler/grpc"
+	attendanceRepository "github.com/ePSA-eJya/Mess_Management/internal/attendance/repository"
+	attendanceUseCase "github.com/ePSA-eJya/Mess_Management/internal/attendance/usecase"
+	GrpcBillingHandler "github.com/ePSA-eJya/Mess_Management/internal/billing/handler/grpc"
+	billingRepository "github.com/ePSA-eJya/Mess_Management/internal/billing/repository"
+	billingUseCase "github.com/ePSA-eJya/Mess_Management/internal/billing/usecase"
+	GrpcCancellationHandler "github.com/ePSA-eJya/Mess_Management/internal/cancellation/handler/grpc"
+	cancellationRepository "github.com/ePSA-eJya/Mess_Management/internal/cancellation/repository"
+	cancellationUseCase "github.com/ePSA-eJya/Mess_Management/internal/cancellation/usecase"
 	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	idempotencyRepository "github.com/ePSA-eJya/Mess_Management/internal/idempotency/repository"
 	GrpcOrderHandler "github.com/ePSA-eJya/Mess_Management/internal/order/handler/grpc"
 	orderRepository "github.com/ePSA-eJya/Mess_Management/internal/order/repository"
 	orderUseCase "github.com/ePSA-eJya/Mess_Management/internal/order/usecase"
+	GrpcStudentHandler "github.com/ePSA-eJya/Mess_Management/internal/student/handler/grpc"
+	studentRepository "github.com/ePSA-eJya/Mess_Management/internal/student/repository"
+	studentUseCase "github.com/ePSA-eJya/Mess_Management/internal/student/usecase"
+	GrpcUserHandler "github.com/ePSA-eJya/Mess_Management/internal/user/handler/grpc"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	userUseCase "github.com/ePSA-eJya/Mess_Management/internal/user/usecase"
 	"github.com/ePSA-eJya/Mess_Management/pkg/config"
+	"github.com/ePSA-eJya/Mess_Management/pkg/health"
+	"github.com/ePSA-eJya/Mess_Management/pkg/logger"
 	"github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pubsub"
 	"github.com/ePSA-eJya/Mess_Management/pkg/routes"
+	attendancepb "github.com/ePSA-eJya/Mess_Management/proto/attendance"
+	billingpb "github.com/ePSA-eJya/Mess_Management/proto/billing"
+	cancellationpb "github.com/ePSA-eJya/Mess_Management/proto/cancellation"
 	orderpb "github.com/ePSA-eJya/Mess_Management/proto/order"
+	studentpb "github.com/ePSA-eJya/Mess_Management/proto/student"
+	userpb "github.com/ePSA-eJya/Mess_Management/proto/user"
 )
 
 // rest
-func SetupRestServer(db *gorm.DB, cfg *config.Config) (*fiber.App, error) {
+func SetupRestServer(db *gorm.DB, cfg *config.Config, broker pubsub.Broker, checker *health.Checker) (*fiber.App, error) {
 	app := fiber.New()
 	middleware.FiberMiddleware(app)
+	routes.MetricsRoute(app)
+	routes.HealthRoutes(app, checker)
 	// comment out Swagger when testing
 	// routes.SwaggerRoute(app)
-	routes.RegisterPublicRoutes(app, db)
-	routes.RegisterPrivateRoutes(app, db)
+	routes.RegisterPublicRoutes(app, db, cfg)
+
+	// the generated REST routes call the gRPC server started next to this one
+	conn, err := grpc.NewClient("localhost:"+cfg.GrpcPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	if err := routes.RegisterGatewayRoutes(app, conn); err != nil {
+		return nil, err
+	}
+
+	routes.RegisterPrivateRoutes(app, db, cfg, broker)
 	routes.RegisterNotFoundRoute(app)
 	return app, nil
 }
 
 // grpc
-func SetupGrpcServer(db *gorm.DB, cfg *config.Config) (*grpc.Server, error) {
-	s := grpc.NewServer()
+func SetupGrpcServer(db *gorm.DB, cfg *config.Config, broker pubsub.Broker, checker *health.Checker) (*grpc.Server, error) {
+	authService, tokens := routes.NewAuthService(db, cfg)
+	idempotencyRepo := idempotencyRepository.NewGormIdempotencyRepository(db, time.Duration(cfg.IdempotencyKeyTTLHours)*time.Hour)
+	s := grpc.NewServer(middleware.GRPCServerOptions(tokens, authService, idempotencyRepo, routes.NewRateLimits(db, cfg))...)
+	retention := time.Duration(cfg.SoftDeleteRetentionDays) * 24 * time.Hour
 	orderRepo := orderRepository.NewGormOrderRepository(db)
-	orderService := orderUseCase.NewOrderService(orderRepo)
+	orderService := orderUseCase.NewOrderService(orderRepo, retention)
 
 	orderHandler := GrpcOrderHandler.NewGrpcOrderHandler(orderService)
 	orderpb.RegisterOrderServiceServer(s, orderHandler)
+
+	// User
+	userRepo := userRepository.NewGormUserRepository(db)
+	userpb.RegisterUserServiceServer(s, GrpcUserHandler.NewGrpcUserHandler(userUseCase.NewUserService(userRepo, retention)))
+
+	// Student
+	studentRepo := studentRepository.NewGormStudentRepository(db)
+	studentpb.RegisterStudentServiceServer(s, GrpcStudentHandler.NewGrpcStudentHandler(studentUseCase.NewStudentService(studentRepo, userRepo, retention)))
+
+	// Billing
+	cancellationRepo := cancellationRepository.NewGormCancellationRepository(db)
+	billingService := billingUseCase.NewBillingService(
+		billingRepository.NewGormBillingRepository(db),
+		studentRepo,
+		cancellationRepo,
+		billingUseCase.Rates{
+			Breakfast: cfg.MealRateBreakfast,
+			Lunch:     cfg.MealRateLunch,
+			Dinner:    cfg.MealRateDinner,
+		},
+	)
+	billingpb.RegisterBillingServiceServer(s, GrpcBillingHandler.NewGrpcBillingHandler(billingService))
+
+	// Cancellation
+	cancellationService := cancellationUseCase.NewCancellationService(cancellationRepo, studentRepo, userRepo, broker, cfg.CancellationCutoffHour)
+	cancellationpb.RegisterMealCancellationServiceServer(s, GrpcCancellationHandler.NewGrpcCancellationHandler(cancellationService))
+
+	// Attendance
+	attendanceService := attendanceUseCase.NewAttendanceService(
+		attendanceRepository.NewGormAttendanceRepository(db),
+		studentRepo,
+		cancellationRepo,
+		broker,
+	)
+	attendancepb.RegisterAttendanceServiceServer(s, GrpcAttendanceHandler.NewGrpcAttendanceHandler(attendanceService))
+
+	// Health checking protocol and server reflection, both served without a
+	// token. Every service follows the readiness of the checker.
+	healthServer := grpchealth.NewServer()
+	for name := range s.GetServiceInfo() {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	checker.Mirror(healthServer)
+	healthpb.RegisterHealthServer(s, healthServer)
+	reflection.Register(s)
 	return s, nil
 }
 
 // dependencies
 func SetupDependencies(env string) (*gorm.DB, *config.Config, error) {
 	cfg := config.LoadConfig(env)
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := logger.Setup(logger.Config{Level: cfg.LogLevel, Format: cfg.LogFormat, Levels: cfg.LogLevels}, os.Stdout); err != nil {
+		return nil, nil, err
+	}
 
 	db, err := database.Connect(cfg.DatabaseDSN)
 	if err != nil {
@@ -51,7 +148,57 @@ func SetupDependencies(env string) (*gorm.DB, *config.Config, error) {
 	if env == "test" {
 		_ = db.Migrator().DropTable(&entities.Order{}, &entities.User{})
 	}
-	if err := db.AutoMigrate(&entities.Order{}, &entities.User{}); err != nil {
+	if err := database.CreateEnumTypes(db); err != nil {
+		return nil, nil, err
+	}
+	// accounts created before email verification existed are trusted as they are
+	backfillVerified := db.Migrator().HasTable(&entities.User{}) &&
+		!db.Migrator().HasColumn(&entities.User{}, "EmailVerifiedAt")
+	if err := db.AutoMigrate(
+		&entities.Order{},
+		&entities.User{},
+		&entities.Admin{},
+		&entities.Complaint{},
+		&entities.ComplaintAttachment{},
+		&entities.Notification{},
+		&entities.NotificationPreference{},
+		&entities.Student{},
+		&entities.Semester{},
+		&entities.MealCancellationRecord{},
+		&entities.MonthlyBill{},
+		&entities.JobRun{},
+		&entities.Payment{},
+		&entities.OutboxEvent{},
+		&entities.OutboxDelivery{},
+		&entities.WebhookSubscription{},
+		&entities.Session{},
+		&entities.RefreshToken{},
+		&entities.UserToken{},
+		&entities.LoginThrottle{},
+		&entities.SSOLoginState{},
+		&entities.ExternalIdentity{},
+		&entities.MealAttendance{},
+		&entities.AuditLog{},
+		&entities.IdempotencyKey{},
+		&entities.RateLimitBucket{},
+		&entities.SchemaVersion{},
+	); err != nil {
+		return nil, nil, err
+	}
+	if err := database.CreateAuditTriggers(db); err != nil {
+		return nil, nil, err
+	}
+	if err := database.DropReplacedIndexes(db); err != nil {
+		return nil, nil, err
+	}
+	if backfillVerified {
+		if err := db.Model(&entities.User{}).
+			Where("email_verified_at IS NULL").
+			Update("email_verified_at", time.Now()).Error; err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := database.RecordSchemaVersion(db); err != nil {
 		return nil, nil, err
 	}
 
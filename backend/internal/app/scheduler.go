@@ -0,0 +1,215 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	adminRepository "github.com/ePSA-eJya/Mess_Management/internal/admin/repository"
+	authRepository "github.com/ePSA-eJya/Mess_Management/internal/auth/repository"
+	billingRepository "github.com/ePSA-eJya/Mess_Management/internal/billing/repository"
+	billingUseCase "github.com/ePSA-eJya/Mess_Management/internal/billing/usecase"
+	cancellationRepository "github.com/ePSA-eJya/Mess_Management/internal/cancellation/repository"
+	complaintRepository "github.com/ePSA-eJya/Mess_Management/internal/complaint/repository"
+	complaintUseCase "github.com/ePSA-eJya/Mess_Management/internal/complaint/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	idempotencyRepository "github.com/ePSA-eJya/Mess_Management/internal/idempotency/repository"
+	jobRepository "github.com/ePSA-eJya/Mess_Management/internal/jobs/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/notification/notifier"
+	notificationRepository "github.com/ePSA-eJya/Mess_Management/internal/notification/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/notification/subscriber"
+	notificationUseCase "github.com/ePSA-eJya/Mess_Management/internal/notification/usecase"
+	orderRepository "github.com/ePSA-eJya/Mess_Management/internal/order/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/outbox/dispatcher"
+	outboxRepository "github.com/ePSA-eJya/Mess_Management/internal/outbox/repository"
+	rateLimitRepository "github.com/ePSA-eJya/Mess_Management/internal/ratelimit/repository"
+	studentRepository "github.com/ePSA-eJya/Mess_Management/internal/student/repository"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/config"
+	"github.com/ePSA-eJya/Mess_Management/pkg/filestore"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pubsub"
+	"github.com/ePSA-eJya/Mess_Management/pkg/scheduler"
+)
+
+// scheduler
+func SetupScheduler(db *gorm.DB, cfg *config.Config, broker pubsub.Broker) (*scheduler.Scheduler, error) {
+	userRepo := userRepository.NewGormUserRepository(db)
+	notificationRepo := notificationRepository.NewGormNotificationRepository(db)
+	notificationService := notificationUseCase.NewNotificationService(
+		notificationRepo,
+		userRepo,
+		notifier.FromConfig(notificationRepo, cfg)...,
+	)
+
+	complaintService := complaintUseCase.NewComplaintService(
+		complaintRepository.NewGormComplaintRepository(db),
+		adminRepository.NewGormAdminRepository(db),
+		userRepo,
+		filestore.NewLocalFileStore(cfg.FileStoreDir),
+		notificationService,
+		broker,
+		complaintUseCase.SLA{
+			Acknowledge: time.Duration(cfg.ComplaintAckSLAHours) * time.Hour,
+			Resolve:     time.Duration(cfg.ComplaintResolveSLAHours) * time.Hour,
+		},
+	)
+
+	studentRepo := studentRepository.NewGormStudentRepository(db)
+	billingService := billingUseCase.NewBillingService(
+		billingRepository.NewGormBillingRepository(db),
+		studentRepo,
+		cancellationRepository.NewGormCancellationRepository(db),
+		billingUseCase.Rates{
+			Breakfast: cfg.MealRateBreakfast,
+			Lunch:     cfg.MealRateLunch,
+			Dinner:    cfg.MealRateDinner,
+		},
+	)
+
+	outboxDispatcher := dispatcher.New(outboxRepository.NewGormOutboxRepository(db), dispatcher.Config{
+		BatchSize:      cfg.OutboxBatchSize,
+		MaxAttempts:    cfg.OutboxMaxAttempts,
+		BaseBackoff:    30 * time.Second,
+		MaxBackoff:     6 * time.Hour,
+		WebhookTimeout: time.Duration(cfg.WebhookTimeoutSeconds) * time.Second,
+		ClaimLease:     time.Duration(cfg.OutboxClaimLeaseSeconds) * time.Second,
+	})
+	outboxDispatcher.Subscribe(entities.EventTypeBillGenerated, subscriber.NewBillGeneratedHandler(studentRepo, userRepo, notificationService))
+	outboxDispatcher.Subscribe(entities.EventTypePaymentRecorded, subscriber.NewPaymentRecordedHandler(studentRepo, userRepo, notificationService))
+
+	sessionRepo := authRepository.NewGormSessionRepository(db)
+	throttleRepo := authRepository.NewGormLoginThrottleRepository(db)
+	ssoRepo := authRepository.NewGormSSORepository(db)
+	orderRepo := orderRepository.NewGormOrderRepository(db)
+	idempotencyRepo := idempotencyRepository.NewGormIdempotencyRepository(db, time.Duration(cfg.IdempotencyKeyTTLHours)*time.Hour)
+	rateLimitRepo := rateLimitRepository.NewGormRateLimitRepository(db)
+
+	jobRunRepo := jobRepository.NewGormJobRunRepository(db)
+	s := scheduler.New(jobRunRepo)
+
+	jobs := []scheduler.Job{
+		{
+			Name: "monthly-billing",
+			Spec: cfg.JobBillingSpec,
+			Run: func(ctx context.Context) error {
+				lastMonth := time.Now().AddDate(0, -1, 0)
+				bills, err := billingService.GenerateMonthlyBills(ctx, lastMonth)
+				log.Info("Generated monthly bills", "count", len(bills), "month", lastMonth.Format(billingUseCase.MonthFormat))
+				return err
+			},
+		},
+		{
+			Name: "complaint-escalation",
+			Spec: cfg.JobEscalationSpec,
+			Run: func(ctx context.Context) error {
+				escalated, err := complaintService.EscalateOverdue(ctx)
+				if escalated > 0 {
+					log.Info("Escalated overdue complaints", "count", escalated)
+				}
+				return err
+			},
+		},
+		{
+			Name: "cancellation-cutoff-reminder",
+			Spec: cfg.JobCutoffReminderSpec,
+			Run: func(ctx context.Context) error {
+				tomorrow := time.Now().AddDate(0, 0, 1)
+				_, err := notificationService.NotifyRole(ctx, entities.RoleStudent, entities.EventCancellationCutoff, map[string]any{
+					"Date":   tomorrow.Format("2006-01-02"),
+					"Cutoff": fmt.Sprintf("%02d:00 today", cfg.CancellationCutoffHour),
+				})
+				return err
+			},
+		},
+		{
+			Name: "session-cleanup",
+			Spec: cfg.JobSessionCleanupSpec,
+			Run: func(ctx context.Context) error {
+				now := time.Now()
+				deleted, err := sessionRepo.DeleteExpired(now)
+				if deleted > 0 {
+					log.Info("Deleted expired sessions", "count", deleted)
+				}
+				if err != nil {
+					return err
+				}
+
+				cleared, err := throttleRepo.DeleteStale(now.Add(-time.Duration(cfg.LoginFailureWindowMinutes) * time.Minute))
+				if cleared > 0 {
+					log.Info("Cleared stale sign-in failure counters", "count", cleared)
+				}
+				if err != nil {
+					return err
+				}
+
+				if _, err := ssoRepo.DeleteExpiredLoginStates(now); err != nil {
+					return err
+				}
+
+				expiredKeys, err := idempotencyRepo.DeleteExpired(now)
+				if expiredKeys > 0 {
+					log.Info("Deleted expired idempotency keys", "count", expiredKeys)
+				}
+				if err != nil {
+					return err
+				}
+
+				// only written with RATE_LIMIT_STORE=postgres
+				_, err = rateLimitRepo.DeleteFull(now)
+				return err
+			},
+		},
+		{
+			Name: "soft-delete-purge",
+			Spec: cfg.JobPurgeSpec,
+			Run: func(ctx context.Context) error {
+				before := time.Now().AddDate(0, 0, -cfg.SoftDeleteRetentionDays)
+				for name, purge := range map[string]func(time.Time) (int64, error){
+					"users":    userRepo.Purge,
+					"students": studentRepo.Purge,
+					"orders":   orderRepo.Purge,
+				} {
+					purged, err := purge(before)
+					if purged > 0 {
+						log.Info("Purged deleted records", "count", purged, "table", name)
+					}
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Name: "job-run-cleanup",
+			Spec: cfg.JobRunCleanupSpec,
+			Run: func(ctx context.Context) error {
+				deleted, err := jobRunRepo.DeleteFinished(time.Now().AddDate(0, 0, -cfg.JobRunRetentionDays))
+				if deleted > 0 {
+					log.Info("Deleted finished job runs", "count", deleted)
+				}
+				return err
+			},
+		},
+		{
+			Name: "outbox-dispatch",
+			Spec: cfg.JobOutboxSpec,
+			Run: func(ctx context.Context) error {
+				delivered, err := outboxDispatcher.DispatchDue(ctx)
+				if delivered > 0 {
+					log.Info("Delivered outbox events", "count", delivered)
+				}
+				return err
+			},
+		},
+	}
+
+	for _, job := range jobs {
+		if err := s.Register(job); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
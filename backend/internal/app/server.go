@@ -1,51 +1,128 @@
 package app
 
 import (
-	"log"
+	"context"
+	"os"
+	"time"
 
 	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/logger"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pubsub"
+	"github.com/ePSA-eJya/Mess_Management/pkg/routes"
+	"github.com/ePSA-eJya/Mess_Management/pkg/tracing"
 	"github.com/ePSA-eJya/Mess_Management/utils"
 )
 
+var log = logger.For("app")
+
+const (
+	// healthWatchInterval is how often readiness is checked for the gRPC health service
+	healthWatchInterval = 10 * time.Second
+	// shutdownTimeout bounds every step of the shutdown once draining is over
+	shutdownTimeout = 30 * time.Second
+)
+
 func Start() {
 
 	// Setup dependencies: database and configuration
 	db, cfg, err := SetupDependencies("dev")
 	if err != nil {
-		log.Fatalf("❌ Failed to setup dependencies: %v", err)
+		log.Error("Failed to setup dependencies", "error", err)
+		os.Exit(1)
+	}
+
+	// Raw database and library errors stay in the logs in production
+	apperror.SetMaskInternal(cfg.AppEnv == "production")
+
+	// Traces are exported only when OTEL_ENABLED is set
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Enabled:     cfg.OTelEnabled,
+		Endpoint:    cfg.OTelEndpoint,
+		Insecure:    cfg.OTelInsecure,
+		ServiceName: cfg.OTelServiceName,
+		SampleRatio: cfg.OTelSampleRatio,
+	})
+	if err != nil {
+		log.Error("Failed to setup tracing", "error", err)
+		os.Exit(1)
 	}
 
+	// In-process message broker shared by the servers for live updates
+	broker := pubsub.NewMemoryBroker()
+
+	// Readiness shared by /readyz and the gRPC health service
+	checker := routes.NewHealthChecker(db, cfg)
+
 	// Setup REST server
-	restApp, err := SetupRestServer(db, cfg)
+	restApp, err := SetupRestServer(db, cfg, broker, checker)
 	if err != nil {
-		log.Fatalf("❌ Failed to setup REST server: %v", err)
+		log.Error("Failed to setup REST server", "error", err)
+		os.Exit(1)
 	}
 
 	// Setup gRPC server
-	grpcServer, err := SetupGrpcServer(db, cfg)
+	grpcServer, err := SetupGrpcServer(db, cfg, broker, checker)
 	if err != nil {
-		log.Fatalf("❌ Failed to setup gRPC server: %v", err)
+		log.Error("Failed to setup gRPC server", "error", err)
+		os.Exit(1)
+	}
+
+	// Setup job scheduler
+	jobScheduler, err := SetupScheduler(db, cfg, broker)
+	if err != nil {
+		log.Error("Failed to setup job scheduler", "error", err)
+		os.Exit(1)
 	}
 
 	// Start REST and gRPC servers
 	go utils.StartRestServer(restApp, cfg)
 	go utils.StartGrpcServer(grpcServer, cfg)
 
+	// Start scheduled jobs
+	jobScheduler.Start()
+
+	// Keep the gRPC health service current between /readyz probes
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	go checker.Watch(watchCtx, healthWatchInterval)
+
 	// Graceful shutdown listener
 	utils.WaitForShutdown([]func(){
 		func() {
-			log.Println("Shutting down REST server...")
-			if err := restApp.Shutdown(); err != nil {
-				log.Printf("Error shutting down REST server: %v", err)
+			log.Info("Draining", "seconds", cfg.ShutdownDrainSeconds)
+			stopWatching()
+			checker.Drain()
+			time.Sleep(time.Duration(cfg.ShutdownDrainSeconds) * time.Second)
+		},
+		func() {
+			log.Info("Stopping job scheduler")
+			ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+			if err := jobScheduler.Stop(ctx); err != nil {
+				log.Error("Stopping job scheduler failed", "error", err)
 			}
 		},
 		func() {
-			log.Println("Shutting down gRPC server...")
-			grpcServer.GracefulStop()
+			log.Info("Shutting down REST server")
+			// dashboard streams stay open until the timeout cuts them
+			if err := restApp.ShutdownWithTimeout(shutdownTimeout); err != nil {
+				log.Error("Shutting down REST server failed", "error", err)
+			}
+		},
+		func() {
+			log.Info("Shutting down gRPC server")
+			utils.StopGrpcServer(grpcServer, shutdownTimeout)
+		},
+		func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTracing(ctx); err != nil {
+				log.Error("Flushing traces failed", "error", err)
+			}
 		},
 		func() {
 			if err := database.Close(); err != nil {
-				log.Printf("Error closing DB: %v", err)
+				log.Error("Closing database failed", "error", err)
 			}
 		},
 	})
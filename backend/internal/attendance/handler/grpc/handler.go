@@ -0,0 +1,118 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/attendance/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+	attendancepb "github.com/ePSA-eJya/Mess_Management/proto/attendance"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GrpcAttendanceHandler struct {
+	attendanceUseCase usecase.AttendanceUseCase
+	attendancepb.UnimplementedAttendanceServiceServer
+}
+
+func NewGrpcAttendanceHandler(uc usecase.AttendanceUseCase) *GrpcAttendanceHandler {
+	return &GrpcAttendanceHandler{attendanceUseCase: uc}
+}
+
+func (h *GrpcAttendanceHandler) RecordAttendance(ctx context.Context, req *attendancepb.RecordAttendanceRequest) (*attendancepb.RecordAttendanceResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	claims, _ := middleware.ClaimsFromContext(ctx)
+
+	attendance, count, err := h.attendanceUseCase.RecordAttendance(ctx, claims.UserID, uint(req.Roll), entities.MealType(req.MealType))
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	return &attendancepb.RecordAttendanceResponse{
+		Attendance: toProtoAttendance(attendance),
+		Count:      toProtoMealCount(count),
+	}, nil
+}
+
+func (h *GrpcAttendanceHandler) GetMealCount(ctx context.Context, req *attendancepb.MealCountRequest) (*attendancepb.GetMealCountResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	date, err := parseDate(req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := h.attendanceUseCase.FindMealCount(ctx, uint(req.MessNo), date, entities.MealType(req.MealType))
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	return &attendancepb.GetMealCountResponse{Count: toProtoMealCount(count)}, nil
+}
+
+func (h *GrpcAttendanceHandler) WatchMealCount(req *attendancepb.MealCountRequest, stream grpc.ServerStreamingServer[attendancepb.MealCount]) error {
+	ctx := stream.Context()
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+	date, err := parseDate(req.Date)
+	if err != nil {
+		return err
+	}
+
+	counts, err := h.attendanceUseCase.WatchMealCount(ctx, uint(req.MessNo), date, entities.MealType(req.MealType))
+	if err != nil {
+		return apperror.GRPCError(err)
+	}
+	for count := range counts {
+		if err := stream.Send(toProtoMealCount(count)); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func requireAdmin(ctx context.Context) error {
+	return middleware.RequireGRPCRole(ctx, string(entities.RoleOfficeAdmin), string(entities.RoleMessAdmin))
+}
+
+// parseDate reads an optional YYYY-MM-DD date, today when empty
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+	date, err := time.Parse(usecase.DateFormat, value)
+	if err != nil {
+		return time.Time{}, status.Error(codes.InvalidArgument, "date must be YYYY-MM-DD")
+	}
+	return date, nil
+}
+
+// helper function convert entities.MealAttendance to attendancepb.MealAttendance
+func toProtoAttendance(a *entities.MealAttendance) *attendancepb.MealAttendance {
+	return &attendancepb.MealAttendance{
+		Id:         uint32(a.ID),
+		Roll:       uint32(a.Roll),
+		MessNo:     uint32(a.MessNo),
+		MealType:   string(a.MealType),
+		Date:       a.Date.Format(usecase.DateFormat),
+		RecordedBy: a.RecordedBy.String(),
+		CreatedAt:  a.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// helper function convert usecase.MealCount to attendancepb.MealCount
+func toProtoMealCount(c *usecase.MealCount) *attendancepb.MealCount {
+	return &attendancepb.MealCount{
+		MessNo:   uint32(c.MessNo),
+		MealType: string(c.MealType),
+		Date:     c.Date,
+		Served:   c.Served,
+		Expected: c.Expected,
+	}
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+)
+
+type AttendanceRepository interface {
+	Save(ctx context.Context, attendance *entities.MealAttendance) error
+	Exists(ctx context.Context, roll uint, date time.Time, mealType entities.MealType) (bool, error)
+	// CountServed is how many students of a mess were served the meal
+	CountServed(ctx context.Context, messNo uint, date time.Time, mealType entities.MealType) (int64, error)
+	// CountExpected is how many active students of a mess did not cancel the meal
+	CountExpected(ctx context.Context, messNo uint, date time.Time, mealType entities.MealType) (int64, error)
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"gorm.io/gorm"
+)
+
+type GormAttendanceRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAttendanceRepository(db *gorm.DB) AttendanceRepository {
+	return &GormAttendanceRepository{db: db}
+}
+
+// Save fails with apperror.ErrAlreadyExists when the student was already served
+// the meal, also when a concurrent scan got there first
+func (r *GormAttendanceRepository) Save(ctx context.Context, attendance *entities.MealAttendance) error {
+	if err := r.db.WithContext(ctx).Create(attendance).Error; err != nil {
+		if database.IsUniqueViolation(err) {
+			return apperror.ErrAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *GormAttendanceRepository) Exists(ctx context.Context, roll uint, date time.Time, mealType entities.MealType) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.MealAttendance{}).
+		Where("roll = ? AND date = ? AND meal_type = ?", roll, date, mealType).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *GormAttendanceRepository) CountServed(ctx context.Context, messNo uint, date time.Time, mealType entities.MealType) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.MealAttendance{}).
+		Where("mess_no = ? AND date = ? AND meal_type = ?", messNo, date, mealType).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *GormAttendanceRepository) CountExpected(ctx context.Context, messNo uint, date time.Time, mealType entities.MealType) (int64, error) {
+	cancelled := r.db.WithContext(ctx).Model(&entities.MealCancellationRecord{}).
+		Select("1").
+		Where("meal_cancellation_records.roll = students.roll AND date = ? AND meal_type = ?", date, mealType)
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.Student{}).
+		Where("mess_no = ? AND status = ?", messNo, entities.Active).
+		Where("NOT EXISTS (?)", cancelled).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+)
+
+// MealCount is the live served versus expected counter of one meal in one mess
+type MealCount struct {
+	MessNo   uint              `json:"mess_no"`
+	MealType entities.MealType `json:"meal_type"`
+	Date     string            `json:"date"`
+	Served   int64             `json:"served"`
+	Expected int64             `json:"expected"`
+}
+
+type AttendanceUseCase interface {
+	RecordAttendance(ctx context.Context, recordedBy string, roll uint, mealType entities.MealType) (*entities.MealAttendance, *MealCount, error)
+	FindMealCount(ctx context.Context, messNo uint, date time.Time, mealType entities.MealType) (*MealCount, error)
+	// WatchMealCount sends the current count and then every update until ctx is done
+	WatchMealCount(ctx context.Context, messNo uint, date time.Time, mealType entities.MealType) (<-chan *MealCount, error)
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/attendance/repository"
+	cancellationRepository "github.com/ePSA-eJya/Mess_Management/internal/cancellation/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	studentRepository "github.com/ePSA-eJya/Mess_Management/internal/student/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/logger"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pubsub"
+	"github.com/ePSA-eJya/Mess_Management/pkg/tracing"
+	"github.com/google/uuid"
+)
+
+var log = logger.For("attendance")
+
+// DateFormat is how meal dates are exchanged with clients
+const DateFormat = "2006-01-02"
+
+// AttendanceService
+type AttendanceService struct {
+	repo             repository.AttendanceRepository
+	studentRepo      studentRepository.StudentRepository
+	cancellationRepo cancellationRepository.CancellationRepository
+	broker           pubsub.Broker
+}
+
+// Init AttendanceService, counter updates are published on broker
+func NewAttendanceService(
+	repo repository.AttendanceRepository,
+	studentRepo studentRepository.StudentRepository,
+	cancellationRepo cancellationRepository.CancellationRepository,
+	broker pubsub.Broker,
+) AttendanceUseCase {
+	return &AttendanceService{
+		repo:             repo,
+		studentRepo:      studentRepo,
+		cancellationRepo: cancellationRepo,
+		broker:           broker,
+	}
+}
+
+// AttendanceService Methods - 1 record a student being served today's meal and
+// publish the new count of their mess
+func (s *AttendanceService) RecordAttendance(ctx context.Context, recordedBy string, roll uint, mealType entities.MealType) (_ *entities.MealAttendance, _ *MealCount, err error) {
+	ctx, span := tracing.Start(ctx, "AttendanceService.RecordAttendance")
+	defer func() { tracing.End(span, err) }()
+
+	if !mealType.Valid() {
+		return nil, nil, apperror.ErrInvalidData
+	}
+	recorder, err := uuid.Parse(recordedBy)
+	if err != nil {
+		return nil, nil, apperror.ErrUnauthorized
+	}
+
+	student, err := s.studentRepo.FindByRoll(ctx, roll)
+	if err != nil {
+		return nil, nil, err
+	}
+	if student.Status != entities.Active {
+		return nil, nil, fmt.Errorf("%w: student %d is not active", apperror.ErrUnprocessable, roll)
+	}
+
+	day := today()
+	cancelled, err := s.cancellationRepo.Exists(ctx, roll, day, mealType)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cancelled {
+		return nil, nil, fmt.Errorf("%w: student %d cancelled this meal", apperror.ErrUnprocessable, roll)
+	}
+	served, err := s.repo.Exists(ctx, roll, day, mealType)
+	if err != nil {
+		return nil, nil, err
+	}
+	if served {
+		return nil, nil, apperror.ErrAlreadyExists
+	}
+
+	attendance := &entities.MealAttendance{
+		Roll:       roll,
+		MessNo:     student.MessNo,
+		MealType:   mealType,
+		Date:       day,
+		RecordedBy: recorder,
+	}
+	if err := s.repo.Save(ctx, attendance); err != nil {
+		return nil, nil, err
+	}
+
+	count, err := s.FindMealCount(ctx, student.MessNo, day, mealType)
+	if err != nil {
+		return nil, nil, err
+	}
+	payload, err := json.Marshal(count)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the attendance is already stored, watchers catch up on the next update
+	if err := s.broker.Publish(context.WithoutCancel(ctx), entities.MealCountTopic(student.MessNo, day.Format(DateFormat), mealType), payload); err != nil {
+		log.ErrorContext(ctx, "Publishing meal count failed", "mess_no", student.MessNo, "error", err)
+	}
+	return attendance, count, nil
+}
+
+// AttendanceService Methods - 2 served versus expected count of a meal
+func (s *AttendanceService) FindMealCount(ctx context.Context, messNo uint, date time.Time, mealType entities.MealType) (_ *MealCount, err error) {
+	ctx, span := tracing.Start(ctx, "AttendanceService.FindMealCount")
+	defer func() { tracing.End(span, err) }()
+
+	if messNo == 0 || !mealType.Valid() {
+		return nil, apperror.ErrInvalidData
+	}
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	served, err := s.repo.CountServed(ctx, messNo, day, mealType)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := s.repo.CountExpected(ctx, messNo, day, mealType)
+	if err != nil {
+		return nil, err
+	}
+	return &MealCount{
+		MessNo:   messNo,
+		MealType: mealType,
+		Date:     day.Format(DateFormat),
+		Served:   served,
+		Expected: expected,
+	}, nil
+}
+
+// AttendanceService Methods - 3 stream the count of a meal, starting with its current value
+func (s *AttendanceService) WatchMealCount(ctx context.Context, messNo uint, date time.Time, mealType entities.MealType) (<-chan *MealCount, error) {
+	// subscribe before reading the snapshot so no update falls in between
+	updates, err := s.broker.Subscribe(ctx, entities.MealCountTopic(messNo, date.Format(DateFormat), mealType))
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.FindMealCount(ctx, messNo, date, mealType)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(chan *MealCount, 1)
+	counts <- current
+	go func() {
+		defer close(counts)
+		for payload := range updates {
+			var count MealCount
+			if err := json.Unmarshal(payload, &count); err != nil {
+				log.ErrorContext(ctx, "Decoding meal count update failed", "error", err)
+				continue
+			}
+			select {
+			case counts <- &count:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return counts, nil
+}
+
+// today is the current local date stored the way meal dates are, midnight UTC
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
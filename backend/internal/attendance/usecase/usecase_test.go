@@ -0,0 +1,141 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/attendance/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/attendance/usecase"
+	cancellationRepository "github.com/ePSA-eJya/Mess_Management/internal/cancellation/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	studentRepository "github.com/ePSA-eJya/Mess_Management/internal/student/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pubsub"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+var ctx = context.Background()
+
+const recorder = "9a176ca5-f3e0-4994-869c-fac0e8c9d5dc"
+
+type AttendanceUseCaseTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	service usecase.AttendanceUseCase
+	cleanup func()
+}
+
+func (s *AttendanceUseCaseTestSuite) SetupTest() {
+	s.db, s.cleanup = database.SetupTestDB(s.T())
+
+	s.service = usecase.NewAttendanceService(
+		repository.NewGormAttendanceRepository(s.db),
+		studentRepository.NewGormStudentRepository(s.db),
+		cancellationRepository.NewGormCancellationRepository(s.db),
+		pubsub.NewMemoryBroker(),
+	)
+
+	for _, student := range []entities.Student{
+		{Roll: 1, Name: "First", Hostel: "H1", RoomNo: 101, MessNo: 1, Email: "first@example.com"},
+		{Roll: 2, Name: "Second", Hostel: "H1", RoomNo: 102, MessNo: 1, Email: "second@example.com"},
+		{Roll: 3, Name: "Third", Hostel: "H1", RoomNo: 103, MessNo: 1, Email: "third@example.com"},
+		{Roll: 4, Name: "Other mess", Hostel: "H2", RoomNo: 201, MessNo: 2, Email: "other@example.com"},
+		{Roll: 5, Name: "Former", Hostel: "H1", RoomNo: 104, MessNo: 1, Email: "former@example.com", Status: entities.Inactive},
+	} {
+		s.Require().NoError(s.db.Create(&student).Error)
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	s.Require().NoError(s.db.Create(&entities.MealCancellationRecord{Roll: 3, MealType: entities.Lunch, Date: today}).Error)
+}
+
+func (s *AttendanceUseCaseTestSuite) TearDownTest() {
+	if s.cleanup != nil {
+		s.cleanup()
+	}
+}
+
+func TestAttendanceUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AttendanceUseCaseTestSuite))
+}
+
+func (s *AttendanceUseCaseTestSuite) TestRecordAttendance() {
+	attendance, count, err := s.service.RecordAttendance(ctx, recorder, 1, entities.Lunch)
+	s.NoError(err)
+	s.NotZero(attendance.ID)
+	s.Equal(uint(1), attendance.MessNo)
+
+	// rolls 1 and 2 are expected, 3 cancelled, 4 eats elsewhere and 5 left
+	s.Equal(int64(1), count.Served)
+	s.Equal(int64(2), count.Expected)
+
+	_, _, err = s.service.RecordAttendance(ctx, recorder, 1, entities.Lunch)
+	s.ErrorIs(err, apperror.ErrAlreadyExists)
+}
+
+func (s *AttendanceUseCaseTestSuite) TestRecordAttendance_ConcurrentScans() {
+	errs := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, _, err := s.service.RecordAttendance(ctx, recorder, 2, entities.Lunch)
+			errs <- err
+		}()
+	}
+	first, second := <-errs, <-errs
+
+	// whichever scan loses the race is told the meal was served, not a 500
+	s.True((first == nil) != (second == nil), "exactly one scan succeeds: %v, %v", first, second)
+	if first != nil {
+		s.ErrorIs(first, apperror.ErrAlreadyExists)
+	} else {
+		s.ErrorIs(second, apperror.ErrAlreadyExists)
+	}
+}
+
+func (s *AttendanceUseCaseTestSuite) TestRecordAttendance_Rejects() {
+	_, _, err := s.service.RecordAttendance(ctx, recorder, 3, entities.Lunch)
+	s.ErrorIs(err, apperror.ErrUnprocessable)
+
+	_, _, err = s.service.RecordAttendance(ctx, recorder, 5, entities.Lunch)
+	s.ErrorIs(err, apperror.ErrUnprocessable)
+
+	_, _, err = s.service.RecordAttendance(ctx, recorder, 1, entities.MealType("BRUNCH"))
+	s.ErrorIs(err, apperror.ErrInvalidData)
+
+	_, _, err = s.service.RecordAttendance(ctx, recorder, 99, entities.Lunch)
+	s.ErrorIs(err, apperror.ErrRecordNotFound)
+}
+
+func (s *AttendanceUseCaseTestSuite) TestWatchMealCount() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	counts, err := s.service.WatchMealCount(ctx, 1, time.Now(), entities.Lunch)
+	s.Require().NoError(err)
+
+	first := <-counts
+	s.Equal(int64(0), first.Served)
+	s.Equal(int64(2), first.Expected)
+
+	_, _, err = s.service.RecordAttendance(ctx, recorder, 2, entities.Lunch)
+	s.Require().NoError(err)
+	// attendance in another mess is not part of this feed
+	_, _, err = s.service.RecordAttendance(ctx, recorder, 4, entities.Lunch)
+	s.Require().NoError(err)
+
+	select {
+	case update := <-counts:
+		s.Equal(int64(1), update.Served)
+		s.Equal(uint(1), update.MessNo)
+	case <-time.After(time.Second):
+		s.Fail("no update received")
+	}
+
+	cancel()
+	for range counts {
+	}
+}
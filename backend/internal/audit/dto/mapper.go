@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/audit/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+)
+
+func ToAuditLogResponse(log *entities.AuditLog) *AuditLogResponse {
+	return &AuditLogResponse{
+		ID:         log.ID,
+		ActorID:    log.ActorID,
+		IP:         log.IP,
+		Action:     log.Action,
+		EntityType: log.EntityType,
+		EntityID:   log.EntityID,
+		Changes:    json.RawMessage(log.Changes),
+		CreatedAt:  log.CreatedAt,
+		PrevHash:   log.PrevHash,
+		Hash:       log.Hash,
+	}
+}
+
+func ToAuditLogPageResponse(page *pagination.Page[*entities.AuditLog]) *AuditLogPageResponse {
+	return pagination.Map(page, ToAuditLogResponse)
+}
+
+func ToVerificationResponse(v *usecase.Verification) *VerificationResponse {
+	return &VerificationResponse{Valid: v.Valid, Checked: v.Checked, BrokenAt: v.BrokenAt}
+}
@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+)
+
+type AuditLogResponse struct {
+	ID         uint            `json:"id"`
+	ActorID    string          `json:"actor_id"`
+	IP         string          `json:"ip"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Changes    json.RawMessage `json:"changes"`
+	CreatedAt  time.Time       `json:"created_at"`
+	PrevHash   string          `json:"prev_hash"`
+	Hash       string          `json:"hash"`
+}
+
+type AuditLogPageResponse = pagination.Page[*AuditLogResponse]
+
+type VerificationResponse struct {
+	Valid    bool `json:"valid"`
+	Checked  int  `json:"checked"`
+	BrokenAt uint `json:"broken_at,omitempty"`
+}
@@ -0,0 +1,59 @@
+package rest
+
+import (
+	"github.com/ePSA-eJya/Mess_Management/internal/audit/dto"
+	"github.com/ePSA-eJya/Mess_Management/internal/audit/usecase"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/gofiber/fiber/v2"
+)
+
+type HttpAuditHandler struct {
+	auditUseCase usecase.AuditUseCase
+}
+
+func NewHttpAuditHandler(useCase usecase.AuditUseCase) *HttpAuditHandler {
+	return &HttpAuditHandler{auditUseCase: useCase}
+}
+
+// FindLogs godoc
+// @Summary Get a page of audit log entries
+// @Tags audit
+// @Produce json
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Page size, at most 100"
+// @Param sort query string false "id or created_at, prefix with - for descending, newest first by default"
+// @Param filter[actor_id] query string false "Only changes made by this user"
+// @Param filter[action] query string false "Only this action, e.g. user.updated"
+// @Param filter[entity_type] query string false "Only changes to this kind of entity"
+// @Param filter[entity_id] query string false "Only changes to this entity"
+// @Success 200 {object} dto.AuditLogPageResponse
+// @Router /audit-logs [get]
+func (h *HttpAuditHandler) FindLogs(c *fiber.Ctx) error {
+	query, err := pagination.Parse(c.Queries())
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	page, err := h.auditUseCase.FindLogs(query)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToAuditLogPageResponse(page))
+}
+
+// Verify godoc
+// @Summary Check the audit log's hash chain for tampering
+// @Tags audit
+// @Produce json
+// @Success 200 {object} dto.VerificationResponse
+// @Router /audit-logs/verify [get]
+func (h *HttpAuditHandler) Verify(c *fiber.Ctx) error {
+	result, err := h.auditUseCase.Verify()
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToVerificationResponse(result))
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+)
+
+// AuditLogFields are the fields audit logs can be listed by
+var AuditLogFields = pagination.Fields{
+	Filter: map[string]string{
+		"actor_id":    "actor_id",
+		"action":      "action",
+		"entity_type": "entity_type",
+		"entity_id":   "entity_id",
+	},
+	Sort:        map[string]string{"id": "id", "created_at": "created_at"},
+	DefaultSort: "-id",
+}
+
+type AuditRepository interface {
+	FindAll(query pagination.Query) (*pagination.Page[*entities.AuditLog], error)
+	// FindAfter returns up to limit entries with an id above afterID, oldest first
+	FindAfter(afterID uint, limit int) ([]*entities.AuditLog, error)
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// appendLock serialises appends so every entry chains onto the one before it
+const appendLock = 4301
+
+type GormAuditRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAuditRepository(db *gorm.DB) AuditRepository {
+	return &GormAuditRepository{db: db}
+}
+
+// Append chains log onto the latest entry and writes it through tx, so it is
+// committed or rolled back together with the change it records. A nil log is
+// skipped.
+func Append(tx *gorm.DB, log *entities.AuditLog) error {
+	if log == nil {
+		return nil
+	}
+	// held until tx ends, so no other append reads the same latest entry
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", appendLock).Error; err != nil {
+		return err
+	}
+
+	var last entities.AuditLog
+	err := tx.Select("hash").Order("id DESC").Take(&last).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	log.ID = 0
+	log.PrevHash = last.Hash
+	// Postgres keeps microseconds, the hash must survive the round trip
+	log.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	if log.Hash, err = log.ComputeHash(); err != nil {
+		return err
+	}
+	return tx.Create(log).Error
+}
+
+func (r *GormAuditRepository) FindAll(query pagination.Query) (*pagination.Page[*entities.AuditLog], error) {
+	return pagination.Find[entities.AuditLog](r.db, query, AuditLogFields)
+}
+
+func (r *GormAuditRepository) FindAfter(afterID uint, limit int) ([]*entities.AuditLog, error) {
+	var logValues []entities.AuditLog
+	if err := r.db.Where("id > ?", afterID).Order("id").Limit(limit).Find(&logValues).Error; err != nil {
+		return nil, err
+	}
+	logs := make([]*entities.AuditLog, len(logValues))
+	for i := range logValues {
+		logs[i] = &logValues[i]
+	}
+	return logs, nil
+}
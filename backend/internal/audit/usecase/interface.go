@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+)
+
+// Verification is the outcome of checking the audit log's hash chain
+type Verification struct {
+	Valid   bool
+	Checked int
+	// BrokenAt is the first entry whose hash or link does not match, 0 when valid
+	BrokenAt uint
+}
+
+type AuditUseCase interface {
+	FindLogs(query pagination.Query) (*pagination.Page[*entities.AuditLog], error)
+	Verify() (*Verification, error)
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"github.com/ePSA-eJya/Mess_Management/internal/audit/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+)
+
+const verifyBatch = 500
+
+// AuditService
+type AuditService struct {
+	repo repository.AuditRepository
+}
+
+// Init AuditService
+func NewAuditService(repo repository.AuditRepository) AuditUseCase {
+	return &AuditService{repo: repo}
+}
+
+// AuditService Methods - 1 a page of audit log entries
+func (s *AuditService) FindLogs(query pagination.Query) (*pagination.Page[*entities.AuditLog], error) {
+	return s.repo.FindAll(query)
+}
+
+// AuditService Methods - 2 walk the chain from the first entry, every entry must
+// link to the hash of the one before it and hash to what it stores
+func (s *AuditService) Verify() (*Verification, error) {
+	result := &Verification{Valid: true}
+	var afterID uint
+	prevHash := ""
+	for {
+		logs, err := s.repo.FindAfter(afterID, verifyBatch)
+		if err != nil {
+			return nil, err
+		}
+		for _, log := range logs {
+			hash, err := log.ComputeHash()
+			if err != nil || log.PrevHash != prevHash || log.Hash != hash {
+				result.Valid = false
+				result.BrokenAt = log.ID
+				return result, nil
+			}
+			result.Checked++
+			prevHash = log.Hash
+			afterID = log.ID
+		}
+		if len(logs) < verifyBatch {
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/audit/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/audit/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type AuditUseCaseTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	service usecase.AuditUseCase
+	cleanup func()
+}
+
+func (s *AuditUseCaseTestSuite) SetupTest() {
+	s.db, s.cleanup = database.SetupTestDB(s.T())
+	s.service = usecase.NewAuditService(repository.NewGormAuditRepository(s.db))
+}
+
+func (s *AuditUseCaseTestSuite) TearDownTest() {
+	if s.cleanup != nil {
+		s.cleanup()
+	}
+}
+
+func TestAuditUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditUseCaseTestSuite))
+}
+
+var admin = entities.AuditActor{UserID: "9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", IP: "203.0.113.7"}
+
+// rename appends an entry for a user renamed from one name to another
+func (s *AuditUseCaseTestSuite) rename(id, from, to string) *entities.AuditLog {
+	log, err := entities.NewAuditLog(admin, entities.AuditUserUpdated, "user", id, &entities.User{Name: from}, &entities.User{Name: to})
+	s.Require().NoError(err)
+	s.Require().NoError(repository.Append(s.db, log))
+	return log
+}
+
+func (s *AuditUseCaseTestSuite) TestAppend_ChainsEntries() {
+	first := s.rename("user-1", "A", "B")
+	second := s.rename("user-2", "C", "D")
+
+	s.Empty(first.PrevHash)
+	s.Equal(first.Hash, second.PrevHash)
+
+	result, err := s.service.Verify()
+	s.NoError(err)
+	s.Equal(&usecase.Verification{Valid: true, Checked: 2}, result)
+}
+
+func (s *AuditUseCaseTestSuite) TestFindLogs_Filter() {
+	s.rename("user-1", "A", "B")
+	s.rename("user-2", "C", "D")
+	s.rename("user-1", "B", "E")
+
+	page, err := s.service.FindLogs(pagination.Query{Filter: map[string]string{"entity_id": "user-1"}})
+	s.NoError(err)
+	s.Equal(int64(2), page.Total)
+	s.Require().Len(page.Items, 2)
+	// newest first
+	s.JSONEq(`{"name":{"before":"B","after":"E"}}`, page.Items[0].Changes)
+}
+
+func (s *AuditUseCaseTestSuite) TestAuditLogsAreAppendOnly() {
+	log := s.rename("user-1", "A", "B")
+
+	s.Error(s.db.Model(&entities.AuditLog{}).Where("id = ?", log.ID).Update("actor_id", "someone-else").Error)
+	s.Error(s.db.Delete(&entities.AuditLog{}, log.ID).Error)
+}
+
+func (s *AuditUseCaseTestSuite) TestVerify_DetectsTampering() {
+	s.rename("user-1", "A", "B")
+	tampered := s.rename("user-2", "C", "D")
+	s.rename("user-3", "E", "F")
+
+	// someone with direct access to the database can lift the trigger
+	s.Require().NoError(s.db.Exec("ALTER TABLE audit_logs DISABLE TRIGGER audit_logs_append_only").Error)
+	defer s.db.Exec("ALTER TABLE audit_logs ENABLE TRIGGER audit_logs_append_only")
+	s.Require().NoError(s.db.Model(&entities.AuditLog{}).Where("id = ?", tampered.ID).
+		Update("changes", `{"name":{"before":"C","after":"X"}}`).Error)
+
+	result, err := s.service.Verify()
+	s.NoError(err)
+	s.False(result.Valid)
+	s.Equal(1, result.Checked)
+	s.Equal(tampered.ID, result.BrokenAt)
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"github.com/ePSA-eJya/Mess_Management/internal/auth/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	userDto "github.com/ePSA-eJya/Mess_Management/internal/user/dto"
+)
+
+func ToTokenResponse(pair *usecase.TokenPair, user *entities.User) *TokenResponse {
+	response := &TokenResponse{
+		Token:        pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    pair.ExpiresIn,
+	}
+	if user != nil {
+		response.User = userDto.ToUserResponse(user)
+	}
+	return response
+}
+
+func ToSessionResponseList(sessions []*entities.Session, currentSessionID string) []*SessionResponse {
+	result := make([]*SessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		result = append(result, &SessionResponse{
+			ID:         s.ID,
+			DeviceName: s.DeviceName,
+			IPAddress:  s.IPAddress,
+			Current:    s.ID.String() == currentSessionID,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			ExpiresAt:  s.ExpiresAt,
+		})
+	}
+	return result
+}
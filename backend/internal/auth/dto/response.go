@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"time"
+
+	userDto "github.com/ePSA-eJya/Mess_Management/internal/user/dto"
+	"github.com/google/uuid"
+)
+
+type TokenResponse struct {
+	User         *userDto.UserResponse `json:"user,omitempty"`
+	Token        string                `json:"token"`
+	RefreshToken string                `json:"refresh_token"`
+	TokenType    string                `json:"token_type"`
+	ExpiresIn    int                   `json:"expires_in"`
+}
+
+type SessionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	DeviceName string    `json:"device_name"`
+	IPAddress  string    `json:"ip_address"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
@@ -0,0 +1,127 @@
+package rest
+
+import (
+	"github.com/ePSA-eJya/Mess_Management/internal/auth/dto"
+	"github.com/ePSA-eJya/Mess_Management/internal/auth/usecase"
+	userDto "github.com/ePSA-eJya/Mess_Management/internal/user/dto"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/ePSA-eJya/Mess_Management/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+)
+
+// emailQueuedMessage is the same for known and unknown addresses so neither endpoint reveals accounts
+const emailQueuedMessage = "if the address belongs to an account, an email is on its way"
+
+type HttpAccountHandler struct {
+	accountUseCase usecase.AccountUseCase
+}
+
+func NewHttpAccountHandler(useCase usecase.AccountUseCase) *HttpAccountHandler {
+	return &HttpAccountHandler{accountUseCase: useCase}
+}
+
+// Register godoc
+// @Summary Register a new user and email a verification link
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param user body userDto.RegisterRequest true "User registration payload"
+// @Success 201 {object} userDto.UserResponse
+// @Router /auth/signup [post]
+func (h *HttpAccountHandler) Register(c *fiber.Ctx) error {
+	req := new(userDto.RegisterRequest)
+	if err := validation.ParseBody(c, req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	userEntity := userDto.ToUserEntity(req)
+	if err := h.accountUseCase.Register(c.UserContext(), userEntity); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(userDto.ToUserResponse(userEntity))
+}
+
+// VerifyEmail godoc
+// @Summary Confirm an email address with the emailed token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body dto.VerifyEmailRequest true "Verification token"
+// @Success 200 {object} responses.MessageResponse
+// @Router /auth/verify-email [post]
+func (h *HttpAccountHandler) VerifyEmail(c *fiber.Ctx) error {
+	req := new(dto.VerifyEmailRequest)
+	if err := validation.ParseBody(c, req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	if err := h.accountUseCase.VerifyEmail(c.UserContext(), req.Token); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Message(c, fiber.StatusOK, "email address verified")
+}
+
+// ResendVerification godoc
+// @Summary Email a new verification link
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param email body dto.EmailRequest true "Account email"
+// @Success 202 {object} responses.MessageResponse
+// @Router /auth/resend-verification [post]
+func (h *HttpAccountHandler) ResendVerification(c *fiber.Ctx) error {
+	req := new(dto.EmailRequest)
+	if err := validation.ParseBody(c, req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	if err := h.accountUseCase.ResendVerification(c.UserContext(), req.Email); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Message(c, fiber.StatusAccepted, emailQueuedMessage)
+}
+
+// ForgotPassword godoc
+// @Summary Email a password reset link
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param email body dto.EmailRequest true "Account email"
+// @Success 202 {object} responses.MessageResponse
+// @Router /auth/forgot-password [post]
+func (h *HttpAccountHandler) ForgotPassword(c *fiber.Ctx) error {
+	req := new(dto.EmailRequest)
+	if err := validation.ParseBody(c, req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	if err := h.accountUseCase.ForgotPassword(c.UserContext(), req.Email); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Message(c, fiber.StatusAccepted, emailQueuedMessage)
+}
+
+// ResetPassword godoc
+// @Summary Choose a new password with the emailed token, signing every device out
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param reset body dto.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} responses.MessageResponse
+// @Router /auth/reset-password [post]
+func (h *HttpAccountHandler) ResetPassword(c *fiber.Ctx) error {
+	req := new(dto.ResetPasswordRequest)
+	if err := validation.ParseBody(c, req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	if err := h.accountUseCase.ResetPassword(c.UserContext(), req.Token, req.Password); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Message(c, fiber.StatusOK, "password updated, sign in again")
+}
@@ -0,0 +1,181 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/auth/dto"
+	"github.com/ePSA-eJya/Mess_Management/internal/auth/usecase"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/ePSA-eJya/Mess_Management/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+)
+
+const maxDeviceNameLength = 200
+
+type HttpAuthHandler struct {
+	authUseCase usecase.AuthUseCase
+}
+
+func NewHttpAuthHandler(useCase usecase.AuthUseCase) *HttpAuthHandler {
+	return &HttpAuthHandler{authUseCase: useCase}
+}
+
+// Login godoc
+// @Summary Authenticate user and start a session
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body dto.LoginRequest true "Login credentials"
+// @Success 200 {object} dto.TokenResponse
+// @Router /auth/signin [post]
+func (h *HttpAuthHandler) Login(c *fiber.Ctx) error {
+	req := new(dto.LoginRequest)
+	if err := validation.ParseBody(c, req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	pair, user, err := h.authUseCase.Login(c.UserContext(), req.Email, req.Password, deviceFromCtx(c, req.DeviceName))
+	if err != nil {
+		var lockout *usecase.LockoutError
+		switch {
+		case errors.As(err, &lockout):
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(lockout.RetryAfter.Seconds()))))
+			return responses.ErrorWithMessage(c, err, "too many failed sign-in attempts, try again later")
+		case apperror.StatusCode(err) == fiber.StatusUnauthorized:
+			return responses.ErrorWithMessage(c, apperror.ErrUnauthorized, "invalid email or password")
+		}
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToTokenResponse(pair, user))
+}
+
+// Refresh godoc
+// @Summary Exchange a refresh token for a new token pair
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param refresh body dto.RefreshRequest true "Refresh token"
+// @Success 200 {object} dto.TokenResponse
+// @Router /auth/refresh [post]
+func (h *HttpAuthHandler) Refresh(c *fiber.Ctx) error {
+	req := new(dto.RefreshRequest)
+	if err := validation.ParseBody(c, req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	pair, err := h.authUseCase.Refresh(c.UserContext(), req.RefreshToken)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToTokenResponse(pair, nil))
+}
+
+// Logout godoc
+// @Summary End the current session
+// @Tags auth
+// @Produce json
+// @Success 200 {object} responses.MessageResponse
+// @Router /auth/logout [post]
+func (h *HttpAuthHandler) Logout(c *fiber.Ctx) error {
+	sessionID := c.Locals("session_id")
+	if sessionID == nil {
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	if err := h.authUseCase.Logout(c.UserContext(), fmt.Sprint(sessionID)); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Message(c, fiber.StatusOK, "logged out")
+}
+
+// LogoutAll godoc
+// @Summary End every session of the current user
+// @Tags auth
+// @Produce json
+// @Success 200 {object} responses.MessageResponse
+// @Router /auth/logout-all [post]
+func (h *HttpAuthHandler) LogoutAll(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	if err := h.authUseCase.LogoutAll(c.UserContext(), fmt.Sprint(userID)); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Message(c, fiber.StatusOK, "logged out of all devices")
+}
+
+// FindSessions godoc
+// @Summary List the devices the current user is signed in on
+// @Tags auth
+// @Produce json
+// @Success 200 {array} dto.SessionResponse
+// @Router /auth/sessions [get]
+func (h *HttpAuthHandler) FindSessions(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	sessions, err := h.authUseCase.FindSessions(c.UserContext(), fmt.Sprint(userID))
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToSessionResponseList(sessions, fmt.Sprint(c.Locals("session_id"))))
+}
+
+// RevokeSession godoc
+// @Summary Sign one of the current user's devices out
+// @Tags auth
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} responses.MessageResponse
+// @Router /auth/sessions/{id} [delete]
+func (h *HttpAuthHandler) RevokeSession(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	if err := h.authUseCase.RevokeSession(c.UserContext(), fmt.Sprint(userID), c.Params("id")); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Message(c, fiber.StatusOK, "session revoked")
+}
+
+// UnlockAccount godoc
+// @Summary Lift the sign-in lockout of an account
+// @Tags auth
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} responses.MessageResponse
+// @Router /users/{id}/unlock [post]
+func (h *HttpAuthHandler) UnlockAccount(c *fiber.Ctx) error {
+	if err := h.authUseCase.UnlockAccount(c.UserContext(), c.Params("id")); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Message(c, fiber.StatusOK, "account unlocked")
+}
+
+// deviceFromCtx names the device after the client's choice, falling back to its user agent
+func deviceFromCtx(c *fiber.Ctx, name string) usecase.Device {
+	if name == "" {
+		name = c.Get(fiber.HeaderUserAgent)
+	}
+	if len(name) > maxDeviceNameLength {
+		name = name[:maxDeviceNameLength]
+	}
+	return usecase.Device{Name: name, IPAddress: c.IP()}
+}
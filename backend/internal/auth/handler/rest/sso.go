@@ -0,0 +1,79 @@
+package rest
+
+import (
+	"path"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/auth/dto"
+	"github.com/ePSA-eJya/Mess_Management/internal/auth/usecase"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ssoStateCookie keeps the state of a sign-in in the browser that started it
+const ssoStateCookie = "sso_state"
+
+type HttpSSOHandler struct {
+	ssoUseCase   usecase.SSOUseCase
+	secureCookie bool
+}
+
+// NewHttpSSOHandler, secureCookie sends the state cookie over HTTPS only
+func NewHttpSSOHandler(useCase usecase.SSOUseCase, secureCookie bool) *HttpSSOHandler {
+	return &HttpSSOHandler{ssoUseCase: useCase, secureCookie: secureCookie}
+}
+
+// setStateCookie scopes the cookie to the SSO routes. SameSite Lax still sends
+// it on the top-level redirect back from the identity provider.
+func (h *HttpSSOHandler) setStateCookie(c *fiber.Ctx, value string, expires time.Time) {
+	c.Cookie(&fiber.Cookie{
+		Name:     ssoStateCookie,
+		Value:    value,
+		Path:     path.Dir(c.Path()),
+		Expires:  expires,
+		Secure:   h.secureCookie,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
+
+// Begin godoc
+// @Summary Redirect to the campus identity provider to sign in
+// @Tags auth
+// @Success 302
+// @Router /auth/oidc/login [get]
+func (h *HttpSSOHandler) Begin(c *fiber.Ctx) error {
+	authURL, state, err := h.ssoUseCase.Begin(c.UserContext())
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	h.setStateCookie(c, state, time.Now().Add(usecase.SSOStateTTL))
+	return c.Redirect(authURL, fiber.StatusFound)
+}
+
+// Callback godoc
+// @Summary Finish signing in with the campus identity provider
+// @Tags auth
+// @Produce json
+// @Param state query string true "State from the sign-in redirect"
+// @Param code query string true "Authorization code"
+// @Success 200 {object} dto.TokenResponse
+// @Router /auth/oidc/callback [get]
+func (h *HttpSSOHandler) Callback(c *fiber.Ctx) error {
+	boundState := c.Cookies(ssoStateCookie)
+	h.setStateCookie(c, "", time.Unix(0, 0))
+
+	if providerErr := c.Query("error"); providerErr != "" {
+		message := "sign-in was not completed at the identity provider: " + providerErr
+		return responses.ErrorWithMessage(c, apperror.ErrUnauthorized, message)
+	}
+
+	pair, user, err := h.ssoUseCase.Complete(c.UserContext(), c.Query("state"), boundState, c.Query("code"), deviceFromCtx(c, ""))
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToTokenResponse(pair, user))
+}
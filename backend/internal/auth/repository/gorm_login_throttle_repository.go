@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormLoginThrottleRepository struct {
+	db *gorm.DB
+}
+
+func NewGormLoginThrottleRepository(db *gorm.DB) LoginThrottleRepository {
+	return &GormLoginThrottleRepository{db: db}
+}
+
+func (r *GormLoginThrottleRepository) FindThrottles(ctx context.Context, keys ...string) ([]*entities.LoginThrottle, error) {
+	var throttleValues []entities.LoginThrottle
+	if err := r.db.WithContext(ctx).Where("key IN ?", keys).Find(&throttleValues).Error; err != nil {
+		return nil, err
+	}
+
+	throttles := make([]*entities.LoginThrottle, len(throttleValues))
+	for i := range throttleValues {
+		throttles[i] = &throttleValues[i]
+	}
+	return throttles, nil
+}
+
+func (r *GormLoginThrottleRepository) RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (*entities.LoginThrottle, error) {
+	throttle := &entities.LoginThrottle{Key: key, Failures: 1, LastFailureAt: now}
+	err := r.db.WithContext(ctx).Clauses(
+		clause.OnConflict{
+			Columns: []clause.Column{{Name: "key"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"failures": gorm.Expr(
+					"CASE WHEN login_throttles.last_failure_at < ? THEN 1 ELSE login_throttles.failures + 1 END",
+					now.Add(-window),
+				),
+				"last_failure_at": now,
+			}),
+		},
+		clause.Returning{},
+	).Create(throttle).Error
+	if err != nil {
+		return nil, err
+	}
+	return throttle, nil
+}
+
+func (r *GormLoginThrottleRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	return r.db.WithContext(ctx).Model(&entities.LoginThrottle{}).Where("key = ?", key).Update("locked_until", until).Error
+}
+
+func (r *GormLoginThrottleRepository) Clear(ctx context.Context, key string) error {
+	return r.db.WithContext(ctx).Where("key = ?", key).Delete(&entities.LoginThrottle{}).Error
+}
+
+func (r *GormLoginThrottleRepository) DeleteStale(before time.Time) (int64, error) {
+	result := r.db.
+		Where("last_failure_at < ? AND (locked_until IS NULL OR locked_until < ?)", before, before).
+		Delete(&entities.LoginThrottle{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"gorm.io/gorm"
+)
+
+type GormSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewGormSessionRepository(db *gorm.DB) SessionRepository {
+	return &GormSessionRepository{db: db}
+}
+
+func (r *GormSessionRepository) CreateSession(ctx context.Context, session *entities.Session, refresh *entities.RefreshToken) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		refresh.SessionID = session.ID
+		return tx.Create(refresh).Error
+	})
+}
+
+func (r *GormSessionRepository) FindSession(ctx context.Context, id string) (*entities.Session, error) {
+	var session entities.Session
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *GormSessionRepository) FindActiveSessions(ctx context.Context, userID string, now time.Time) ([]*entities.Session, error) {
+	var sessionValues []entities.Session
+	err := r.db.WithContext(ctx).Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_used_at DESC").
+		Find(&sessionValues).Error
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]*entities.Session, len(sessionValues))
+	for i := range sessionValues {
+		sessions[i] = &sessionValues[i]
+	}
+	return sessions, nil
+}
+
+func (r *GormSessionRepository) FindRefreshToken(ctx context.Context, tokenHash string) (*entities.RefreshToken, error) {
+	var refresh entities.RefreshToken
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&refresh).Error; err != nil {
+		return nil, err
+	}
+	return &refresh, nil
+}
+
+func (r *GormSessionRepository) Rotate(ctx context.Context, used *entities.RefreshToken, next *entities.RefreshToken, now time.Time) (bool, error) {
+	rotated := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL", used.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		rotated = true
+
+		next.SessionID = used.SessionID
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+		return tx.Model(&entities.Session{}).Where("id = ?", used.SessionID).Update("last_used_at", now).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return rotated, nil
+}
+
+func (r *GormSessionRepository) RevokeSession(ctx context.Context, id string, reason string, now time.Time) error {
+	result := r.db.WithContext(ctx).Model(&entities.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]any{"revoked_at": now, "revoked_reason": reason})
+	return result.Error
+}
+
+func (r *GormSessionRepository) RevokeUserSessions(ctx context.Context, userID string, reason string, now time.Time) (int64, error) {
+	return RevokeUserSessions(r.db.WithContext(ctx), userID, reason, now)
+}
+
+// RevokeUserSessions revokes every active session of the user through tx, so
+// other modules can sign a user out in the same transaction as their change
+func RevokeUserSessions(tx *gorm.DB, userID string, reason string, now time.Time) (int64, error) {
+	result := tx.Model(&entities.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]any{"revoked_at": now, "revoked_reason": reason})
+	return result.RowsAffected, result.Error
+}
+
+// DeleteExpired removes sessions, and their refresh tokens, that expired before the given time
+func (r *GormSessionRepository) DeleteExpired(before time.Time) (int64, error) {
+	var deleted int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		expired := tx.Model(&entities.Session{}).Select("id").Where("expires_at < ?", before)
+		if err := tx.Where("session_id IN (?)", expired).Delete(&entities.RefreshToken{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("expires_at < ?", before).Delete(&entities.Session{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	return deleted, err
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormSSORepository struct {
+	db *gorm.DB
+}
+
+func NewGormSSORepository(db *gorm.DB) SSORepository {
+	return &GormSSORepository{db: db}
+}
+
+func (r *GormSSORepository) SaveLoginState(ctx context.Context, state *entities.SSOLoginState) error {
+	return r.db.WithContext(ctx).Create(state).Error
+}
+
+func (r *GormSSORepository) ConsumeLoginState(ctx context.Context, stateHash string) (*entities.SSOLoginState, error) {
+	var states []entities.SSOLoginState
+	err := r.db.WithContext(ctx).Clauses(clause.Returning{}).
+		Where("state_hash = ?", stateHash).
+		Delete(&states).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(states) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &states[0], nil
+}
+
+func (r *GormSSORepository) DeleteExpiredLoginStates(before time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", before).Delete(&entities.SSOLoginState{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *GormSSORepository) FindIdentity(ctx context.Context, issuer, subject string) (*entities.ExternalIdentity, error) {
+	var identity entities.ExternalIdentity
+	if err := r.db.WithContext(ctx).Where("issuer = ? AND subject = ?", issuer, subject).First(&identity).Error; err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (r *GormSSORepository) SaveIdentity(ctx context.Context, identity *entities.ExternalIdentity) error {
+	return r.db.WithContext(ctx).Create(identity).Error
+}
+
+func (r *GormSSORepository) ClaimUnverifiedUser(ctx context.Context, identity *entities.ExternalIdentity, reason string, now time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.User{}).
+			Where("id = ?", identity.UserID).
+			UpdateColumns(map[string]any{
+				"password":          "",
+				"email_verified_at": now,
+				"version":           gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if _, err := RevokeUserSessions(tx, identity.UserID.String(), reason, now); err != nil {
+			return err
+		}
+		return tx.Create(identity).Error
+	})
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"gorm.io/gorm"
+)
+
+type GormUserTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewGormUserTokenRepository(db *gorm.DB) UserTokenRepository {
+	return &GormUserTokenRepository{db: db}
+}
+
+func (r *GormUserTokenRepository) Save(ctx context.Context, userToken *entities.UserToken) error {
+	return r.db.WithContext(ctx).Create(userToken).Error
+}
+
+func (r *GormUserTokenRepository) FindByHash(ctx context.Context, tokenHash string, purpose entities.UserTokenPurpose) (*entities.UserToken, error) {
+	var userToken entities.UserToken
+	if err := r.db.WithContext(ctx).Where("token_hash = ? AND purpose = ?", tokenHash, purpose).First(&userToken).Error; err != nil {
+		return nil, err
+	}
+	return &userToken, nil
+}
+
+func (r *GormUserTokenRepository) Consume(ctx context.Context, id uint, now time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&entities.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *GormUserTokenRepository) InvalidateUserTokens(ctx context.Context, userID string, purpose entities.UserTokenPurpose, now time.Time) error {
+	return r.db.WithContext(ctx).Model(&entities.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", now).Error
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+)
+
+// LoginThrottleRepository counts within the sign-in request's ctx, except
+// DeleteStale which the cleanup job runs on its own.
+type LoginThrottleRepository interface {
+	FindThrottles(ctx context.Context, keys ...string) ([]*entities.LoginThrottle, error)
+	// RecordFailure counts one more failure for key, starting over when the previous
+	// one is older than window, and returns the updated counter
+	RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (*entities.LoginThrottle, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	Clear(ctx context.Context, key string) error
+	// DeleteStale drops counters with no failure and no lock since before
+	DeleteStale(before time.Time) (int64, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+)
+
+// SessionRepository queries are traced under the request that makes them,
+// DeleteExpired is called by the cleanup job only and takes no ctx.
+type SessionRepository interface {
+	// CreateSession stores a new session together with its first refresh token
+	CreateSession(ctx context.Context, session *entities.Session, refresh *entities.RefreshToken) error
+	FindSession(ctx context.Context, id string) (*entities.Session, error)
+	FindActiveSessions(ctx context.Context, userID string, now time.Time) ([]*entities.Session, error)
+	FindRefreshToken(ctx context.Context, tokenHash string) (*entities.RefreshToken, error)
+	// Rotate marks used as spent and stores next in its place, it returns false
+	// when used was already spent by a concurrent request
+	Rotate(ctx context.Context, used *entities.RefreshToken, next *entities.RefreshToken, now time.Time) (bool, error)
+	RevokeSession(ctx context.Context, id string, reason string, now time.Time) error
+	RevokeUserSessions(ctx context.Context, userID string, reason string, now time.Time) (int64, error)
+	DeleteExpired(before time.Time) (int64, error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+)
+
+// SSORepository queries run within ctx apart from DeleteExpiredLoginStates,
+// which only the cleanup job calls.
+type SSORepository interface {
+	SaveLoginState(ctx context.Context, state *entities.SSOLoginState) error
+	// ConsumeLoginState deletes and returns the state, so each one is redeemed once
+	ConsumeLoginState(ctx context.Context, stateHash string) (*entities.SSOLoginState, error)
+	DeleteExpiredLoginStates(before time.Time) (int64, error)
+	FindIdentity(ctx context.Context, issuer, subject string) (*entities.ExternalIdentity, error)
+	SaveIdentity(ctx context.Context, identity *entities.ExternalIdentity) error
+	// ClaimUnverifiedUser links the identity to its user in one transaction with
+	// marking the email verified, clearing the password and revoking every session
+	ClaimUnverifiedUser(ctx context.Context, identity *entities.ExternalIdentity, reason string, now time.Time) error
+}
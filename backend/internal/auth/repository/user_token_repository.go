@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+)
+
+type UserTokenRepository interface {
+	Save(ctx context.Context, userToken *entities.UserToken) error
+	FindByHash(ctx context.Context, tokenHash string, purpose entities.UserTokenPurpose) (*entities.UserToken, error)
+	// Consume marks the token used, it returns false when it already was
+	Consume(ctx context.Context, id uint, now time.Time) (bool, error)
+	// InvalidateUserTokens spends every unused token of the user for purpose
+	InvalidateUserTokens(ctx context.Context, userID string, purpose entities.UserTokenPurpose, now time.Time) error
+}
@@ -0,0 +1,283 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/auth/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	userUseCase "github.com/ePSA-eJya/Mess_Management/internal/user/usecase"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/mailer"
+	"github.com/ePSA-eJya/Mess_Management/pkg/token"
+	"github.com/ePSA-eJya/Mess_Management/pkg/tracing"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	RevokedPasswordReset = "PASSWORD_RESET"
+
+	minPasswordLength = 6
+)
+
+var (
+	ErrInvalidUserToken = fmt.Errorf("%w: invalid or expired token", apperror.ErrInvalidData)
+	ErrWeakPassword     = fmt.Errorf("%w: password must be at least %d characters", apperror.ErrInvalidData, minPasswordLength)
+)
+
+// AccountConfig controls the emailed links and how long they stay valid
+type AccountConfig struct {
+	LinkBaseURL     string // the frontend pages that take the token, e.g. https://mess.example.com
+	VerificationTTL time.Duration
+	ResetTTL        time.Duration
+}
+
+// AccountService
+type AccountService struct {
+	users       userUseCase.UserUseCase
+	userRepo    userRepository.UserRepository
+	tokenRepo   repository.UserTokenRepository
+	sessionRepo repository.SessionRepository
+	sender      mailer.Sender
+	cfg         AccountConfig
+}
+
+// Init AccountService
+func NewAccountService(
+	users userUseCase.UserUseCase,
+	userRepo userRepository.UserRepository,
+	tokenRepo repository.UserTokenRepository,
+	sessionRepo repository.SessionRepository,
+	sender mailer.Sender,
+	cfg AccountConfig,
+) AccountUseCase {
+	return &AccountService{
+		users:       users,
+		userRepo:    userRepo,
+		tokenRepo:   tokenRepo,
+		sessionRepo: sessionRepo,
+		sender:      sender,
+		cfg:         cfg,
+	}
+}
+
+// AccountService Methods - 1 create the account and email a verification link. A
+// failed delivery does not undo the signup, the user can ask for another link.
+func (s *AccountService) Register(ctx context.Context, user *entities.User) (err error) {
+	ctx, span := tracing.Start(ctx, "AccountService.Register")
+	defer func() { tracing.End(span, err) }()
+
+	if len(user.Password) < minPasswordLength {
+		return ErrWeakPassword
+	}
+	user.EmailVerifiedAt = nil
+	if err := s.users.Register(ctx, user); err != nil {
+		return err
+	}
+
+	if err := s.sendVerification(ctx, user); err != nil {
+		log.ErrorContext(ctx, "Sending verification email failed", "user_id", user.ID, "error", err)
+	}
+	return nil
+}
+
+// AccountService Methods - 2 mark the address behind the token as verified
+func (s *AccountService) VerifyEmail(ctx context.Context, rawToken string) (err error) {
+	ctx, span := tracing.Start(ctx, "AccountService.VerifyEmail")
+	defer func() { tracing.End(span, err) }()
+
+	userToken, err := s.consume(ctx, rawToken, entities.TokenEmailVerification)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userToken.UserID.String())
+	if err != nil {
+		return ErrInvalidUserToken
+	}
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	return s.userRepo.Patch(ctx, user.ID.String(), &entities.User{EmailVerifiedAt: &now}, 0, nil)
+}
+
+// AccountService Methods - 3 email a fresh verification link. Unknown and already
+// verified addresses are silently ignored so the endpoint cannot probe for accounts,
+// and for the same reason a failed delivery is only logged.
+func (s *AccountService) ResendVerification(ctx context.Context, email string) (err error) {
+	ctx, span := tracing.Start(ctx, "AccountService.ResendVerification")
+	defer func() { tracing.End(span, err) }()
+
+	user, err := s.findByEmail(ctx, email)
+	if err != nil || user == nil || user.EmailVerifiedAt != nil {
+		return err
+	}
+
+	err = s.tokenRepo.InvalidateUserTokens(ctx, user.ID.String(), entities.TokenEmailVerification, time.Now())
+	if err == nil {
+		err = s.sendVerification(ctx, user)
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "Sending verification email failed", "user_id", user.ID, "error", err)
+	}
+	return nil
+}
+
+// AccountService Methods - 4 email a password reset link. Unknown addresses are
+// silently ignored and failed deliveries only logged, so both answer the same.
+func (s *AccountService) ForgotPassword(ctx context.Context, email string) (err error) {
+	ctx, span := tracing.Start(ctx, "AccountService.ForgotPassword")
+	defer func() { tracing.End(span, err) }()
+
+	user, err := s.findByEmail(ctx, email)
+	if err != nil || user == nil {
+		return err
+	}
+
+	if err := s.sendPasswordReset(ctx, user); err != nil {
+		log.ErrorContext(ctx, "Sending password reset email failed", "user_id", user.ID, "error", err)
+	}
+	return nil
+}
+
+func (s *AccountService) sendPasswordReset(ctx context.Context, user *entities.User) error {
+	if err := s.tokenRepo.InvalidateUserTokens(ctx, user.ID.String(), entities.TokenPasswordReset, time.Now()); err != nil {
+		return err
+	}
+	rawToken, err := s.newUserToken(ctx, user, entities.TokenPasswordReset, s.cfg.ResetTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.sender.Send(mailer.Message{
+		To:      []string{user.Email},
+		Subject: "Reset your Mess Management password",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nUse the link below to choose a new password. It expires in %s.\n\n%s\n\nIf you did not ask for this you can ignore this email.",
+			user.Name, s.cfg.ResetTTL, s.link("reset-password", rawToken),
+		),
+	})
+}
+
+// AccountService Methods - 5 set a new password with a reset token and sign every device out
+func (s *AccountService) ResetPassword(ctx context.Context, rawToken, newPassword string) (err error) {
+	ctx, span := tracing.Start(ctx, "AccountService.ResetPassword")
+	defer func() { tracing.End(span, err) }()
+
+	if len(newPassword) < minPasswordLength {
+		return ErrWeakPassword
+	}
+
+	userToken, err := s.consume(ctx, rawToken, entities.TokenPasswordReset)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userToken.UserID.String())
+	if err != nil {
+		return ErrInvalidUserToken
+	}
+
+	hashedPwd, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	patch := &entities.User{Password: string(hashedPwd)}
+	if user.EmailVerifiedAt == nil {
+		// the reset link reached the inbox, which proves the address as well
+		patch.EmailVerifiedAt = &now
+	}
+	if err := s.userRepo.Patch(ctx, user.ID.String(), patch, 0, nil); err != nil {
+		return err
+	}
+
+	if err := s.tokenRepo.InvalidateUserTokens(ctx, user.ID.String(), entities.TokenPasswordReset, now); err != nil {
+		return err
+	}
+	_, err = s.sessionRepo.RevokeUserSessions(ctx, user.ID.String(), RevokedPasswordReset, now)
+	return err
+}
+
+// consume spends a valid token, any unknown, expired or used token is rejected alike
+func (s *AccountService) consume(ctx context.Context, rawToken string, purpose entities.UserTokenPurpose) (*entities.UserToken, error) {
+	if rawToken == "" {
+		return nil, ErrInvalidUserToken
+	}
+
+	userToken, err := s.tokenRepo.FindByHash(ctx, token.Hash(rawToken), purpose)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidUserToken
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	if userToken.UsedAt != nil || !now.Before(userToken.ExpiresAt) {
+		return nil, ErrInvalidUserToken
+	}
+
+	consumed, err := s.tokenRepo.Consume(ctx, userToken.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, ErrInvalidUserToken
+	}
+	return userToken, nil
+}
+
+func (s *AccountService) findByEmail(ctx context.Context, email string) (*entities.User, error) {
+	user, err := s.userRepo.FindByEmail(ctx, strings.TrimSpace(email))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (s *AccountService) sendVerification(ctx context.Context, user *entities.User) error {
+	rawToken, err := s.newUserToken(ctx, user, entities.TokenEmailVerification, s.cfg.VerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.sender.Send(mailer.Message{
+		To:      []string{user.Email},
+		Subject: "Verify your Mess Management email address",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nConfirm your email address to finish signing up. The link expires in %s.\n\n%s",
+			user.Name, s.cfg.VerificationTTL, s.link("verify-email", rawToken),
+		),
+	})
+}
+
+func (s *AccountService) newUserToken(ctx context.Context, user *entities.User, purpose entities.UserTokenPurpose, ttl time.Duration) (string, error) {
+	rawToken, err := token.NewOpaque()
+	if err != nil {
+		return "", err
+	}
+
+	userToken := &entities.UserToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: token.Hash(rawToken),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.tokenRepo.Save(ctx, userToken); err != nil {
+		return "", err
+	}
+	return rawToken, nil
+}
+
+func (s *AccountService) link(page, rawToken string) string {
+	return fmt.Sprintf("%s/%s?token=%s", strings.TrimRight(s.cfg.LinkBaseURL, "/"), page, rawToken)
+}
@@ -0,0 +1,193 @@
+package usecase_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/auth/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/auth/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	userUseCase "github.com/ePSA-eJya/Mess_Management/internal/user/usecase"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/mailer"
+	"github.com/ePSA-eJya/Mess_Management/pkg/token"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type AccountUseCaseTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	mail    *mailer.CaptureSender
+	service usecase.AccountUseCase
+	auth    usecase.AuthUseCase
+	cleanup func()
+}
+
+func (s *AccountUseCaseTestSuite) SetupTest() {
+	s.db, s.cleanup = database.SetupTestDB(s.T())
+
+	userRepo := userRepository.NewGormUserRepository(s.db)
+	sessionRepo := repository.NewGormSessionRepository(s.db)
+	s.mail = mailer.NewCaptureSender(false)
+	s.service = usecase.NewAccountService(
+		userUseCase.NewUserService(userRepo, time.Hour),
+		userRepo,
+		repository.NewGormUserTokenRepository(s.db),
+		sessionRepo,
+		s.mail,
+		usecase.AccountConfig{LinkBaseURL: "https://mess.example.com/", VerificationTTL: 48 * time.Hour, ResetTTL: time.Hour},
+	)
+	s.auth = usecase.NewAuthService(
+		userRepo,
+		sessionRepo,
+		repository.NewGormLoginThrottleRepository(s.db),
+		token.NewManager("test-secret-key-for-jwt-token-generation", 15*time.Minute),
+		24*time.Hour,
+		testPolicy,
+	)
+}
+
+func (s *AccountUseCaseTestSuite) TearDownTest() {
+	if s.cleanup != nil {
+		s.cleanup()
+	}
+}
+
+func TestAccountUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountUseCaseTestSuite))
+}
+
+// linkToken pulls the token out of the last link emailed to the address
+func (s *AccountUseCaseTestSuite) linkToken(email, page string) string {
+	msg, ok := s.mail.Last(email)
+	s.Require().True(ok, "no email sent to %s", email)
+	prefix := "https://mess.example.com/" + page + "?token="
+	start := strings.Index(msg.Body, prefix)
+	s.Require().GreaterOrEqual(start, 0, "no %s link in %q", page, msg.Body)
+	return strings.Fields(msg.Body[start+len(prefix):])[0]
+}
+
+func (s *AccountUseCaseTestSuite) register(email string) *entities.User {
+	user := &entities.User{Email: email, Password: "password123", Name: "New User"}
+	s.Require().NoError(s.service.Register(ctx, user))
+	return user
+}
+
+func (s *AccountUseCaseTestSuite) TestRegister_SendsVerification() {
+	s.register("new@example.com")
+
+	_, _, err := s.auth.Login(ctx, "new@example.com", "password123", phone)
+	s.ErrorIs(err, apperror.ErrForbidden)
+
+	s.NoError(s.service.VerifyEmail(ctx, s.linkToken("new@example.com", "verify-email")))
+
+	_, _, err = s.auth.Login(ctx, "new@example.com", "password123", phone)
+	s.NoError(err)
+}
+
+func (s *AccountUseCaseTestSuite) TestRegister_WeakPassword() {
+	err := s.service.Register(ctx, &entities.User{Email: "weak@example.com", Password: "123", Name: "Weak"})
+	s.ErrorIs(err, apperror.ErrInvalidData)
+	s.Empty(s.mail.Messages())
+}
+
+func (s *AccountUseCaseTestSuite) TestVerifyEmail_SingleUse() {
+	s.register("once@example.com")
+	rawToken := s.linkToken("once@example.com", "verify-email")
+
+	s.NoError(s.service.VerifyEmail(ctx, rawToken))
+	s.ErrorIs(s.service.VerifyEmail(ctx, rawToken), usecase.ErrInvalidUserToken)
+	s.ErrorIs(s.service.VerifyEmail(ctx, "made-up"), usecase.ErrInvalidUserToken)
+}
+
+func (s *AccountUseCaseTestSuite) TestVerifyEmail_Expired() {
+	s.register("late@example.com")
+	rawToken := s.linkToken("late@example.com", "verify-email")
+	s.Require().NoError(s.db.Model(&entities.UserToken{}).
+		Where("token_hash = ?", token.Hash(rawToken)).
+		Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+	s.ErrorIs(s.service.VerifyEmail(ctx, rawToken), usecase.ErrInvalidUserToken)
+}
+
+func (s *AccountUseCaseTestSuite) TestResendVerification_ReplacesOldLink() {
+	s.register("resend@example.com")
+	first := s.linkToken("resend@example.com", "verify-email")
+
+	s.NoError(s.service.ResendVerification(ctx, "resend@example.com"))
+	second := s.linkToken("resend@example.com", "verify-email")
+	s.NotEqual(first, second)
+
+	s.ErrorIs(s.service.VerifyEmail(ctx, first), usecase.ErrInvalidUserToken)
+	s.NoError(s.service.VerifyEmail(ctx, second))
+}
+
+func (s *AccountUseCaseTestSuite) TestForgotPassword_UnknownEmail() {
+	s.NoError(s.service.ForgotPassword(ctx, "nobody@example.com"))
+	s.Empty(s.mail.Messages())
+}
+
+type failingSender struct{}
+
+func (failingSender) Send(mailer.Message) error {
+	return errors.New("smtp: connection refused")
+}
+
+func (s *AccountUseCaseTestSuite) TestForgotPassword_DeliveryFailure() {
+	s.register("down@example.com")
+	userRepo := userRepository.NewGormUserRepository(s.db)
+	service := usecase.NewAccountService(
+		userUseCase.NewUserService(userRepo, time.Hour),
+		userRepo,
+		repository.NewGormUserTokenRepository(s.db),
+		repository.NewGormSessionRepository(s.db),
+		failingSender{},
+		usecase.AccountConfig{LinkBaseURL: "https://mess.example.com/", VerificationTTL: 48 * time.Hour, ResetTTL: time.Hour},
+	)
+
+	// an existing account answers exactly like an unknown address
+	s.NoError(service.ForgotPassword(ctx, "down@example.com"))
+	s.NoError(service.ResendVerification(ctx, "down@example.com"))
+}
+
+func (s *AccountUseCaseTestSuite) TestResetPassword() {
+	user := s.register("forgot@example.com")
+	s.NoError(s.service.VerifyEmail(ctx, s.linkToken("forgot@example.com", "verify-email")))
+	pair, _, err := s.auth.Login(ctx, "forgot@example.com", "password123", phone)
+	s.Require().NoError(err)
+
+	s.NoError(s.service.ForgotPassword(ctx, "forgot@example.com"))
+	rawToken := s.linkToken("forgot@example.com", "reset-password")
+
+	s.ErrorIs(s.service.ResetPassword(ctx, rawToken, "short"), apperror.ErrInvalidData)
+	s.NoError(s.service.ResetPassword(ctx, rawToken, "newpassword456"))
+	s.ErrorIs(s.service.ResetPassword(ctx, rawToken, "another789"), usecase.ErrInvalidUserToken)
+
+	_, _, err = s.auth.Login(ctx, "forgot@example.com", "password123", phone)
+	s.ErrorIs(err, apperror.ErrUnauthorized)
+	_, _, err = s.auth.Login(ctx, "forgot@example.com", "newpassword456", phone)
+	s.NoError(err)
+
+	// sessions from before the reset are signed out
+	_, err = s.auth.Refresh(ctx, pair.RefreshToken)
+	s.ErrorIs(err, apperror.ErrUnauthorized)
+
+	sessions, err := s.auth.FindSessions(ctx, user.ID.String())
+	s.NoError(err)
+	s.Len(sessions, 1)
+}
+
+func (s *AccountUseCaseTestSuite) TestResetPassword_VerifiesEmail() {
+	s.register("unverified@example.com")
+
+	s.NoError(s.service.ForgotPassword(ctx, "unverified@example.com"))
+	s.NoError(s.service.ResetPassword(ctx, s.linkToken("unverified@example.com", "reset-password"), "newpassword456"))
+
+	_, _, err := s.auth.Login(ctx, "unverified@example.com", "newpassword456", phone)
+	s.NoError(err)
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/token"
+)
+
+// Device describes where a session was started or refreshed from
+type Device struct {
+	Name      string
+	IPAddress string
+}
+
+// TokenPair is a short-lived access token and the refresh token that renews it
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int // access token lifetime in seconds
+	SessionID    string
+}
+
+type AuthUseCase interface {
+	Login(ctx context.Context, email, password string, device Device) (*TokenPair, *entities.User, error)
+	StartSession(ctx context.Context, user *entities.User, device Device) (*TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (*TokenPair, error)
+	Logout(ctx context.Context, sessionID string) error
+	LogoutAll(ctx context.Context, userID string) error
+	FindSessions(ctx context.Context, userID string) ([]*entities.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID string) error
+	IsRevoked(ctx context.Context, claims *token.Claims) (bool, error)
+	UnlockAccount(ctx context.Context, userID string) error
+}
+
+// AccountUseCase covers the emailed account flows, proving ownership of the
+// address on signup and recovering a forgotten password
+type AccountUseCase interface {
+	Register(ctx context.Context, user *entities.User) error
+	VerifyEmail(ctx context.Context, rawToken string) error
+	ResendVerification(ctx context.Context, email string) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, rawToken, newPassword string) error
+}
+
+// SSOUseCase signs users in through the campus identity provider
+type SSOUseCase interface {
+	// Begin returns the provider URL to send the browser to and the state the
+	// browser must keep until the callback
+	Begin(ctx context.Context) (authURL string, state string, err error)
+	// Complete finishes the sign-in from the provider's callback parameters and
+	// the state the browser kept from Begin
+	Complete(ctx context.Context, state, boundState, code string, device Device) (*TokenPair, *entities.User, error)
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/auth/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	studentRepository "github.com/ePSA-eJya/Mess_Management/internal/student/repository"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/oidc"
+	"github.com/ePSA-eJya/Mess_Management/pkg/token"
+	"github.com/ePSA-eJya/Mess_Management/pkg/tracing"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SSOStateTTL is how long the user may take at the identity provider
+const SSOStateTTL = 10 * time.Minute
+
+// RevokedSSOLink revokes the sessions of an unverified account taken over by its SSO identity
+const RevokedSSOLink = "SSO_LINK"
+
+var (
+	ErrInvalidSSOState    = fmt.Errorf("%w: sign-in attempt is unknown or expired, start again", apperror.ErrUnauthorized)
+	ErrSSOEmailUnverified = fmt.Errorf("%w: identity provider has not verified the email address", apperror.ErrForbidden)
+	ErrSSONoAccount       = fmt.Errorf("%w: no account or student record for this email address", apperror.ErrForbidden)
+)
+
+// SSOService
+type SSOService struct {
+	client      *oidc.Client
+	ssoRepo     repository.SSORepository
+	userRepo    userRepository.UserRepository
+	studentRepo studentRepository.StudentRepository
+	auth        AuthUseCase
+}
+
+// Init SSOService, sessions are started through auth so they behave like password sign-ins
+func NewSSOService(
+	client *oidc.Client,
+	ssoRepo repository.SSORepository,
+	userRepo userRepository.UserRepository,
+	studentRepo studentRepository.StudentRepository,
+	auth AuthUseCase,
+) SSOUseCase {
+	return &SSOService{
+		client:      client,
+		ssoRepo:     ssoRepo,
+		userRepo:    userRepo,
+		studentRepo: studentRepo,
+		auth:        auth,
+	}
+}
+
+// SSOService Methods - 1 remember a new attempt and build the provider URL for it.
+// The state is returned as well, the browser must keep it to complete the attempt.
+func (s *SSOService) Begin(ctx context.Context) (_ string, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "SSOService.Begin")
+	defer func() { tracing.End(span, err) }()
+
+	state, err := token.NewOpaque()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := token.NewOpaque()
+	if err != nil {
+		return "", "", err
+	}
+	verifier := oidc.NewCodeVerifier()
+
+	authURL, err := s.client.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %v", apperror.ErrDependencyFail, err)
+	}
+
+	loginState := &entities.SSOLoginState{
+		StateHash:    token.Hash(state),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    time.Now().Add(SSOStateTTL),
+	}
+	if err := s.ssoRepo.SaveLoginState(ctx, loginState); err != nil {
+		return "", "", err
+	}
+	return authURL, state, nil
+}
+
+// SSOService Methods - 2 redeem the code, map the identity to a user and start a session.
+// boundState is the state the browser kept from Begin, a callback carrying any other
+// state was started in another browser and is refused.
+func (s *SSOService) Complete(ctx context.Context, state, boundState, code string, device Device) (_ *TokenPair, _ *entities.User, err error) {
+	ctx, span := tracing.Start(ctx, "SSOService.Complete")
+	defer func() { tracing.End(span, err) }()
+
+	if state == "" || code == "" || subtle.ConstantTimeCompare([]byte(state), []byte(boundState)) != 1 {
+		return nil, nil, ErrInvalidSSOState
+	}
+
+	loginState, err := s.ssoRepo.ConsumeLoginState(ctx, token.Hash(state))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInvalidSSOState
+		}
+		return nil, nil, err
+	}
+	if !time.Now().Before(loginState.ExpiresAt) {
+		return nil, nil, ErrInvalidSSOState
+	}
+
+	identity, err := s.client.Exchange(ctx, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		if errors.Is(err, oidc.ErrExchangeFailed) || errors.Is(err, oidc.ErrInvalidIDToken) {
+			log.WarnContext(ctx, "SSO sign-in rejected", "error", err)
+			return nil, nil, apperror.ErrUnauthorized
+		}
+		return nil, nil, fmt.Errorf("%w: %v", apperror.ErrDependencyFail, err)
+	}
+
+	user, err := s.resolveUser(ctx, identity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pair, err := s.auth.StartSession(ctx, user, device)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pair, user, nil
+}
+
+// resolveUser finds the user linked to the identity. On first sign-in it links the
+// user with the same email, or creates a student account from the student record.
+func (s *SSOService) resolveUser(ctx context.Context, identity *oidc.Identity) (*entities.User, error) {
+	link, err := s.ssoRepo.FindIdentity(ctx, identity.Issuer, identity.Subject)
+	if err == nil {
+		return s.userRepo.FindByID(ctx, link.UserID.String())
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	email := strings.TrimSpace(identity.Email)
+	if email == "" || !identity.EmailVerified {
+		return nil, ErrSSOEmailUnverified
+	}
+
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	now := time.Now()
+	if user == nil {
+		if user, err = s.createStudentUser(ctx, email, identity.Name, now); err != nil {
+			return nil, err
+		}
+	}
+
+	link = &entities.ExternalIdentity{
+		UserID:  user.ID,
+		Issuer:  identity.Issuer,
+		Subject: identity.Subject,
+		Email:   email,
+	}
+	if user.EmailVerifiedAt != nil {
+		if err := s.ssoRepo.SaveIdentity(ctx, link); err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+
+	// the provider vouched for the address, whoever registered it did not, so their
+	// password and sessions go and a password can be set through the reset flow
+	if err := s.ssoRepo.ClaimUnverifiedUser(ctx, link, RevokedSSOLink, now); err != nil {
+		return nil, err
+	}
+	user.Password = ""
+	user.EmailVerifiedAt = &now
+	return user, nil
+}
+
+// createStudentUser creates the account of a student signing in for the first time.
+// It has no password, one can be set later through the password reset flow.
+func (s *SSOService) createStudentUser(ctx context.Context, email, name string, now time.Time) (*entities.User, error) {
+	student, err := s.studentRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSSONoAccount
+		}
+		return nil, err
+	}
+
+	if student.Name != "" {
+		name = student.Name
+	}
+	user := &entities.User{
+		Email:           student.Email,
+		Name:            name,
+		Role:            entities.RoleStudent,
+		EmailVerifiedAt: &now,
+	}
+	user.ID = uuid.New()
+	audit, err := entities.NewAuditLog(entities.AuditActor{UserID: user.ID.String()}, entities.AuditUserCreated, "user", user.ID.String(), nil, user)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.Save(ctx, user, audit); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,175 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/auth/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/auth/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	studentRepository "github.com/ePSA-eJya/Mess_Management/internal/student/repository"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/oidc"
+	"github.com/ePSA-eJya/Mess_Management/pkg/oidc/oidctest"
+	"github.com/ePSA-eJya/Mess_Management/pkg/token"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type SSOUseCaseTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	idp     *oidctest.Server
+	tokens  *token.Manager
+	service usecase.SSOUseCase
+	cleanup func()
+}
+
+func (s *SSOUseCaseTestSuite) SetupTest() {
+	s.db, s.cleanup = database.SetupTestDB(s.T())
+	s.idp = oidctest.NewServer("mess", "mess-secret")
+
+	userRepo := userRepository.NewGormUserRepository(s.db)
+	s.tokens = token.NewManager("test-secret-key-for-jwt-token-generation", 15*time.Minute)
+	auth := usecase.NewAuthService(
+		userRepo,
+		repository.NewGormSessionRepository(s.db),
+		repository.NewGormLoginThrottleRepository(s.db),
+		s.tokens,
+		24*time.Hour,
+		testPolicy,
+	)
+	client := oidc.NewClient(oidc.Config{
+		IssuerURL:    s.idp.URL,
+		ClientID:     "mess",
+		ClientSecret: "mess-secret",
+		RedirectURL:  "http://localhost:8000/api/v1/auth/oidc/callback",
+	}, nil)
+	s.service = usecase.NewSSOService(
+		client,
+		repository.NewGormSSORepository(s.db),
+		userRepo,
+		studentRepository.NewGormStudentRepository(s.db),
+		auth,
+	)
+
+	s.Require().NoError(s.db.Create(&entities.Student{
+		Roll: 2101, Name: "Asha Rao", Hostel: "H1", RoomNo: 12, MessNo: 1, Email: "asha@campus.edu",
+	}).Error)
+}
+
+func (s *SSOUseCaseTestSuite) TearDownTest() {
+	s.idp.Close()
+	if s.cleanup != nil {
+		s.cleanup()
+	}
+}
+
+func TestSSOUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(SSOUseCaseTestSuite))
+}
+
+// signIn runs the browser round trip and returns the callback parameters
+func (s *SSOUseCaseTestSuite) signIn(identity oidctest.Identity) (state, code string) {
+	s.idp.SignInAs(identity)
+	authURL, bound, err := s.service.Begin(context.Background())
+	s.Require().NoError(err)
+
+	callback, err := s.idp.Authorize(authURL)
+	s.Require().NoError(err)
+	s.Require().Equal(bound, callback.Query().Get("state"))
+	return callback.Query().Get("state"), callback.Query().Get("code")
+}
+
+func (s *SSOUseCaseTestSuite) TestComplete_CreatesStudentUser() {
+	state, code := s.signIn(oidctest.Identity{Subject: "idp-1", Email: "asha@campus.edu", EmailVerified: true, Name: "A. Rao"})
+
+	pair, user, err := s.service.Complete(context.Background(), state, state, code, phone)
+	s.Require().NoError(err)
+	s.Equal("asha@campus.edu", user.Email)
+	s.Equal("Asha Rao", user.Name)
+	s.Equal(entities.RoleStudent, user.Role)
+	s.NotNil(user.EmailVerifiedAt)
+
+	claims, err := s.tokens.Parse(pair.AccessToken)
+	s.NoError(err)
+	s.Equal(user.ID.String(), claims.UserID)
+	s.Equal(pair.SessionID, claims.SessionID)
+
+	// the second sign-in finds the linked user
+	state, code = s.signIn(oidctest.Identity{Subject: "idp-1", Email: "asha@campus.edu", EmailVerified: true})
+	_, again, err := s.service.Complete(context.Background(), state, state, code, phone)
+	s.NoError(err)
+	s.Equal(user.ID, again.ID)
+}
+
+func (s *SSOUseCaseTestSuite) TestComplete_LinksExistingUser() {
+	existing := &entities.User{Email: "warden@campus.edu", Name: "Warden", Role: entities.RoleOfficeAdmin}
+	s.Require().NoError(s.db.Create(existing).Error)
+
+	state, code := s.signIn(oidctest.Identity{Subject: "idp-2", Email: "warden@campus.edu", EmailVerified: true})
+	_, user, err := s.service.Complete(context.Background(), state, state, code, phone)
+	s.Require().NoError(err)
+	s.Equal(existing.ID, user.ID)
+	s.Equal(entities.RoleOfficeAdmin, user.Role)
+	s.NotNil(user.EmailVerifiedAt)
+}
+
+func (s *SSOUseCaseTestSuite) TestComplete_ClaimsUnverifiedUser() {
+	// registered by someone who never proved they own the address
+	squatter := &entities.User{Email: "asha@campus.edu", Password: "hashed", Name: "Asha", Role: entities.RoleStudent}
+	s.Require().NoError(s.db.Create(squatter).Error)
+	session := &entities.Session{UserID: squatter.ID, ExpiresAt: time.Now().Add(time.Hour)}
+	s.Require().NoError(s.db.Create(session).Error)
+
+	state, code := s.signIn(oidctest.Identity{Subject: "idp-5", Email: "asha@campus.edu", EmailVerified: true})
+	_, user, err := s.service.Complete(context.Background(), state, state, code, phone)
+	s.Require().NoError(err)
+	s.Equal(squatter.ID, user.ID)
+
+	var stored entities.User
+	s.Require().NoError(s.db.First(&stored, "id = ?", squatter.ID).Error)
+	s.Empty(stored.Password)
+	s.NotNil(stored.EmailVerifiedAt)
+
+	s.Require().NoError(s.db.First(session, "id = ?", session.ID).Error)
+	s.NotNil(session.RevokedAt)
+	s.Equal(usecase.RevokedSSOLink, session.RevokedReason)
+}
+
+func (s *SSOUseCaseTestSuite) TestComplete_OtherBrowser() {
+	state, code := s.signIn(oidctest.Identity{Subject: "idp-1", Email: "asha@campus.edu", EmailVerified: true})
+
+	// a callback forwarded to a browser that never started the sign-in
+	_, _, err := s.service.Complete(context.Background(), state, "", code, phone)
+	s.ErrorIs(err, usecase.ErrInvalidSSOState)
+}
+
+func (s *SSOUseCaseTestSuite) TestComplete_NoAccount() {
+	state, code := s.signIn(oidctest.Identity{Subject: "idp-3", Email: "visitor@campus.edu", EmailVerified: true})
+
+	_, _, err := s.service.Complete(context.Background(), state, state, code, phone)
+	s.ErrorIs(err, usecase.ErrSSONoAccount)
+}
+
+func (s *SSOUseCaseTestSuite) TestComplete_UnverifiedEmail() {
+	state, code := s.signIn(oidctest.Identity{Subject: "idp-4", Email: "asha@campus.edu"})
+
+	_, _, err := s.service.Complete(context.Background(), state, state, code, phone)
+	s.ErrorIs(err, apperror.ErrForbidden)
+}
+
+func (s *SSOUseCaseTestSuite) TestComplete_StateIsSingleUse() {
+	state, code := s.signIn(oidctest.Identity{Subject: "idp-1", Email: "asha@campus.edu", EmailVerified: true})
+
+	_, _, err := s.service.Complete(context.Background(), state, state, code, phone)
+	s.Require().NoError(err)
+	_, _, err = s.service.Complete(context.Background(), state, state, code, phone)
+	s.ErrorIs(err, usecase.ErrInvalidSSOState)
+
+	_, _, err = s.service.Complete(context.Background(), "forged-state", "forged-state", code, phone)
+	s.ErrorIs(err, usecase.ErrInvalidSSOState)
+}
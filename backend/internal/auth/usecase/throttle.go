@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// LoginPolicy bounds failed sign-ins. Once a counter reaches its limit every further
+// failure locks sign-in out for BaseLockout, doubling each time up to MaxLockout.
+type LoginPolicy struct {
+	MaxAccountFailures int // per email, whether or not it has an account
+	MaxIPFailures      int // per client address, across all emails
+	BaseLockout        time.Duration
+	MaxLockout         time.Duration
+	FailureWindow      time.Duration // counters start over after this long without failures
+}
+
+// LockoutError is returned while sign-in is locked out
+type LockoutError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockoutError) Error() string {
+	return fmt.Sprintf("%s: too many failed sign-in attempts, retry in %s", apperror.ErrLimitExceeded, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LockoutError) Unwrap() error {
+	return apperror.ErrLimitExceeded
+}
+
+// lockout is how long to lock a counter out after failures, zero below limit
+func (p LoginPolicy) lockout(failures, limit int) time.Duration {
+	if limit <= 0 || failures < limit {
+		return 0
+	}
+	d := p.BaseLockout
+	for i := limit; i < failures && d < p.MaxLockout; i++ {
+		d *= 2
+	}
+	if d > p.MaxLockout {
+		d = p.MaxLockout
+	}
+	return d
+}
+
+type throttleKeys struct {
+	account string
+	ip      string // empty when the client address is unknown
+}
+
+func loginThrottleKeys(email, ipAddress string) throttleKeys {
+	keys := throttleKeys{account: "account:" + strings.ToLower(strings.TrimSpace(email))}
+	if ipAddress != "" {
+		keys.ip = "ip:" + ipAddress
+	}
+	return keys
+}
+
+func (k throttleKeys) list() []string {
+	if k.ip == "" {
+		return []string{k.account}
+	}
+	return []string{k.account, k.ip}
+}
+
+func (s *AuthService) checkLockout(ctx context.Context, now time.Time, keys throttleKeys) error {
+	throttles, err := s.throttleRepo.FindThrottles(ctx, keys.list()...)
+	if err != nil {
+		return err
+	}
+
+	var retryAfter time.Duration
+	for _, throttle := range throttles {
+		if d := throttle.RetryAfter(now); d > retryAfter {
+			retryAfter = d
+		}
+	}
+	if retryAfter > 0 {
+		return &LockoutError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// failLogin counts the failure against both keys and always reports bad credentials,
+// the lock it may set only shows on the next attempt
+func (s *AuthService) failLogin(ctx context.Context, now time.Time, keys throttleKeys) error {
+	limits := map[string]int{keys.account: s.policy.MaxAccountFailures}
+	if keys.ip != "" {
+		limits[keys.ip] = s.policy.MaxIPFailures
+	}
+
+	for key, limit := range limits {
+		throttle, err := s.throttleRepo.RecordFailure(ctx, key, now, s.policy.FailureWindow)
+		if err != nil {
+			return err
+		}
+		if d := s.policy.lockout(throttle.Failures, limit); d > 0 {
+			if err := s.throttleRepo.Lock(ctx, key, now.Add(d)); err != nil {
+				return err
+			}
+		}
+	}
+	return ErrInvalidCredentials
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+)
+
+// dummyPasswordHash is compared against when the email is unknown
+func dummyPasswordHash() []byte {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not-a-real-password"), bcrypt.DefaultCost)
+	})
+	return dummyHash
+}
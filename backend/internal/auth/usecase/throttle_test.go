@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginPolicyLockout(t *testing.T) {
+	policy := LoginPolicy{BaseLockout: 30 * time.Second, MaxLockout: 5 * time.Minute}
+
+	assert.Zero(t, policy.lockout(4, 5))
+	assert.Equal(t, 30*time.Second, policy.lockout(5, 5))
+	assert.Equal(t, time.Minute, policy.lockout(6, 5))
+	assert.Equal(t, 4*time.Minute, policy.lockout(8, 5))
+	assert.Equal(t, 5*time.Minute, policy.lockout(9, 5))
+	assert.Equal(t, 5*time.Minute, policy.lockout(500, 5))
+
+	// a limit of zero turns the counter off
+	assert.Zero(t, policy.lockout(100, 0))
+}
+
+func TestLoginThrottleKeys(t *testing.T) {
+	keys := loginThrottleKeys("  Student@Example.com ", "10.0.0.1")
+	assert.Equal(t, "account:student@example.com", keys.account)
+	assert.Equal(t, []string{"account:student@example.com", "ip:10.0.0.1"}, keys.list())
+
+	assert.Equal(t, []string{"account:student@example.com"}, loginThrottleKeys("student@example.com", "").list())
+}
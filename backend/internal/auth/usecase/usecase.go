@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/auth/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/logger"
+	"github.com/ePSA-eJya/Mess_Management/pkg/token"
+	"github.com/ePSA-eJya/Mess_Management/pkg/tracing"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var log = logger.For("auth")
+
+// Reasons recorded on revoked sessions
+const (
+	RevokedLogout    = "LOGOUT"
+	RevokedLogoutAll = "LOGOUT_ALL"
+	RevokedByUser    = "REVOKED_BY_USER"
+	RevokedReuse     = "REFRESH_TOKEN_REUSE"
+)
+
+var (
+	// ErrInvalidCredentials is returned for unknown emails and wrong passwords alike
+	ErrInvalidCredentials = fmt.Errorf("%w: invalid email or password", apperror.ErrUnauthorized)
+	// ErrEmailNotVerified rejects sign in until the account's address is confirmed
+	ErrEmailNotVerified = fmt.Errorf("%w: email address not verified", apperror.ErrForbidden)
+)
+
+// AuthService
+type AuthService struct {
+	userRepo     userRepository.UserRepository
+	sessionRepo  repository.SessionRepository
+	throttleRepo repository.LoginThrottleRepository
+	tokens       *token.Manager
+	refreshTTL   time.Duration
+	policy       LoginPolicy
+}
+
+// Init AuthService, refreshTTL bounds how long a session lasts without signing in again
+func NewAuthService(
+	userRepo userRepository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	throttleRepo repository.LoginThrottleRepository,
+	tokens *token.Manager,
+	refreshTTL time.Duration,
+	policy LoginPolicy,
+) AuthUseCase {
+	return &AuthService{
+		userRepo:     userRepo,
+		sessionRepo:  sessionRepo,
+		throttleRepo: throttleRepo,
+		tokens:       tokens,
+		refreshTTL:   refreshTTL,
+		policy:       policy,
+	}
+}
+
+// AuthService Methods - 1 check credentials and start a session for the device. Failures
+// are counted per account and per client address and lock sign-in out for a while.
+func (s *AuthService) Login(ctx context.Context, email, password string, device Device) (_ *TokenPair, _ *entities.User, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Login")
+	defer func() { tracing.End(span, err) }()
+
+	now := time.Now()
+	keys := loginThrottleKeys(email, device.IPAddress)
+	if err := s.checkLockout(ctx, now, keys); err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.userRepo.FindByEmail(ctx, strings.TrimSpace(email))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, err
+	}
+	if user == nil {
+		// spend as long as a wrong password would so timing does not reveal accounts
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+		return nil, nil, s.failLogin(ctx, now, keys)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return nil, nil, s.failLogin(ctx, now, keys)
+	}
+
+	if err := s.throttleRepo.Clear(ctx, keys.account); err != nil {
+		return nil, nil, err
+	}
+	if user.EmailVerifiedAt == nil {
+		return nil, nil, ErrEmailNotVerified
+	}
+
+	pair, err := s.StartSession(ctx, user, device)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pair, user, nil
+}
+
+// AuthService Methods - 2 start a session for a user whose identity was already
+// established, by credentials or by the campus identity provider
+func (s *AuthService) StartSession(ctx context.Context, user *entities.User, device Device) (_ *TokenPair, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.StartSession")
+	defer func() { tracing.End(span, err) }()
+
+	now := time.Now()
+	session := &entities.Session{
+		UserID:     user.ID,
+		DeviceName: device.Name,
+		IPAddress:  device.IPAddress,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(s.refreshTTL),
+	}
+	refresh, refreshToken, err := s.newRefreshToken(session.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sessionRepo.CreateSession(ctx, session, refresh); err != nil {
+		return nil, err
+	}
+
+	return s.issue(user, session, refreshToken)
+}
+
+// AuthService Methods - 3 trade a refresh token for a new pair. Every refresh token
+// works once, presenting a spent one revokes the whole session since it may be stolen.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (_ *TokenPair, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Refresh")
+	defer func() { tracing.End(span, err) }()
+
+	used, err := s.sessionRepo.FindRefreshToken(ctx, token.Hash(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	session, err := s.sessionRepo.FindSession(ctx, used.SessionID.String())
+	if err != nil {
+		return nil, apperror.ErrUnauthorized
+	}
+
+	now := time.Now()
+	if !session.Active(now) || !now.Before(used.ExpiresAt) {
+		return nil, apperror.ErrUnauthorized
+	}
+	if used.UsedAt != nil {
+		return nil, s.revokeReused(ctx, session, now)
+	}
+	// deleting a user revokes their sessions, this also covers a refresh racing the delete
+	user, err := s.userRepo.FindByID(ctx, session.UserID.String())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	next, nextToken, err := s.newRefreshToken(session.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	rotated, err := s.sessionRepo.Rotate(ctx, used, next, now)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		// another request spent the same token first
+		return nil, s.revokeReused(ctx, session, now)
+	}
+	return s.issue(user, session, nextToken)
+}
+
+// AuthService Methods - 4 end the current session
+func (s *AuthService) Logout(ctx context.Context, sessionID string) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Logout")
+	defer func() { tracing.End(span, err) }()
+
+	return s.sessionRepo.RevokeSession(ctx, sessionID, RevokedLogout, time.Now())
+}
+
+// AuthService Methods - 5 end every session of the user
+func (s *AuthService) LogoutAll(ctx context.Context, userID string) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.LogoutAll")
+	defer func() { tracing.End(span, err) }()
+
+	_, err = s.sessionRepo.RevokeUserSessions(ctx, userID, RevokedLogoutAll, time.Now())
+	return err
+}
+
+// AuthService Methods - 6 devices the user is signed in on
+func (s *AuthService) FindSessions(ctx context.Context, userID string) (_ []*entities.Session, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.FindSessions")
+	defer func() { tracing.End(span, err) }()
+
+	return s.sessionRepo.FindActiveSessions(ctx, userID, time.Now())
+}
+
+// AuthService Methods - 7 sign one of the user's devices out
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID string) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.RevokeSession")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := uuid.Parse(sessionID); err != nil {
+		return apperror.ErrInvalidID
+	}
+	session, err := s.sessionRepo.FindSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID.String() != userID {
+		return apperror.ErrRecordNotFound
+	}
+	return s.sessionRepo.RevokeSession(ctx, sessionID, RevokedByUser, time.Now())
+}
+
+// AuthService Methods - 8 whether a verified access token belongs to a session that has ended,
+// tokens issued before sessions existed carry no session and count as ended
+func (s *AuthService) IsRevoked(ctx context.Context, claims *token.Claims) (_ bool, err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.IsRevoked")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := uuid.Parse(claims.SessionID); err != nil {
+		return true, nil
+	}
+	session, err := s.sessionRepo.FindSession(ctx, claims.SessionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return session.RevokedAt != nil || session.UserID.String() != claims.UserID, nil
+}
+
+// AuthService Methods - 9 lift the sign-in lockout of an account
+func (s *AuthService) UnlockAccount(ctx context.Context, userID string) (err error) {
+	ctx, span := tracing.Start(ctx, "AuthService.UnlockAccount")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := uuid.Parse(userID); err != nil {
+		return apperror.ErrInvalidID
+	}
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.throttleRepo.Clear(ctx, loginThrottleKeys(user.Email, "").account)
+}
+
+func (s *AuthService) revokeReused(ctx context.Context, session *entities.Session, now time.Time) error {
+	log.WarnContext(ctx, "Refresh token reuse detected, revoking the session", "session_id", session.ID, "user_id", session.UserID)
+	if err := s.sessionRepo.RevokeSession(ctx, session.ID.String(), RevokedReuse, now); err != nil {
+		return err
+	}
+	return apperror.ErrUnauthorized
+}
+
+func (s *AuthService) newRefreshToken(expiresAt time.Time) (*entities.RefreshToken, string, error) {
+	opaque, err := token.NewOpaque()
+	if err != nil {
+		return nil, "", err
+	}
+	return &entities.RefreshToken{TokenHash: token.Hash(opaque), ExpiresAt: expiresAt}, opaque, nil
+}
+
+func (s *AuthService) issue(user *entities.User, session *entities.Session, refreshToken string) (*TokenPair, error) {
+	accessToken, _, err := s.tokens.Issue(user.ID.String(), string(user.Role), session.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(s.tokens.AccessTTL().Seconds()),
+		SessionID:    session.ID.String(),
+	}, nil
+}
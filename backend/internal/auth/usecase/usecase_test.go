@@ -0,0 +1,283 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/auth/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/auth/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	userUseCase "github.com/ePSA-eJya/Mess_Management/internal/user/usecase"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/token"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+var ctx = context.Background()
+
+type AuthUseCaseTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	tokens  *token.Manager
+	service usecase.AuthUseCase
+	user    *entities.User
+	cleanup func()
+}
+
+func (s *AuthUseCaseTestSuite) SetupTest() {
+	s.db, s.cleanup = database.SetupTestDB(s.T())
+
+	userRepo := userRepository.NewGormUserRepository(s.db)
+	s.tokens = token.NewManager("test-secret-key-for-jwt-token-generation", 15*time.Minute)
+	s.service = usecase.NewAuthService(
+		userRepo,
+		repository.NewGormSessionRepository(s.db),
+		repository.NewGormLoginThrottleRepository(s.db),
+		s.tokens,
+		24*time.Hour,
+		testPolicy,
+	)
+
+	s.user = &entities.User{Email: "login@example.com", Password: "password123", Name: "Login User"}
+	s.Require().NoError(userUseCase.NewUserService(userRepo, time.Hour).Register(ctx, s.user))
+	verifiedAt := time.Now()
+	s.Require().NoError(userRepo.Patch(ctx, s.user.ID.String(), &entities.User{EmailVerifiedAt: &verifiedAt}, 0, nil))
+}
+
+func (s *AuthUseCaseTestSuite) TearDownTest() {
+	if s.cleanup != nil {
+		s.cleanup()
+	}
+}
+
+func TestAuthUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthUseCaseTestSuite))
+}
+
+var phone = usecase.Device{Name: "phone", IPAddress: "10.0.0.1"}
+
+var testPolicy = usecase.LoginPolicy{
+	MaxAccountFailures: 3,
+	MaxIPFailures:      6,
+	BaseLockout:        time.Minute,
+	MaxLockout:         time.Hour,
+	FailureWindow:      15 * time.Minute,
+}
+
+func (s *AuthUseCaseTestSuite) TestLogin() {
+	pair, loggedInUser, err := s.service.Login(ctx, "login@example.com", "password123", phone)
+	s.NoError(err)
+	s.Require().NotNil(pair)
+	s.NotEmpty(pair.RefreshToken)
+	s.Equal(900, pair.ExpiresIn)
+	s.Equal(s.user.Email, loggedInUser.Email)
+
+	claims, err := s.tokens.Parse(pair.AccessToken)
+	s.NoError(err)
+	s.Equal(s.user.ID.String(), claims.UserID)
+	s.Equal(pair.SessionID, claims.SessionID)
+
+	sessions, err := s.service.FindSessions(ctx, s.user.ID.String())
+	s.NoError(err)
+	s.Require().Len(sessions, 1)
+	s.Equal("phone", sessions[0].DeviceName)
+}
+
+func (s *AuthUseCaseTestSuite) TestLogin_WrongPassword() {
+	pair, loggedInUser, err := s.service.Login(ctx, "login@example.com", "wrongpassword", phone)
+	s.ErrorIs(err, apperror.ErrUnauthorized)
+	s.Nil(pair)
+	s.Nil(loggedInUser)
+}
+
+func (s *AuthUseCaseTestSuite) TestLogin_UserNotFound() {
+	pair, loggedInUser, err := s.service.Login(ctx, "notfound@example.com", "password123", phone)
+	s.ErrorIs(err, apperror.ErrUnauthorized)
+	s.Nil(pair)
+	s.Nil(loggedInUser)
+}
+
+func (s *AuthUseCaseTestSuite) TestLogin_LocksAccountOut() {
+	for i := 0; i < testPolicy.MaxAccountFailures; i++ {
+		_, _, err := s.service.Login(ctx, "login@example.com", "wrongpassword", phone)
+		s.ErrorIs(err, usecase.ErrInvalidCredentials)
+	}
+
+	// even the right password is refused while locked out
+	_, _, err := s.service.Login(ctx, "login@example.com", "password123", phone)
+	var lockout *usecase.LockoutError
+	s.Require().ErrorAs(err, &lockout)
+	s.ErrorIs(err, apperror.ErrLimitExceeded)
+	s.InDelta(time.Minute.Seconds(), lockout.RetryAfter.Seconds(), 5)
+
+	s.NoError(s.service.UnlockAccount(ctx, s.user.ID.String()))
+	_, _, err = s.service.Login(ctx, "login@example.com", "password123", phone)
+	s.NoError(err)
+}
+
+func (s *AuthUseCaseTestSuite) TestLogin_UnknownEmailLocksOutAlike() {
+	for i := 0; i < testPolicy.MaxAccountFailures; i++ {
+		_, _, err := s.service.Login(ctx, "ghost@example.com", "password123", phone)
+		s.ErrorIs(err, usecase.ErrInvalidCredentials)
+	}
+
+	_, _, err := s.service.Login(ctx, "ghost@example.com", "password123", phone)
+	s.ErrorIs(err, apperror.ErrLimitExceeded)
+}
+
+func (s *AuthUseCaseTestSuite) TestLogin_LocksAddressOut() {
+	for i := 0; i < testPolicy.MaxIPFailures; i++ {
+		// spread over many emails so no single account reaches its limit
+		_, _, err := s.service.Login(ctx, fmt.Sprintf("user%d@example.com", i), "password123", phone)
+		s.ErrorIs(err, usecase.ErrInvalidCredentials)
+	}
+
+	_, _, err := s.service.Login(ctx, "login@example.com", "password123", phone)
+	s.ErrorIs(err, apperror.ErrLimitExceeded)
+
+	// other addresses are unaffected
+	_, _, err = s.service.Login(ctx, "login@example.com", "password123", usecase.Device{Name: "laptop", IPAddress: "10.0.0.2"})
+	s.NoError(err)
+}
+
+func (s *AuthUseCaseTestSuite) TestLogin_SuccessResetsFailures() {
+	for i := 0; i < testPolicy.MaxAccountFailures-1; i++ {
+		_, _, err := s.service.Login(ctx, "login@example.com", "wrongpassword", phone)
+		s.ErrorIs(err, usecase.ErrInvalidCredentials)
+	}
+	_, _, err := s.service.Login(ctx, "login@example.com", "password123", phone)
+	s.Require().NoError(err)
+
+	_, _, err = s.service.Login(ctx, "login@example.com", "wrongpassword", phone)
+	s.ErrorIs(err, usecase.ErrInvalidCredentials)
+	_, _, err = s.service.Login(ctx, "login@example.com", "password123", phone)
+	s.NoError(err)
+}
+
+func (s *AuthUseCaseTestSuite) TestUnlockAccount_NotFound() {
+	s.Error(s.service.UnlockAccount(ctx, "9a176ca5-f3e0-4994-869c-fac0e8c9d5dc"))
+	s.ErrorIs(s.service.UnlockAccount(ctx, "not-a-uuid"), apperror.ErrInvalidID)
+}
+
+func (s *AuthUseCaseTestSuite) TestLogin_EmailNotVerified() {
+	s.Require().NoError(s.db.Model(&entities.User{}).Where("id = ?", s.user.ID).Update("email_verified_at", nil).Error)
+
+	pair, loggedInUser, err := s.service.Login(ctx, "login@example.com", "password123", phone)
+	s.ErrorIs(err, apperror.ErrForbidden)
+	s.Nil(pair)
+	s.Nil(loggedInUser)
+}
+
+func (s *AuthUseCaseTestSuite) TestRefresh_Rotates() {
+	first, _, err := s.service.Login(ctx, "login@example.com", "password123", phone)
+	s.Require().NoError(err)
+
+	second, err := s.service.Refresh(ctx, first.RefreshToken)
+	s.NoError(err)
+	s.Require().NotNil(second)
+	s.NotEqual(first.RefreshToken, second.RefreshToken)
+	s.Equal(first.SessionID, second.SessionID)
+
+	third, err := s.service.Refresh(ctx, second.RefreshToken)
+	s.NoError(err)
+	s.NotNil(third)
+}
+
+func (s *AuthUseCaseTestSuite) TestRefresh_ReuseRevokesSession() {
+	first, _, err := s.service.Login(ctx, "login@example.com", "password123", phone)
+	s.Require().NoError(err)
+	second, err := s.service.Refresh(ctx, first.RefreshToken)
+	s.Require().NoError(err)
+
+	// the spent token shows up again, e.g. replayed by an attacker
+	_, err = s.service.Refresh(ctx, first.RefreshToken)
+	s.ErrorIs(err, apperror.ErrUnauthorized)
+
+	// the legitimate holder is signed out too
+	_, err = s.service.Refresh(ctx, second.RefreshToken)
+	s.ErrorIs(err, apperror.ErrUnauthorized)
+
+	claims, err := s.tokens.Parse(second.AccessToken)
+	s.Require().NoError(err)
+	revoked, err := s.service.IsRevoked(ctx, claims)
+	s.NoError(err)
+	s.True(revoked)
+}
+
+func (s *AuthUseCaseTestSuite) TestIsRevoked_WithoutSession() {
+	for _, sessionID := range []string{"", "not-a-session"} {
+		revoked, err := s.service.IsRevoked(ctx, &token.Claims{UserID: "9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", SessionID: sessionID})
+		s.NoError(err)
+		s.True(revoked, sessionID)
+	}
+}
+
+func (s *AuthUseCaseTestSuite) TestDeletedUser_SignedOut() {
+	pair, _, err := s.service.Login(ctx, "login@example.com", "password123", phone)
+	s.Require().NoError(err)
+
+	userRepo := userRepository.NewGormUserRepository(s.db)
+	s.Require().NoError(userRepo.Delete(ctx, s.user.ID.String(), nil))
+
+	claims, err := s.tokens.Parse(pair.AccessToken)
+	s.Require().NoError(err)
+	revoked, err := s.service.IsRevoked(ctx, claims)
+	s.NoError(err)
+	s.True(revoked)
+
+	_, err = s.service.Refresh(ctx, pair.RefreshToken)
+	s.ErrorIs(err, apperror.ErrUnauthorized)
+}
+
+func (s *AuthUseCaseTestSuite) TestRefresh_UnknownToken() {
+	_, err := s.service.Refresh(ctx, "not-a-refresh-token")
+	s.ErrorIs(err, apperror.ErrUnauthorized)
+}
+
+func (s *AuthUseCaseTestSuite) TestLogout_RevokesOnlyThatSession() {
+	phonePair, _, err := s.service.Login(ctx, "login@example.com", "password123", phone)
+	s.Require().NoError(err)
+	laptopPair, _, err := s.service.Login(ctx, "login@example.com", "password123", usecase.Device{Name: "laptop"})
+	s.Require().NoError(err)
+
+	s.NoError(s.service.Logout(ctx, phonePair.SessionID))
+
+	phoneClaims, _ := s.tokens.Parse(phonePair.AccessToken)
+	revoked, err := s.service.IsRevoked(ctx, phoneClaims)
+	s.NoError(err)
+	s.True(revoked)
+
+	laptopClaims, _ := s.tokens.Parse(laptopPair.AccessToken)
+	revoked, err = s.service.IsRevoked(ctx, laptopClaims)
+	s.NoError(err)
+	s.False(revoked)
+
+	_, err = s.service.Refresh(ctx, phonePair.RefreshToken)
+	s.ErrorIs(err, apperror.ErrUnauthorized)
+}
+
+func (s *AuthUseCaseTestSuite) TestLogoutAll() {
+	_, _, err := s.service.Login(ctx, "login@example.com", "password123", phone)
+	s.Require().NoError(err)
+	_, _, err = s.service.Login(ctx, "login@example.com", "password123", usecase.Device{Name: "laptop"})
+	s.Require().NoError(err)
+
+	s.NoError(s.service.LogoutAll(ctx, s.user.ID.String()))
+
+	sessions, err := s.service.FindSessions(ctx, s.user.ID.String())
+	s.NoError(err)
+	s.Empty(sessions)
+}
+
+func (s *AuthUseCaseTestSuite) TestRevokeSession_OtherUsersSession() {
+	pair, _, err := s.service.Login(ctx, "login@example.com", "password123", phone)
+	s.Require().NoError(err)
+
+	err = s.service.RevokeSession(ctx, "9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", pair.SessionID)
+	s.ErrorIs(err, apperror.ErrRecordNotFound)
+}
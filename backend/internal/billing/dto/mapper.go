@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/google/uuid"
+)
+
+func ToMonthlyBillResponse(bill *entities.MonthlyBill) *MonthlyBillResponse {
+	return &MonthlyBillResponse{
+		BillID:         bill.BillID,
+		Roll:           bill.Roll,
+		Month:          bill.Month,
+		SemesterID:     bill.SemesterID,
+		BreakfastCount: bill.BreakfastCount,
+		LunchCount:     bill.LunchCount,
+		DinnerCount:    bill.DinnerCount,
+		TotalBill:      bill.TotalBill,
+		CreatedAt:      bill.CreatedAt,
+	}
+}
+
+func ToMonthlyBillResponseList(bills []*entities.MonthlyBill) []*MonthlyBillResponse {
+	result := make([]*MonthlyBillResponse, 0, len(bills))
+	for _, b := range bills {
+		result = append(result, ToMonthlyBillResponse(b))
+	}
+	return result
+}
+
+func ToPaymentEntity(billID uuid.UUID, req *RecordPaymentRequest) *entities.Payment {
+	return &entities.Payment{
+		BillID:    billID,
+		Amount:    req.Amount,
+		Method:    entities.PaymentMethod(req.Method),
+		Reference: req.Reference,
+		PaidAt:    req.PaidAt,
+	}
+}
+
+func ToPaymentResponse(payment *entities.Payment) *PaymentResponse {
+	return &PaymentResponse{
+		PaymentID:  payment.PaymentID,
+		BillID:     payment.BillID,
+		Roll:       payment.Roll,
+		Amount:     payment.Amount,
+		Method:     string(payment.Method),
+		Reference:  payment.Reference,
+		RecordedBy: payment.RecordedBy,
+		PaidAt:     payment.PaidAt,
+		CreatedAt:  payment.CreatedAt,
+	}
+}
+
+func ToPaymentResponseList(payments []*entities.Payment) []*PaymentResponse {
+	result := make([]*PaymentResponse, 0, len(payments))
+	for _, p := range payments {
+		result = append(result, ToPaymentResponse(p))
+	}
+	return result
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "time"
+
+type RecordPaymentRequest struct {
+	Amount    float64   `json:"amount" validate:"required,gt=0"`
+	Method    string    `json:"method" validate:"required,oneof=CASH UPI CARD BANK_TRANSFER"`
+	Reference string    `json:"reference" validate:"required,max=100"`
+	PaidAt    time.Time `json:"paid_at"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MonthlyBillResponse struct {
+	BillID         uuid.UUID `json:"bill_id"`
+	Roll           uint      `json:"roll"`
+	Month          string    `json:"month"`
+	SemesterID     uint      `json:"semester_id"`
+	BreakfastCount uint      `json:"breakfast_count"`
+	LunchCount     uint      `json:"lunch_count"`
+	DinnerCount    uint      `json:"dinner_count"`
+	TotalBill      float64   `json:"total_bill"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type PaymentResponse struct {
+	PaymentID  uuid.UUID `json:"payment_id"`
+	BillID     uuid.UUID `json:"bill_id"`
+	Roll       uint      `json:"roll"`
+	Amount     float64   `json:"amount"`
+	Method     string    `json:"method"`
+	Reference  string    `json:"reference"`
+	RecordedBy uuid.UUID `json:"recorded_by"`
+	PaidAt     time.Time `json:"paid_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,143 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/billing/dto"
+	"github.com/ePSA-eJya/Mess_Management/internal/billing/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+	"github.com/ePSA-eJya/Mess_Management/pkg/validation"
+	billingpb "github.com/ePSA-eJya/Mess_Management/proto/billing"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GrpcBillingHandler struct {
+	billingUseCase usecase.BillingUseCase
+	billingpb.UnimplementedBillingServiceServer
+}
+
+func NewGrpcBillingHandler(uc usecase.BillingUseCase) *GrpcBillingHandler {
+	return &GrpcBillingHandler{billingUseCase: uc}
+}
+
+func (h *GrpcBillingHandler) FindMonthlyBills(ctx context.Context, req *billingpb.FindMonthlyBillsRequest) (*billingpb.FindMonthlyBillsResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	month := req.Month
+	if month == "" {
+		month = time.Now().AddDate(0, -1, 0).Format(usecase.MonthFormat)
+	}
+	if _, err := time.Parse(usecase.MonthFormat, month); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "month must be YYYY-MM")
+	}
+
+	bills, err := h.billingUseCase.FindMonthlyBills(ctx, month)
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+
+	protoBills := make([]*billingpb.MonthlyBill, len(bills))
+	for i, b := range bills {
+		protoBills[i] = toProtoBill(b)
+	}
+	return &billingpb.FindMonthlyBillsResponse{Bills: protoBills}, nil
+}
+
+func (h *GrpcBillingHandler) FindMonthlyBillByID(ctx context.Context, req *billingpb.FindMonthlyBillByIDRequest) (*billingpb.FindMonthlyBillByIDResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	bill, err := h.billingUseCase.FindMonthlyBillByID(ctx, req.BillId)
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	return &billingpb.FindMonthlyBillByIDResponse{Bill: toProtoBill(bill)}, nil
+}
+
+func (h *GrpcBillingHandler) RecordPayment(ctx context.Context, req *billingpb.RecordPaymentRequest) (*billingpb.RecordPaymentResponse, error) {
+	if err := middleware.RequireGRPCRole(ctx, string(entities.RoleOfficeAdmin)); err != nil {
+		return nil, err
+	}
+	claims, _ := middleware.ClaimsFromContext(ctx)
+
+	billID, err := uuid.Parse(req.BillId)
+	if err != nil {
+		return nil, apperror.GRPCError(apperror.ErrInvalidID)
+	}
+	err = validation.Struct(&dto.RecordPaymentRequest{Amount: req.Amount, Method: req.Method, Reference: req.Reference})
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	payment := &entities.Payment{
+		BillID:    billID,
+		Amount:    req.Amount,
+		Method:    entities.PaymentMethod(req.Method),
+		Reference: req.Reference,
+	}
+	if req.PaidAt != "" {
+		if payment.PaidAt, err = time.Parse(time.RFC3339, req.PaidAt); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "paid_at must be RFC 3339")
+		}
+	}
+
+	actor := entities.AuditActor{UserID: claims.UserID, IP: middleware.ClientIPFromContext(ctx)}
+	if err := h.billingUseCase.RecordPayment(ctx, actor, payment); err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	return &billingpb.RecordPaymentResponse{Payment: toProtoPayment(payment)}, nil
+}
+
+func (h *GrpcBillingHandler) FindPayments(ctx context.Context, req *billingpb.FindPaymentsRequest) (*billingpb.FindPaymentsResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	payments, err := h.billingUseCase.FindPayments(ctx, req.BillId)
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+
+	protoPayments := make([]*billingpb.Payment, len(payments))
+	for i, p := range payments {
+		protoPayments[i] = toProtoPayment(p)
+	}
+	return &billingpb.FindPaymentsResponse{Payments: protoPayments}, nil
+}
+
+func requireAdmin(ctx context.Context) error {
+	return middleware.RequireGRPCRole(ctx, string(entities.RoleOfficeAdmin), string(entities.RoleMessAdmin))
+}
+
+// helper function convert entities.MonthlyBill to billingpb.MonthlyBill
+func toProtoBill(b *entities.MonthlyBill) *billingpb.MonthlyBill {
+	return &billingpb.MonthlyBill{
+		BillId:         b.BillID.String(),
+		Roll:           uint32(b.Roll),
+		Month:          b.Month,
+		SemesterId:     uint32(b.SemesterID),
+		BreakfastCount: uint32(b.BreakfastCount),
+		LunchCount:     uint32(b.LunchCount),
+		DinnerCount:    uint32(b.DinnerCount),
+		TotalBill:      b.TotalBill,
+		CreatedAt:      b.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// helper function convert entities.Payment to billingpb.Payment
+func toProtoPayment(p *entities.Payment) *billingpb.Payment {
+	return &billingpb.Payment{
+		PaymentId:  p.PaymentID.String(),
+		BillId:     p.BillID.String(),
+		Roll:       uint32(p.Roll),
+		Amount:     p.Amount,
+		Method:     string(p.Method),
+		Reference:  p.Reference,
+		RecordedBy: p.RecordedBy.String(),
+		PaidAt:     p.PaidAt.Format(time.RFC3339),
+	}
+}
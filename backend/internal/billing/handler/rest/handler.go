@@ -0,0 +1,110 @@
+package rest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/billing/dto"
+	"github.com/ePSA-eJya/Mess_Management/internal/billing/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/ePSA-eJya/Mess_Management/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type HttpBillingHandler struct {
+	billingUseCase usecase.BillingUseCase
+}
+
+func NewHttpBillingHandler(useCase usecase.BillingUseCase) *HttpBillingHandler {
+	return &HttpBillingHandler{billingUseCase: useCase}
+}
+
+// FindMonthlyBills godoc
+// @Summary List the monthly bills of a month
+// @Tags bills
+// @Produce json
+// @Param month query string false "Month as YYYY-MM (default: last month)"
+// @Success 200 {array} dto.MonthlyBillResponse
+// @Router /bills [get]
+func (h *HttpBillingHandler) FindMonthlyBills(c *fiber.Ctx) error {
+	month := c.Query("month", time.Now().AddDate(0, -1, 0).Format(usecase.MonthFormat))
+	if _, err := time.Parse(usecase.MonthFormat, month); err != nil {
+		return responses.ErrorWithMessage(c, apperror.ErrInvalidFormat, "month must be YYYY-MM")
+	}
+
+	bills, err := h.billingUseCase.FindMonthlyBills(c.UserContext(), month)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToMonthlyBillResponseList(bills))
+}
+
+// FindMonthlyBillByID godoc
+// @Summary Get a monthly bill
+// @Tags bills
+// @Produce json
+// @Param id path string true "Bill ID"
+// @Success 200 {object} dto.MonthlyBillResponse
+// @Router /bills/{id} [get]
+func (h *HttpBillingHandler) FindMonthlyBillByID(c *fiber.Ctx) error {
+	bill, err := h.billingUseCase.FindMonthlyBillByID(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToMonthlyBillResponse(bill))
+}
+
+// RecordPayment godoc
+// @Summary Record a payment against a bill
+// @Tags bills
+// @Accept json
+// @Produce json
+// @Param id path string true "Bill ID"
+// @Param payment body dto.RecordPaymentRequest true "Payment payload"
+// @Success 201 {object} dto.PaymentResponse
+// @Router /bills/{id}/payments [post]
+func (h *HttpBillingHandler) RecordPayment(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	billID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return responses.Error(c, apperror.ErrInvalidID)
+	}
+
+	var req dto.RecordPaymentRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	payment := dto.ToPaymentEntity(billID, &req)
+	actor := entities.AuditActor{UserID: fmt.Sprint(userID), IP: c.IP()}
+	if err := h.billingUseCase.RecordPayment(c.UserContext(), actor, payment); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.ToPaymentResponse(payment))
+}
+
+// FindPayments godoc
+// @Summary List payments made against a bill
+// @Tags bills
+// @Produce json
+// @Param id path string true "Bill ID"
+// @Success 200 {array} dto.PaymentResponse
+// @Router /bills/{id}/payments [get]
+func (h *HttpBillingHandler) FindPayments(c *fiber.Ctx) error {
+	payments, err := h.billingUseCase.FindPayments(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToPaymentResponseList(payments))
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+)
+
+type BillingRepository interface {
+	// SaveMonthlyBill returns false when the student already has a bill for that month,
+	// event and audit are only written when the bill is
+	SaveMonthlyBill(ctx context.Context, bill *entities.MonthlyBill, event *entities.OutboxEvent, audit *entities.AuditLog) (bool, error)
+	FindMonthlyBills(ctx context.Context, month string) ([]*entities.MonthlyBill, error)
+	FindMonthlyBillByID(ctx context.Context, billID string) (*entities.MonthlyBill, error)
+	FindSemesterByDate(ctx context.Context, date time.Time) (*entities.Semester, error)
+
+	// SavePayment locks the bill of payment, rejects a reference already used
+	// with apperror.ErrAlreadyExists and hands the bill and what has been paid
+	// on it so far to prepare, which checks the payment and returns the event
+	// and audit entry written with it. Concurrent payments on one bill wait
+	// for each other, so prepare always sees the amount still owed.
+	SavePayment(ctx context.Context, payment *entities.Payment, prepare PreparePayment) error
+	FindPayments(ctx context.Context, billID string) ([]*entities.Payment, error)
+	FindPaymentByReference(ctx context.Context, reference string) (*entities.Payment, error)
+	SumPayments(ctx context.Context, billID string) (float64, error)
+}
+
+type PreparePayment func(bill *entities.MonthlyBill, paid float64) (*entities.OutboxEvent, *entities.AuditLog, error)
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	auditRepository "github.com/ePSA-eJya/Mess_Management/internal/audit/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	outboxRepository "github.com/ePSA-eJya/Mess_Management/internal/outbox/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormBillingRepository struct {
+	db *gorm.DB
+}
+
+func NewGormBillingRepository(db *gorm.DB) BillingRepository {
+	return &GormBillingRepository{db: db}
+}
+
+func (r *GormBillingRepository) SaveMonthlyBill(ctx context.Context, bill *entities.MonthlyBill, event *entities.OutboxEvent, audit *entities.AuditLog) (bool, error) {
+	created := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(bill)
+		if result.Error != nil {
+			return result.Error
+		}
+		if created = result.RowsAffected == 1; !created {
+			return nil
+		}
+		if err := outboxRepository.Enqueue(tx, event); err != nil {
+			return err
+		}
+		return auditRepository.Append(tx, audit)
+	})
+	if err != nil {
+		return false, err
+	}
+	return created, nil
+}
+
+func (r *GormBillingRepository) FindMonthlyBills(ctx context.Context, month string) ([]*entities.MonthlyBill, error) {
+	var billValues []entities.MonthlyBill
+	if err := r.db.WithContext(ctx).Where("month = ?", month).Order("roll").Find(&billValues).Error; err != nil {
+		return nil, err
+	}
+	bills := make([]*entities.MonthlyBill, len(billValues))
+	for i := range billValues {
+		bills[i] = &billValues[i]
+	}
+	return bills, nil
+}
+
+func (r *GormBillingRepository) FindMonthlyBillByID(ctx context.Context, billID string) (*entities.MonthlyBill, error) {
+	var bill entities.MonthlyBill
+	if err := r.db.WithContext(ctx).Where("bill_id = ?", billID).First(&bill).Error; err != nil {
+		return nil, err
+	}
+	return &bill, nil
+}
+
+func (r *GormBillingRepository) FindSemesterByDate(ctx context.Context, date time.Time) (*entities.Semester, error) {
+	var semester entities.Semester
+	if err := r.db.WithContext(ctx).Where("start_date <= ? AND end_date >= ?", date, date).First(&semester).Error; err != nil {
+		return nil, err
+	}
+	return &semester, nil
+}
+
+func (r *GormBillingRepository) SavePayment(ctx context.Context, payment *entities.Payment, prepare PreparePayment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var bill entities.MonthlyBill
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("bill_id = ?", payment.BillID).
+			First(&bill).Error; err != nil {
+			return err
+		}
+
+		var used int64
+		if err := tx.Model(&entities.Payment{}).Where("reference = ?", payment.Reference).Count(&used).Error; err != nil {
+			return err
+		}
+		if used > 0 {
+			return apperror.ErrAlreadyExists
+		}
+
+		var paid float64
+		if err := tx.Model(&entities.Payment{}).
+			Select("COALESCE(SUM(amount), 0)").
+			Where("bill_id = ?", bill.BillID).
+			Scan(&paid).Error; err != nil {
+			return err
+		}
+
+		event, audit, err := prepare(&bill, paid)
+		if err != nil {
+			return err
+		}
+		// the reference may still be taken by a payment on another bill
+		if err := tx.Create(payment).Error; err != nil {
+			if database.IsUniqueViolation(err) {
+				return apperror.ErrAlreadyExists
+			}
+			return err
+		}
+		if err := outboxRepository.Enqueue(tx, event); err != nil {
+			return err
+		}
+		return auditRepository.Append(tx, audit)
+	})
+}
+
+func (r *GormBillingRepository) FindPayments(ctx context.Context, billID string) ([]*entities.Payment, error) {
+	var paymentValues []entities.Payment
+	if err := r.db.WithContext(ctx).Where("bill_id = ?", billID).Order("paid_at").Find(&paymentValues).Error; err != nil {
+		return nil, err
+	}
+	payments := make([]*entities.Payment, len(paymentValues))
+	for i := range paymentValues {
+		payments[i] = &paymentValues[i]
+	}
+	return payments, nil
+}
+
+func (r *GormBillingRepository) FindPaymentByReference(ctx context.Context, reference string) (*entities.Payment, error) {
+	var payment entities.Payment
+	if err := r.db.WithContext(ctx).Where("reference = ?", reference).First(&payment).Error; err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+func (r *GormBillingRepository) SumPayments(ctx context.Context, billID string) (float64, error) {
+	var total float64
+	err := r.db.WithContext(ctx).Model(&entities.Payment{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("bill_id = ?", billID).
+		Scan(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+)
+
+type BillingUseCase interface {
+	GenerateMonthlyBills(ctx context.Context, month time.Time) ([]*entities.MonthlyBill, error)
+	FindMonthlyBills(ctx context.Context, month string) ([]*entities.MonthlyBill, error)
+	FindMonthlyBillByID(ctx context.Context, billID string) (*entities.MonthlyBill, error)
+	RecordPayment(ctx context.Context, actor entities.AuditActor, payment *entities.Payment) error
+	FindPayments(ctx context.Context, billID string) ([]*entities.Payment, error)
+}
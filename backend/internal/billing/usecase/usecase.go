@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/billing/repository"
+	cancellationRepository "github.com/ePSA-eJya/Mess_Management/internal/cancellation/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	studentRepository "github.com/ePSA-eJya/Mess_Management/internal/student/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/metrics"
+	"github.com/ePSA-eJya/Mess_Management/pkg/tracing"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MonthFormat is how months are stored on MonthlyBill
+const MonthFormat = "2006-01"
+
+// Rates is the price of a single meal of each type
+type Rates struct {
+	Breakfast float64
+	Lunch     float64
+	Dinner    float64
+}
+
+// BillingService
+type BillingService struct {
+	repo             repository.BillingRepository
+	studentRepo      studentRepository.StudentRepository
+	cancellationRepo cancellationRepository.CancellationRepository
+	rates            Rates
+}
+
+// Init BillingService
+func NewBillingService(
+	repo repository.BillingRepository,
+	studentRepo studentRepository.StudentRepository,
+	cancellationRepo cancellationRepository.CancellationRepository,
+	rates Rates,
+) BillingUseCase {
+	return &BillingService{
+		repo:             repo,
+		studentRepo:      studentRepo,
+		cancellationRepo: cancellationRepo,
+		rates:            rates,
+	}
+}
+
+// BillingService Methods - 1 bill every active student for the meals they did not cancel,
+// students already billed for the month are skipped so reruns are safe
+func (s *BillingService) GenerateMonthlyBills(ctx context.Context, month time.Time) (_ []*entities.MonthlyBill, err error) {
+	ctx, span := tracing.Start(ctx, "BillingService.GenerateMonthlyBills")
+	defer func() { tracing.End(span, err) }()
+
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	days := uint(to.Sub(from).Hours() / 24)
+
+	var semesterID uint
+	semester, err := s.repo.FindSemesterByDate(ctx, from)
+	switch {
+	case err == nil:
+		semesterID = semester.SemesterID
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+
+	students, err := s.studentRepo.FindActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.cancellationRepo.CountByStudent(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	cancelled := make(map[uint]map[entities.MealType]uint)
+	for _, c := range counts {
+		if cancelled[c.Roll] == nil {
+			cancelled[c.Roll] = make(map[entities.MealType]uint)
+		}
+		cancelled[c.Roll][c.MealType] = c.Count
+	}
+
+	generated := make([]*entities.MonthlyBill, 0, len(students))
+	for _, student := range students {
+		bill := &entities.MonthlyBill{
+			BillID:         uuid.New(),
+			Roll:           student.Roll,
+			Month:          from.Format(MonthFormat),
+			SemesterID:     semesterID,
+			BreakfastCount: served(days, cancelled[student.Roll][entities.Breakfast]),
+			LunchCount:     served(days, cancelled[student.Roll][entities.Lunch]),
+			DinnerCount:    served(days, cancelled[student.Roll][entities.Dinner]),
+		}
+		bill.TotalBill = s.total(bill)
+
+		event, err := entities.NewOutboxEvent(entities.EventTypeBillGenerated, "monthly_bill", bill.BillID.String(), entities.BillGeneratedPayload{
+			BillID: bill.BillID,
+			Roll:   bill.Roll,
+			Month:  bill.Month,
+			Total:  bill.TotalBill,
+		})
+		if err != nil {
+			return generated, err
+		}
+
+		audit, err := entities.NewAuditLog(entities.SystemActor, entities.AuditBillGenerated, "monthly_bill", bill.BillID.String(), nil, bill)
+		if err != nil {
+			return generated, err
+		}
+
+		created, err := s.repo.SaveMonthlyBill(ctx, bill, event, audit)
+		if err != nil {
+			return generated, err
+		}
+		if created {
+			generated = append(generated, bill)
+			metrics.BillsGenerated.Inc()
+		}
+	}
+
+	return generated, nil
+}
+
+// BillingService Methods - 2 bills of a month
+func (s *BillingService) FindMonthlyBills(ctx context.Context, month string) (_ []*entities.MonthlyBill, err error) {
+	ctx, span := tracing.Start(ctx, "BillingService.FindMonthlyBills")
+	defer func() { tracing.End(span, err) }()
+
+	return s.repo.FindMonthlyBills(ctx, month)
+}
+
+// BillingService Methods - 3 find a bill
+func (s *BillingService) FindMonthlyBillByID(ctx context.Context, billID string) (_ *entities.MonthlyBill, err error) {
+	ctx, span := tracing.Start(ctx, "BillingService.FindMonthlyBillByID")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := uuid.Parse(billID); err != nil {
+		return nil, apperror.ErrInvalidID
+	}
+	return s.repo.FindMonthlyBillByID(ctx, billID)
+}
+
+// BillingService Methods - 4 record a payment against a bill, the payment may not exceed what is still owed
+func (s *BillingService) RecordPayment(ctx context.Context, actor entities.AuditActor, payment *entities.Payment) (err error) {
+	ctx, span := tracing.Start(ctx, "BillingService.RecordPayment")
+	defer func() { tracing.End(span, err) }()
+
+	payment.Reference = strings.TrimSpace(payment.Reference)
+	if payment.Amount <= 0 || !payment.Method.Valid() || payment.Reference == "" {
+		return apperror.ErrInvalidData
+	}
+
+	recorder, err := uuid.Parse(actor.UserID)
+	if err != nil {
+		return apperror.ErrUnauthorized
+	}
+
+	// runs with the bill locked, the outstanding amount cannot change under it
+	return s.repo.SavePayment(ctx, payment, func(bill *entities.MonthlyBill, paid float64) (*entities.OutboxEvent, *entities.AuditLog, error) {
+		outstanding := round(bill.TotalBill - paid)
+		if payment.Amount > outstanding {
+			return nil, nil, apperror.ErrOutOfRange
+		}
+
+		payment.PaymentID = uuid.New()
+		payment.Roll = bill.Roll
+		payment.RecordedBy = recorder
+		if payment.PaidAt.IsZero() {
+			payment.PaidAt = time.Now()
+		}
+
+		event, err := entities.NewOutboxEvent(entities.EventTypePaymentRecorded, "payment", payment.PaymentID.String(), entities.PaymentRecordedPayload{
+			PaymentID:   payment.PaymentID,
+			BillID:      payment.BillID,
+			Roll:        payment.Roll,
+			Month:       bill.Month,
+			Amount:      payment.Amount,
+			Method:      payment.Method,
+			Reference:   payment.Reference,
+			PaidAt:      payment.PaidAt,
+			Outstanding: round(outstanding - payment.Amount),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		audit, err := entities.NewAuditLog(actor, entities.AuditPaymentRecorded, "payment", payment.PaymentID.String(), nil, payment)
+		if err != nil {
+			return nil, nil, err
+		}
+		return event, audit, nil
+	})
+}
+
+// BillingService Methods - 5 payments made against a bill
+func (s *BillingService) FindPayments(ctx context.Context, billID string) (_ []*entities.Payment, err error) {
+	ctx, span := tracing.Start(ctx, "BillingService.FindPayments")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := uuid.Parse(billID); err != nil {
+		return nil, apperror.ErrInvalidID
+	}
+	return s.repo.FindPayments(ctx, billID)
+}
+
+func (s *BillingService) total(bill *entities.MonthlyBill) float64 {
+	total := float64(bill.BreakfastCount)*s.rates.Breakfast +
+		float64(bill.LunchCount)*s.rates.Lunch +
+		float64(bill.DinnerCount)*s.rates.Dinner
+	return round(total)
+}
+
+// round keeps amounts to paise
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func served(days, cancelled uint) uint {
+	if cancelled >= days {
+		return 0
+	}
+	return days - cancelled
+}
@@ -0,0 +1,201 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/billing/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/billing/usecase"
+	cancellationRepository "github.com/ePSA-eJya/Mess_Management/internal/cancellation/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	studentRepository "github.com/ePSA-eJya/Mess_Management/internal/student/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+var ctx = context.Background()
+
+type BillingUseCaseTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	service usecase.BillingUseCase
+	cleanup func()
+}
+
+func (s *BillingUseCaseTestSuite) SetupTest() {
+	s.db, s.cleanup = database.SetupTestDB(s.T())
+	s.service = usecase.NewBillingService(
+		repository.NewGormBillingRepository(s.db),
+		studentRepository.NewGormStudentRepository(s.db),
+		cancellationRepository.NewGormCancellationRepository(s.db),
+		usecase.Rates{Breakfast: 30, Lunch: 50, Dinner: 50},
+	)
+}
+
+func (s *BillingUseCaseTestSuite) TearDownTest() {
+	if s.cleanup != nil {
+		s.cleanup()
+	}
+}
+
+func TestBillingUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(BillingUseCaseTestSuite))
+}
+
+func (s *BillingUseCaseTestSuite) createStudent(roll uint, email string, status entities.StudentStatus) {
+	s.Require().NoError(s.db.Create(&entities.Student{
+		Roll: roll, Name: "Student", Hostel: "H1", RoomNo: 101, MessNo: 1, Email: email, Status: status,
+	}).Error)
+}
+
+func (s *BillingUseCaseTestSuite) cancel(roll uint, meal entities.MealType, date time.Time) {
+	s.Require().NoError(s.db.Create(&entities.MealCancellationRecord{Roll: roll, MealType: meal, Date: date}).Error)
+}
+
+func (s *BillingUseCaseTestSuite) TestGenerateMonthlyBills() {
+	s.createStudent(1, "one@example.com", entities.Active)
+	s.createStudent(2, "two@example.com", entities.Inactive)
+
+	// September has 30 days
+	s.cancel(1, entities.Lunch, time.Date(2026, 9, 3, 0, 0, 0, 0, time.UTC))
+	s.cancel(1, entities.Lunch, time.Date(2026, 9, 4, 0, 0, 0, 0, time.UTC))
+	s.cancel(1, entities.Dinner, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC))
+
+	bills, err := s.service.GenerateMonthlyBills(ctx, time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC))
+	s.NoError(err)
+	s.Require().Len(bills, 1)
+
+	bill := bills[0]
+	s.Equal(uint(1), bill.Roll)
+	s.Equal("2026-09", bill.Month)
+	s.Equal(uint(30), bill.BreakfastCount)
+	s.Equal(uint(28), bill.LunchCount)
+	s.Equal(uint(30), bill.DinnerCount)
+	s.Equal(30*30.0+28*50.0+30*50.0, bill.TotalBill)
+}
+
+func (s *BillingUseCaseTestSuite) TestGenerateMonthlyBills_RerunSkipsBilledStudents() {
+	s.createStudent(1, "one@example.com", entities.Active)
+	month := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	bills, err := s.service.GenerateMonthlyBills(ctx, month)
+	s.NoError(err)
+	s.Len(bills, 1)
+
+	bills, err = s.service.GenerateMonthlyBills(ctx, month)
+	s.NoError(err)
+	s.Empty(bills)
+
+	stored, err := s.service.FindMonthlyBills(ctx, "2026-09")
+	s.NoError(err)
+	s.Len(stored, 1)
+}
+
+func (s *BillingUseCaseTestSuite) TestGenerateMonthlyBills_EnqueuesEventPerBill() {
+	s.createStudent(1, "one@example.com", entities.Active)
+	month := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	bills, err := s.service.GenerateMonthlyBills(ctx, month)
+	s.NoError(err)
+	s.Require().Len(bills, 1)
+
+	// a rerun creates no bill and so no event
+	_, err = s.service.GenerateMonthlyBills(ctx, month)
+	s.NoError(err)
+
+	var events []entities.OutboxEvent
+	s.NoError(s.db.Where("event_type = ?", entities.EventTypeBillGenerated).Find(&events).Error)
+	s.Require().Len(events, 1)
+	s.Equal(bills[0].BillID.String(), events[0].AggregateID)
+
+	var payload entities.BillGeneratedPayload
+	s.NoError(json.Unmarshal([]byte(events[0].Payload), &payload))
+	s.Equal(uint(1), payload.Roll)
+	s.Equal(bills[0].TotalBill, payload.Total)
+}
+
+var recorder = entities.AuditActor{UserID: "9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", IP: "203.0.113.7"}
+
+func (s *BillingUseCaseTestSuite) generateBill() *entities.MonthlyBill {
+	s.createStudent(1, "one@example.com", entities.Active)
+	bills, err := s.service.GenerateMonthlyBills(ctx, time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC))
+	s.Require().NoError(err)
+	s.Require().Len(bills, 1)
+	return bills[0]
+}
+
+func (s *BillingUseCaseTestSuite) TestRecordPayment() {
+	bill := s.generateBill()
+	payment := &entities.Payment{BillID: bill.BillID, Amount: 1000, Method: entities.PaymentUPI, Reference: "UPI-001"}
+
+	err := s.service.RecordPayment(ctx, recorder, payment)
+	s.NoError(err)
+	s.Equal(bill.Roll, payment.Roll)
+	s.False(payment.PaidAt.IsZero())
+
+	payments, err := s.service.FindPayments(ctx, bill.BillID.String())
+	s.NoError(err)
+	s.Len(payments, 1)
+
+	var event entities.OutboxEvent
+	s.NoError(s.db.Where("event_type = ?", entities.EventTypePaymentRecorded).First(&event).Error)
+	var payload entities.PaymentRecordedPayload
+	s.NoError(json.Unmarshal([]byte(event.Payload), &payload))
+	s.Equal(bill.TotalBill-1000, payload.Outstanding)
+	s.Equal("2026-09", payload.Month)
+
+	var audit entities.AuditLog
+	s.NoError(s.db.Where("action = ?", entities.AuditPaymentRecorded).First(&audit).Error)
+	s.Equal(recorder.UserID, audit.ActorID)
+	s.Equal(recorder.IP, audit.IP)
+	s.Equal(payment.PaymentID.String(), audit.EntityID)
+	s.NotEmpty(audit.PrevHash) // chained onto the bill generation entry
+}
+
+func (s *BillingUseCaseTestSuite) TestRecordPayment_RejectsOverpayment() {
+	bill := s.generateBill()
+	payment := &entities.Payment{BillID: bill.BillID, Amount: bill.TotalBill + 1, Method: entities.PaymentCash, Reference: "R-1"}
+
+	err := s.service.RecordPayment(ctx, recorder, payment)
+	s.ErrorIs(err, apperror.ErrOutOfRange)
+}
+
+func (s *BillingUseCaseTestSuite) TestRecordPayment_DuplicateReference() {
+	bill := s.generateBill()
+
+	s.NoError(s.service.RecordPayment(ctx, recorder, &entities.Payment{BillID: bill.BillID, Amount: 10, Method: entities.PaymentCash, Reference: "R-1"}))
+	err := s.service.RecordPayment(ctx, recorder, &entities.Payment{BillID: bill.BillID, Amount: 10, Method: entities.PaymentCash, Reference: "R-1"})
+	s.ErrorIs(err, apperror.ErrAlreadyExists)
+
+	var count int64
+	s.NoError(s.db.Model(&entities.OutboxEvent{}).Where("event_type = ?", entities.EventTypePaymentRecorded).Count(&count).Error)
+	s.Equal(int64(1), count)
+}
+
+func (s *BillingUseCaseTestSuite) TestRecordPayment_ConcurrentPaymentsCannotOverpay() {
+	bill := s.generateBill()
+
+	errs := make(chan error, 2)
+	for _, reference := range []string{"R-1", "R-2"} {
+		go func() {
+			errs <- s.service.RecordPayment(ctx, recorder, &entities.Payment{BillID: bill.BillID, Amount: bill.TotalBill, Method: entities.PaymentCash, Reference: reference})
+		}()
+	}
+	first, second := <-errs, <-errs
+
+	// the bill is locked while a payment is checked, the second one sees the first
+	s.True((first == nil) != (second == nil), "exactly one payment succeeds: %v, %v", first, second)
+	if first != nil {
+		s.ErrorIs(first, apperror.ErrOutOfRange)
+	} else {
+		s.ErrorIs(second, apperror.ErrOutOfRange)
+	}
+
+	payments, err := s.service.FindPayments(ctx, bill.BillID.String())
+	s.NoError(err)
+	s.Len(payments, 1)
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "github.com/ePSA-eJya/Mess_Management/internal/entities"
+
+func ToCancellationResponse(record *entities.MealCancellationRecord) *CancellationResponse {
+	return &CancellationResponse{
+		ID:         record.ID,
+		Roll:       record.Roll,
+		SemesterID: record.SemesterID,
+		Date:       record.Date.Format("2006-01-02"),
+		MealType:   string(record.MealType),
+		CreatedAt:  record.CreatedAt,
+	}
+}
+
+func ToCancellationResponseList(records []*entities.MealCancellationRecord) []*CancellationResponse {
+	result := make([]*CancellationResponse, 0, len(records))
+	for _, r := range records {
+		result = append(result, ToCancellationResponse(r))
+	}
+	return result
+}
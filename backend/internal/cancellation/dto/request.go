@@ -0,0 +1,6 @@
+package dto
+
+type CancelMealRequest struct {
+	Date     string `json:"date" validate:"required,datetime=2006-01-02"`
+	MealType string `json:"meal_type" validate:"required,oneof=BREAKFAST LUNCH DINNER"`
+}
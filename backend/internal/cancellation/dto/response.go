@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+type CancellationResponse struct {
+	ID         uint      `json:"id"`
+	Roll       uint      `json:"roll"`
+	SemesterID uint      `json:"semester_id"`
+	Date       string    `json:"date"`
+	MealType   string    `json:"meal_type"`
+	CreatedAt  time.Time `json:"created_at"`
+}
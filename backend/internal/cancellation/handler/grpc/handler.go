@@ -0,0 +1,93 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/cancellation/dto"
+	"github.com/ePSA-eJya/Mess_Management/internal/cancellation/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+	"github.com/ePSA-eJya/Mess_Management/pkg/validation"
+	cancellationpb "github.com/ePSA-eJya/Mess_Management/proto/cancellation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GrpcCancellationHandler struct {
+	cancellationUseCase usecase.CancellationUseCase
+	cancellationpb.UnimplementedMealCancellationServiceServer
+}
+
+func NewGrpcCancellationHandler(uc usecase.CancellationUseCase) *GrpcCancellationHandler {
+	return &GrpcCancellationHandler{cancellationUseCase: uc}
+}
+
+func (h *GrpcCancellationHandler) CancelMeal(ctx context.Context, req *cancellationpb.CancelMealRequest) (*cancellationpb.CancelMealResponse, error) {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing token")
+	}
+	if err := validation.Struct(&dto.CancelMealRequest{Date: req.Date, MealType: req.MealType}); err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	date, err := time.Parse(usecase.DateFormat, req.Date)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "date must be YYYY-MM-DD")
+	}
+
+	record, err := h.cancellationUseCase.CancelMeal(ctx, claims.UserID, date, entities.MealType(req.MealType))
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	return &cancellationpb.CancelMealResponse{Cancellation: toProtoCancellation(record)}, nil
+}
+
+func (h *GrpcCancellationHandler) FindCancellations(ctx context.Context, req *cancellationpb.FindCancellationsRequest) (*cancellationpb.FindCancellationsResponse, error) {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing token")
+	}
+
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if req.From != "" {
+		parsed, err := time.Parse(usecase.DateFormat, req.From)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "from must be YYYY-MM-DD")
+		}
+		from = parsed
+	}
+	to := from.AddDate(0, 1, 0)
+	if req.To != "" {
+		parsed, err := time.Parse(usecase.DateFormat, req.To)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "to must be YYYY-MM-DD")
+		}
+		to = parsed
+	}
+
+	records, err := h.cancellationUseCase.FindCancellations(ctx, claims.UserID, from, to)
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+
+	protoRecords := make([]*cancellationpb.MealCancellation, len(records))
+	for i, r := range records {
+		protoRecords[i] = toProtoCancellation(r)
+	}
+	return &cancellationpb.FindCancellationsResponse{Cancellations: protoRecords}, nil
+}
+
+// helper function convert entities.MealCancellationRecord to cancellationpb.MealCancellation
+func toProtoCancellation(r *entities.MealCancellationRecord) *cancellationpb.MealCancellation {
+	return &cancellationpb.MealCancellation{
+		Id:         uint32(r.ID),
+		Roll:       uint32(r.Roll),
+		SemesterId: uint32(r.SemesterID),
+		MealType:   string(r.MealType),
+		Date:       r.Date.Format(usecase.DateFormat),
+		CreatedAt:  r.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,94 @@
+package rest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/cancellation/dto"
+	"github.com/ePSA-eJya/Mess_Management/internal/cancellation/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/ePSA-eJya/Mess_Management/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+)
+
+type HttpCancellationHandler struct {
+	cancellationUseCase usecase.CancellationUseCase
+}
+
+func NewHttpCancellationHandler(useCase usecase.CancellationUseCase) *HttpCancellationHandler {
+	return &HttpCancellationHandler{cancellationUseCase: useCase}
+}
+
+// CancelMeal godoc
+// @Summary Cancel one of the current student's meals
+// @Tags cancellations
+// @Accept json
+// @Produce json
+// @Param cancellation body dto.CancelMealRequest true "Meal to cancel"
+// @Success 201 {object} dto.CancellationResponse
+// @Router /cancellations [post]
+func (h *HttpCancellationHandler) CancelMeal(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	var req dto.CancelMealRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	date, err := time.Parse(usecase.DateFormat, req.Date)
+	if err != nil {
+		return responses.ErrorWithMessage(c, apperror.ErrInvalidFormat, "date must be YYYY-MM-DD")
+	}
+
+	record, err := h.cancellationUseCase.CancelMeal(c.UserContext(), fmt.Sprint(userID), date, entities.MealType(req.MealType))
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.ToCancellationResponse(record))
+}
+
+// FindCancellations godoc
+// @Summary List the current student's meal cancellations
+// @Tags cancellations
+// @Produce json
+// @Param from query string false "First day, YYYY-MM-DD (default: first day of this month)"
+// @Param to query string false "Day after the last, YYYY-MM-DD (default: a month after from)"
+// @Success 200 {array} dto.CancellationResponse
+// @Router /cancellations [get]
+func (h *HttpCancellationHandler) FindCancellations(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if v := c.Query("from"); v != "" {
+		parsed, err := time.Parse(usecase.DateFormat, v)
+		if err != nil {
+			return responses.ErrorWithMessage(c, apperror.ErrInvalidFormat, "from must be YYYY-MM-DD")
+		}
+		from = parsed
+	}
+	to := from.AddDate(0, 1, 0)
+	if v := c.Query("to"); v != "" {
+		parsed, err := time.Parse(usecase.DateFormat, v)
+		if err != nil {
+			return responses.ErrorWithMessage(c, apperror.ErrInvalidFormat, "to must be YYYY-MM-DD")
+		}
+		to = parsed
+	}
+
+	records, err := h.cancellationUseCase.FindCancellations(c.UserContext(), fmt.Sprint(userID), from, to)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToCancellationResponseList(records))
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+)
+
+// MealCount is how many meals of one type a student cancelled
+type MealCount struct {
+	Roll     uint
+	MealType entities.MealType
+	Count    uint
+}
+
+type CancellationRepository interface {
+	// Save stores the cancellation and its event in one transaction
+	Save(ctx context.Context, record *entities.MealCancellationRecord, event *entities.OutboxEvent) error
+	Exists(ctx context.Context, roll uint, date time.Time, mealType entities.MealType) (bool, error)
+	FindByRoll(ctx context.Context, roll uint, from, to time.Time) ([]*entities.MealCancellationRecord, error)
+	CountByStudent(ctx context.Context, from, to time.Time) ([]MealCount, error)
+	// CountRecent counts cancellations of a mess's meal created since since
+	CountRecent(ctx context.Context, messNo uint, date time.Time, mealType entities.MealType, since time.Time) (int64, error)
+}
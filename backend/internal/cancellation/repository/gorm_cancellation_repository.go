@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	outboxRepository "github.com/ePSA-eJya/Mess_Management/internal/outbox/repository"
+	"gorm.io/gorm"
+)
+
+type GormCancellationRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCancellationRepository(db *gorm.DB) CancellationRepository {
+	return &GormCancellationRepository{db: db}
+}
+
+func (r *GormCancellationRepository) Save(ctx context.Context, record *entities.MealCancellationRecord, event *entities.OutboxEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if record.SemesterID == 0 {
+			err := tx.Model(&entities.Semester{}).
+				Select("semester_id").
+				Where("start_date <= ? AND end_date >= ?", record.Date, record.Date).
+				Limit(1).
+				Scan(&record.SemesterID).Error
+			if err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(record).Error; err != nil {
+			return err
+		}
+		return outboxRepository.Enqueue(tx, event)
+	})
+}
+
+func (r *GormCancellationRepository) Exists(ctx context.Context, roll uint, date time.Time, mealType entities.MealType) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.MealCancellationRecord{}).
+		Where("roll = ? AND date = ? AND meal_type = ?", roll, date, mealType).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindByRoll lists a student's cancellations dated in [from, to)
+func (r *GormCancellationRepository) FindByRoll(ctx context.Context, roll uint, from, to time.Time) ([]*entities.MealCancellationRecord, error) {
+	var recordValues []entities.MealCancellationRecord
+	err := r.db.WithContext(ctx).Where("roll = ? AND date >= ? AND date < ?", roll, from, to).
+		Order("date, meal_type").
+		Find(&recordValues).Error
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*entities.MealCancellationRecord, len(recordValues))
+	for i := range recordValues {
+		records[i] = &recordValues[i]
+	}
+	return records, nil
+}
+
+// CountByStudent counts cancellations dated in [from, to)
+func (r *GormCancellationRepository) CountByStudent(ctx context.Context, from, to time.Time) ([]MealCount, error) {
+	var counts []MealCount
+	err := r.db.WithContext(ctx).Model(&entities.MealCancellationRecord{}).
+		Select("roll, meal_type, COUNT(*) AS count").
+		Where("date >= ? AND date < ?", from, to).
+		Group("roll, meal_type").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *GormCancellationRepository) CountRecent(ctx context.Context, messNo uint, date time.Time, mealType entities.MealType, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entities.MealCancellationRecord{}).
+		Joins("JOIN students ON students.roll = meal_cancellation_records.roll").
+		Where("students.mess_no = ? AND meal_cancellation_records.date = ? AND meal_cancellation_records.meal_type = ?", messNo, date, mealType).
+		Where("meal_cancellation_records.created_at >= ?", since).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+)
+
+type CancellationUseCase interface {
+	CancelMeal(ctx context.Context, userID string, date time.Time, mealType entities.MealType) (*entities.MealCancellationRecord, error)
+	FindCancellations(ctx context.Context, userID string, from, to time.Time) ([]*entities.MealCancellationRecord, error)
+}
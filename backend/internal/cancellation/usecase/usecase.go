@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/cancellation/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	studentRepository "github.com/ePSA-eJya/Mess_Management/internal/student/repository"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/logger"
+	"github.com/ePSA-eJya/Mess_Management/pkg/metrics"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pubsub"
+	"github.com/ePSA-eJya/Mess_Management/pkg/tracing"
+)
+
+var log = logger.For("cancellation")
+
+// DateFormat is how meal dates are exchanged with clients
+const DateFormat = "2006-01-02"
+
+// CancellationService
+type CancellationService struct {
+	repo        repository.CancellationRepository
+	studentRepo studentRepository.StudentRepository
+	userRepo    userRepository.UserRepository
+	broker      pubsub.Broker
+	cutoffHour  int
+}
+
+// Init CancellationService, meals of a day can be cancelled until cutoffHour
+// (server local time) on the day before. Cancellations are published on broker
+// for live dashboards
+func NewCancellationService(
+	repo repository.CancellationRepository,
+	studentRepo studentRepository.StudentRepository,
+	userRepo userRepository.UserRepository,
+	broker pubsub.Broker,
+	cutoffHour int,
+) CancellationUseCase {
+	return &CancellationService{
+		repo:        repo,
+		studentRepo: studentRepo,
+		userRepo:    userRepo,
+		broker:      broker,
+		cutoffHour:  cutoffHour,
+	}
+}
+
+// CancellationService Methods - 1 cancel one of the current student's meals before the cutoff
+func (s *CancellationService) CancelMeal(ctx context.Context, userID string, date time.Time, mealType entities.MealType) (_ *entities.MealCancellationRecord, err error) {
+	ctx, span := tracing.Start(ctx, "CancellationService.CancelMeal")
+	defer func() { tracing.End(span, err) }()
+
+	if !mealType.Valid() {
+		return nil, apperror.ErrInvalidData
+	}
+
+	cutoff := time.Date(date.Year(), date.Month(), date.Day()-1, s.cutoffHour, 0, 0, 0, time.Local)
+	if !time.Now().Before(cutoff) {
+		return nil, fmt.Errorf("%w: cancellations for %s closed at %s", apperror.ErrUnprocessable, date.Format(DateFormat), cutoff.Format(time.RFC3339))
+	}
+
+	student, err := s.findStudent(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	exists, err := s.repo.Exists(ctx, student.Roll, day, mealType)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, apperror.ErrAlreadyExists
+	}
+
+	record := &entities.MealCancellationRecord{Roll: student.Roll, MealType: mealType, Date: day}
+	payload := entities.MealCancelledPayload{
+		Roll:     student.Roll,
+		Date:     day.Format(DateFormat),
+		MealType: mealType,
+	}
+	event, err := entities.NewOutboxEvent(entities.EventTypeMealCancelled, "student", strconv.FormatUint(uint64(student.Roll), 10), payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, record, event); err != nil {
+		return nil, err
+	}
+	metrics.MealCancellations.WithLabelValues(string(mealType)).Inc()
+	s.publishCancelled(ctx, student.MessNo, payload)
+	return record, nil
+}
+
+// CancellationService Methods - 2 the current student's cancellations dated in [from, to)
+func (s *CancellationService) FindCancellations(ctx context.Context, userID string, from, to time.Time) (_ []*entities.MealCancellationRecord, err error) {
+	ctx, span := tracing.Start(ctx, "CancellationService.FindCancellations")
+	defer func() { tracing.End(span, err) }()
+
+	if !from.Before(to) {
+		return nil, apperror.ErrOutOfRange
+	}
+
+	student, err := s.findStudent(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindByRoll(ctx, student.Roll, from, to)
+}
+
+// publishCancelled announces a cancellation to the dashboards of the student's
+// mess, the outbox event stays the durable record so failures are only logged
+func (s *CancellationService) publishCancelled(ctx context.Context, messNo uint, payload entities.MealCancelledPayload) {
+	data, err := json.Marshal(payload)
+	if err == nil {
+		err = s.broker.Publish(context.WithoutCancel(ctx), entities.MealCancelledTopic(messNo), data)
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "Publishing cancellation failed", "roll", payload.Roll, "error", err)
+	}
+}
+
+// findStudent links the authenticated user to their student record by email
+func (s *CancellationService) findStudent(ctx context.Context, userID string) (*entities.Student, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, apperror.ErrUnauthorized
+	}
+	student, err := s.studentRepo.FindByEmail(ctx, user.Email)
+	if err != nil {
+		return nil, apperror.ErrForbidden
+	}
+	if student.Status != entities.Active {
+		return nil, apperror.ErrForbidden
+	}
+	return student, nil
+}
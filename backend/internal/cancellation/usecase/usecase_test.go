@@ -0,0 +1,120 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/cancellation/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/cancellation/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	studentRepository "github.com/ePSA-eJya/Mess_Management/internal/student/repository"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pubsub"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+var ctx = context.Background()
+
+type CancellationUseCaseTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	service usecase.CancellationUseCase
+	broker  pubsub.Broker
+	user    *entities.User
+	cleanup func()
+}
+
+func (s *CancellationUseCaseTestSuite) SetupTest() {
+	s.db, s.cleanup = database.SetupTestDB(s.T())
+
+	userRepo := userRepository.NewGormUserRepository(s.db)
+	s.broker = pubsub.NewMemoryBroker()
+	s.service = usecase.NewCancellationService(
+		repository.NewGormCancellationRepository(s.db),
+		studentRepository.NewGormStudentRepository(s.db),
+		userRepo,
+		s.broker,
+		22,
+	)
+
+	s.Require().NoError(s.db.Create(&entities.Student{
+		Roll: 7, Name: "Student", Hostel: "H1", RoomNo: 101, MessNo: 1, Email: "student@example.com",
+	}).Error)
+	s.user = &entities.User{Email: "student@example.com", Password: "password123", Name: "Student"}
+	s.Require().NoError(userRepo.Save(ctx, s.user, nil))
+}
+
+func (s *CancellationUseCaseTestSuite) TearDownTest() {
+	if s.cleanup != nil {
+		s.cleanup()
+	}
+}
+
+func TestCancellationUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CancellationUseCaseTestSuite))
+}
+
+func (s *CancellationUseCaseTestSuite) TestCancelMeal() {
+	date := time.Now().AddDate(0, 0, 3)
+
+	record, err := s.service.CancelMeal(ctx, s.user.ID.String(), date, entities.Lunch)
+	s.NoError(err)
+	s.NotZero(record.ID)
+	s.Equal(uint(7), record.Roll)
+
+	var event entities.OutboxEvent
+	s.NoError(s.db.Where("event_type = ?", entities.EventTypeMealCancelled).First(&event).Error)
+	s.Equal("7", event.AggregateID)
+	s.Contains(event.Payload, date.Format(usecase.DateFormat))
+
+	from := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	records, err := s.service.FindCancellations(ctx, s.user.ID.String(), from, from.AddDate(0, 0, 1))
+	s.NoError(err)
+	s.Len(records, 1)
+}
+
+func (s *CancellationUseCaseTestSuite) TestCancelMeal_PublishesToMess() {
+	updates, err := s.broker.Subscribe(context.Background(), entities.MealCancelledTopic(1))
+	s.Require().NoError(err)
+	date := time.Now().AddDate(0, 0, 3)
+
+	_, err = s.service.CancelMeal(ctx, s.user.ID.String(), date, entities.Dinner)
+	s.Require().NoError(err)
+
+	var published entities.MealCancelledPayload
+	s.Require().NoError(json.Unmarshal(<-updates, &published))
+	s.Equal(uint(7), published.Roll)
+	s.Equal(date.Format(usecase.DateFormat), published.Date)
+	s.Equal(entities.Dinner, published.MealType)
+}
+
+func (s *CancellationUseCaseTestSuite) TestCancelMeal_AfterCutoff() {
+	_, err := s.service.CancelMeal(ctx, s.user.ID.String(), time.Now(), entities.Dinner)
+	s.ErrorIs(err, apperror.ErrUnprocessable)
+
+	var count int64
+	s.NoError(s.db.Model(&entities.OutboxEvent{}).Count(&count).Error)
+	s.Zero(count)
+}
+
+func (s *CancellationUseCaseTestSuite) TestCancelMeal_Twice() {
+	date := time.Now().AddDate(0, 0, 3)
+
+	_, err := s.service.CancelMeal(ctx, s.user.ID.String(), date, entities.Breakfast)
+	s.NoError(err)
+	_, err = s.service.CancelMeal(ctx, s.user.ID.String(), date, entities.Breakfast)
+	s.ErrorIs(err, apperror.ErrAlreadyExists)
+}
+
+func (s *CancellationUseCaseTestSuite) TestCancelMeal_NotAStudent() {
+	other := &entities.User{Email: "staff@example.com", Password: "password123", Name: "Staff"}
+	s.Require().NoError(userRepository.NewGormUserRepository(s.db).Save(ctx, other, nil))
+
+	_, err := s.service.CancelMeal(ctx, other.ID.String(), time.Now().AddDate(0, 0, 3), entities.Lunch)
+	s.ErrorIs(err, apperror.ErrForbidden)
+}
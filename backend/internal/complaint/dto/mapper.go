@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+)
+
+func ToComplaintResponse(complaint *entities.Complaint) *ComplaintResponse {
+	attachments := make([]*AttachmentResponse, 0, len(complaint.Attachments))
+	for i := range complaint.Attachments {
+		attachments = append(attachments, ToAttachmentResponse(&complaint.Attachments[i]))
+	}
+
+	return &ComplaintResponse{
+		ID:              complaint.ID,
+		UserID:          complaint.UserID,
+		MessNo:          complaint.MessNo,
+		Category:        string(complaint.Category),
+		Title:           complaint.Title,
+		Description:     complaint.Description,
+		Status:          string(complaint.Status),
+		AssignedAdminID: complaint.AssignedAdminID,
+		ResolutionNote:  complaint.ResolutionNote,
+		DueAt:           complaint.DueAt,
+		Escalated:       complaint.Escalated,
+		CreatedAt:       complaint.CreatedAt,
+		UpdatedAt:       complaint.UpdatedAt,
+		Attachments:     attachments,
+	}
+}
+
+func ToComplaintPageResponse(page *pagination.Page[*entities.Complaint]) *ComplaintPageResponse {
+	return pagination.Map(page, ToComplaintResponse)
+}
+
+func ToAttachmentResponse(attachment *entities.ComplaintAttachment) *AttachmentResponse {
+	return &AttachmentResponse{
+		ID:          attachment.ID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+func ToComplaintEntity(req *CreateComplaintRequest) *entities.Complaint {
+	return &entities.Complaint{
+		MessNo:      req.MessNo,
+		Category:    entities.ComplaintCategory(req.Category),
+		Title:       req.Title,
+		Description: req.Description,
+	}
+}
@@ -0,0 +1,13 @@
+package dto
+
+type CreateComplaintRequest struct {
+	MessNo      uint   `json:"mess_no" validate:"required,gt=0"`
+	Category    string `json:"category" validate:"required,oneof=HYGIENE FOOD_QUALITY SERVICE INFRASTRUCTURE OTHER"`
+	Title       string `json:"title" validate:"required,max=200"`
+	Description string `json:"description"`
+}
+
+type UpdateComplaintStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=ACKNOWLEDGED RESOLVED CLOSED"`
+	Note   string `json:"note"`
+}
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"github.com/google/uuid"
+)
+
+type ComplaintPageResponse = pagination.Page[*ComplaintResponse]
+
+type ComplaintResponse struct {
+	ID              uint                  `json:"id"`
+	UserID          uuid.UUID             `json:"user_id"`
+	MessNo          uint                  `json:"mess_no"`
+	Category        string                `json:"category"`
+	Title           string                `json:"title"`
+	Description     string                `json:"description"`
+	Status          string                `json:"status"`
+	AssignedAdminID *uint                 `json:"assigned_admin_id"`
+	ResolutionNote  string                `json:"resolution_note,omitempty"`
+	DueAt           time.Time             `json:"due_at"`
+	Escalated       bool                  `json:"escalated"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+	Attachments     []*AttachmentResponse `json:"attachments,omitempty"`
+}
+
+type AttachmentResponse struct {
+	ID          uint      `json:"id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,230 @@
+package rest
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/complaint/dto"
+	"github.com/ePSA-eJya/Mess_Management/internal/complaint/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/ePSA-eJya/Mess_Management/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+)
+
+type HttpComplaintHandler struct {
+	complaintUseCase usecase.ComplaintUseCase
+}
+
+func NewHttpComplaintHandler(useCase usecase.ComplaintUseCase) *HttpComplaintHandler {
+	return &HttpComplaintHandler{complaintUseCase: useCase}
+}
+
+// FileComplaint godoc
+// @Summary File a new mess complaint
+// @Tags complaints
+// @Accept json
+// @Produce json
+// @Param complaint body dto.CreateComplaintRequest true "Complaint payload"
+// @Success 201 {object} dto.ComplaintResponse
+// @Router /complaints [post]
+func (h *HttpComplaintHandler) FileComplaint(c *fiber.Ctx) error {
+	actor, err := actorFromCtx(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	var req dto.CreateComplaintRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	complaint := dto.ToComplaintEntity(&req)
+	if err := h.complaintUseCase.FileComplaint(c.UserContext(), actor, complaint); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.ToComplaintResponse(complaint))
+}
+
+// FindComplaints godoc
+// @Summary Get a page of the complaints visible to the current user
+// @Tags complaints
+// @Produce json
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Page size, at most 100"
+// @Param sort query string false "created_at (default -created_at), due_at or id, prefix with - for descending"
+// @Param filter[status] query string false "Only complaints in this status"
+// @Param filter[category] query string false "Only complaints of this category"
+// @Param filter[escalated] query bool false "Only escalated or not escalated complaints"
+// @Success 200 {object} dto.ComplaintPageResponse
+// @Router /complaints [get]
+func (h *HttpComplaintHandler) FindComplaints(c *fiber.Ctx) error {
+	actor, err := actorFromCtx(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+	query, err := pagination.Parse(c.Queries())
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	page, err := h.complaintUseCase.FindComplaints(c.UserContext(), actor, query)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToComplaintPageResponse(page))
+}
+
+// FindComplaintByID godoc
+// @Summary Get complaint by ID
+// @Tags complaints
+// @Produce json
+// @Param id path int true "Complaint ID"
+// @Success 200 {object} dto.ComplaintResponse
+// @Router /complaints/{id} [get]
+func (h *HttpComplaintHandler) FindComplaintByID(c *fiber.Ctx) error {
+	actor, err := actorFromCtx(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	id, err := parseID(c, "id")
+	if err != nil {
+		return responses.ErrorWithMessage(c, err, "invalid id")
+	}
+
+	complaint, err := h.complaintUseCase.FindComplaintByID(c.UserContext(), actor, id)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToComplaintResponse(complaint))
+}
+
+// UpdateStatus godoc
+// @Summary Move a complaint to its next status
+// @Tags complaints
+// @Accept json
+// @Produce json
+// @Param id path int true "Complaint ID"
+// @Param status body dto.UpdateComplaintStatusRequest true "Status update payload"
+// @Success 200 {object} dto.ComplaintResponse
+// @Router /complaints/{id}/status [patch]
+func (h *HttpComplaintHandler) UpdateStatus(c *fiber.Ctx) error {
+	actor, err := actorFromCtx(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	id, err := parseID(c, "id")
+	if err != nil {
+		return responses.ErrorWithMessage(c, err, "invalid id")
+	}
+
+	var req dto.UpdateComplaintStatusRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	complaint, err := h.complaintUseCase.UpdateStatus(c.UserContext(), actor, id, entities.ComplaintStatus(req.Status), req.Note)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToComplaintResponse(complaint))
+}
+
+// AddAttachment godoc
+// @Summary Attach a photo or document to a complaint
+// @Tags complaints
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Complaint ID"
+// @Param file formData file true "JPEG, PNG or PDF file"
+// @Success 201 {object} dto.AttachmentResponse
+// @Router /complaints/{id}/attachments [post]
+func (h *HttpComplaintHandler) AddAttachment(c *fiber.Ctx) error {
+	actor, err := actorFromCtx(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	id, err := parseID(c, "id")
+	if err != nil {
+		return responses.ErrorWithMessage(c, err, "invalid id")
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return responses.ErrorWithMessage(c, apperror.ErrRequiredField, "file is required")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return responses.Error(c, err)
+	}
+	defer file.Close()
+
+	attachment, err := h.complaintUseCase.AddAttachment(c.UserContext(), actor, id, fileHeader.Filename, file)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.ToAttachmentResponse(attachment))
+}
+
+// DownloadAttachment godoc
+// @Summary Download a complaint attachment
+// @Tags complaints
+// @Produce octet-stream
+// @Param id path int true "Complaint ID"
+// @Param attachmentId path int true "Attachment ID"
+// @Success 200 {file} file
+// @Router /complaints/{id}/attachments/{attachmentId} [get]
+func (h *HttpComplaintHandler) DownloadAttachment(c *fiber.Ctx) error {
+	actor, err := actorFromCtx(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	id, err := parseID(c, "id")
+	if err != nil {
+		return responses.ErrorWithMessage(c, err, "invalid id")
+	}
+	attachmentID, err := parseID(c, "attachmentId")
+	if err != nil {
+		return responses.ErrorWithMessage(c, err, "invalid attachment id")
+	}
+
+	attachment, content, err := h.complaintUseCase.OpenAttachment(c.UserContext(), actor, id, attachmentID)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, attachment.ContentType)
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", attachment.FileName))
+	// fasthttp closes the reader once the body has been written
+	return c.SendStream(content, int(attachment.Size))
+}
+
+func actorFromCtx(c *fiber.Ctx) (usecase.Actor, error) {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return usecase.Actor{}, apperror.ErrUnauthorized
+	}
+	role, _ := c.Locals("role").(string)
+	return usecase.Actor{UserID: fmt.Sprint(userID), Role: entities.Role(role)}, nil
+}
+
+func parseID(c *fiber.Ctx, param string) (uint, error) {
+	id, err := strconv.ParseUint(c.Params(param), 10, 64)
+	if err != nil || id == 0 {
+		return 0, apperror.ErrInvalidID
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+)
+
+// ComplaintFields are the fields complaints can be listed by
+var ComplaintFields = pagination.Fields{
+	Filter:      map[string]string{"status": "status", "category": "category", "escalated": "escalated"},
+	Sort:        map[string]string{"id": "id", "created_at": "created_at", "due_at": "due_at"},
+	DefaultSort: "-created_at",
+}
+
+// ComplaintFilter scopes FindAll to what the caller may see, unlike the
+// query it cannot be chosen by clients; zero values are ignored
+type ComplaintFilter struct {
+	UserID string
+	MessNo uint
+	Status entities.ComplaintStatus
+}
+
+type ComplaintRepository interface {
+	Save(ctx context.Context, complaint *entities.Complaint) error
+	FindByID(ctx context.Context, id uint) (*entities.Complaint, error)
+	FindAll(ctx context.Context, filter ComplaintFilter, query pagination.Query) (*pagination.Page[*entities.Complaint], error)
+	Update(ctx context.Context, complaint *entities.Complaint) error
+	FindOverdue(ctx context.Context, now time.Time) ([]*entities.Complaint, error)
+	SaveAttachment(ctx context.Context, attachment *entities.ComplaintAttachment) error
+	FindAttachment(ctx context.Context, complaintID, attachmentID uint) (*entities.ComplaintAttachment, error)
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+type GormComplaintRepository struct {
+	db *gorm.DB
+}
+
+func NewGormComplaintRepository(db *gorm.DB) ComplaintRepository {
+	return &GormComplaintRepository{db: db}
+}
+
+func (r *GormComplaintRepository) Save(ctx context.Context, complaint *entities.Complaint) error {
+	return r.db.WithContext(ctx).Create(complaint).Error
+}
+
+func (r *GormComplaintRepository) FindByID(ctx context.Context, id uint) (*entities.Complaint, error) {
+	var complaint entities.Complaint
+	if err := r.db.WithContext(ctx).Preload("Attachments").First(&complaint, id).Error; err != nil {
+		return nil, err
+	}
+	return &complaint, nil
+}
+
+func (r *GormComplaintRepository) FindAll(ctx context.Context, filter ComplaintFilter, query pagination.Query) (*pagination.Page[*entities.Complaint], error) {
+	scope := r.db.WithContext(ctx).Model(&entities.Complaint{})
+	if filter.UserID != "" {
+		scope = scope.Where("user_id = ?", filter.UserID)
+	}
+	if filter.MessNo != 0 {
+		scope = scope.Where("mess_no = ?", filter.MessNo)
+	}
+	if filter.Status != "" {
+		scope = scope.Where("status = ?", filter.Status)
+	}
+	return pagination.Find[entities.Complaint](scope, query, ComplaintFields)
+}
+
+func (r *GormComplaintRepository) Update(ctx context.Context, complaint *entities.Complaint) error {
+	result := r.db.WithContext(ctx).Omit("Attachments").Save(complaint)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindOverdue returns unresolved complaints whose SLA deadline has passed
+// and that have not been escalated yet
+func (r *GormComplaintRepository) FindOverdue(ctx context.Context, now time.Time) ([]*entities.Complaint, error) {
+	var complaintValues []entities.Complaint
+	err := r.db.WithContext(ctx).
+		Where("status IN ?", []entities.ComplaintStatus{entities.ComplaintOpen, entities.ComplaintAcknowledged}).
+		Where("escalated = ? AND due_at < ?", false, now).
+		Order("due_at").
+		Find(&complaintValues).Error
+	if err != nil {
+		return nil, err
+	}
+	complaints := make([]*entities.Complaint, len(complaintValues))
+	for i := range complaintValues {
+		complaints[i] = &complaintValues[i]
+	}
+	return complaints, nil
+}
+
+func (r *GormComplaintRepository) SaveAttachment(ctx context.Context, attachment *entities.ComplaintAttachment) error {
+	return r.db.WithContext(ctx).Create(attachment).Error
+}
+
+func (r *GormComplaintRepository) FindAttachment(ctx context.Context, complaintID, attachmentID uint) (*entities.ComplaintAttachment, error) {
+	var attachment entities.ComplaintAttachment
+	if err := r.db.WithContext(ctx).Where("complaint_id = ?", complaintID).First(&attachment, attachmentID).Error; err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+	"io"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+)
+
+// Actor identifies the authenticated user performing a complaint action
+type Actor struct {
+	UserID string
+	Role   entities.Role
+}
+
+type ComplaintUseCase interface {
+	FileComplaint(ctx context.Context, actor Actor, complaint *entities.Complaint) error
+	FindComplaintByID(ctx context.Context, actor Actor, id uint) (*entities.Complaint, error)
+	FindComplaints(ctx context.Context, actor Actor, query pagination.Query) (*pagination.Page[*entities.Complaint], error)
+	UpdateStatus(ctx context.Context, actor Actor, id uint, status entities.ComplaintStatus, note string) (*entities.Complaint, error)
+	// AddAttachment takes the content type from the first bytes of content,
+	// whatever type the client declared is not trusted
+	AddAttachment(ctx context.Context, actor Actor, complaintID uint, fileName string, content io.Reader) (*entities.ComplaintAttachment, error)
+	OpenAttachment(ctx context.Context, actor Actor, complaintID, attachmentID uint) (*entities.ComplaintAttachment, io.ReadCloser, error)
+	EscalateOverdue(ctx context.Context) (int, error)
+}
@@ -0,0 +1,368 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	adminRepository "github.com/ePSA-eJya/Mess_Management/internal/admin/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/complaint/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	notificationUseCase "github.com/ePSA-eJya/Mess_Management/internal/notification/usecase"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/filestore"
+	"github.com/ePSA-eJya/Mess_Management/pkg/logger"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pubsub"
+	"github.com/ePSA-eJya/Mess_Management/pkg/tracing"
+	"github.com/google/uuid"
+)
+
+var log = logger.For("complaint")
+
+// MaxAttachmentSize matches Fiber's default request body limit
+const MaxAttachmentSize = 4 << 20
+
+var allowedAttachmentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"application/pdf": true,
+}
+
+// SLA holds how long a complaint may stay in each unresolved state before
+// it is escalated to the office
+type SLA struct {
+	Acknowledge time.Duration
+	Resolve     time.Duration
+}
+
+// ComplaintService
+type ComplaintService struct {
+	repo      repository.ComplaintRepository
+	adminRepo adminRepository.AdminRepository
+	userRepo  userRepository.UserRepository
+	store     filestore.FileStore
+	notifier  notificationUseCase.NotificationUseCase
+	broker    pubsub.Broker
+	sla       SLA
+}
+
+// Init ComplaintService, new complaints are published on broker for live dashboards
+func NewComplaintService(
+	repo repository.ComplaintRepository,
+	adminRepo adminRepository.AdminRepository,
+	userRepo userRepository.UserRepository,
+	store filestore.FileStore,
+	notifier notificationUseCase.NotificationUseCase,
+	broker pubsub.Broker,
+	sla SLA,
+) ComplaintUseCase {
+	return &ComplaintService{
+		repo:      repo,
+		adminRepo: adminRepo,
+		userRepo:  userRepo,
+		store:     store,
+		notifier:  notifier,
+		broker:    broker,
+		sla:       sla,
+	}
+}
+
+// ComplaintService Methods - 1 file a complaint and route it to the mess admin
+func (s *ComplaintService) FileComplaint(ctx context.Context, actor Actor, complaint *entities.Complaint) (err error) {
+	ctx, span := tracing.Start(ctx, "ComplaintService.FileComplaint")
+	defer func() { tracing.End(span, err) }()
+
+	if !complaint.Category.Valid() || complaint.Title == "" || complaint.MessNo == 0 {
+		return apperror.ErrInvalidData
+	}
+
+	userID, err := uuid.Parse(actor.UserID)
+	if err != nil {
+		return apperror.ErrUnauthorized
+	}
+
+	now := time.Now()
+	complaint.ID = 0
+	complaint.UserID = userID
+	complaint.Status = entities.ComplaintOpen
+	complaint.DueAt = now.Add(s.sla.Acknowledge)
+	complaint.Escalated = false
+
+	messAdmins, err := s.adminRepo.FindByTypeAndMessNo(ctx, entities.Mess, complaint.MessNo)
+	if err != nil {
+		return err
+	}
+	if len(messAdmins) > 0 {
+		complaint.AssignedAdminID = &messAdmins[0].ID
+	}
+
+	if err := s.repo.Save(ctx, complaint); err != nil {
+		return err
+	}
+	s.publishFiled(ctx, complaint)
+	return nil
+}
+
+// ComplaintService Methods - 2 find by id
+func (s *ComplaintService) FindComplaintByID(ctx context.Context, actor Actor, id uint) (_ *entities.Complaint, err error) {
+	ctx, span := tracing.Start(ctx, "ComplaintService.FindComplaintByID")
+	defer func() { tracing.End(span, err) }()
+
+	complaint, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeView(ctx, actor, complaint); err != nil {
+		return nil, err
+	}
+	return complaint, nil
+}
+
+// ComplaintService Methods - 3 find a page of the complaints visible to the actor
+func (s *ComplaintService) FindComplaints(ctx context.Context, actor Actor, query pagination.Query) (_ *pagination.Page[*entities.Complaint], err error) {
+	ctx, span := tracing.Start(ctx, "ComplaintService.FindComplaints")
+	defer func() { tracing.End(span, err) }()
+
+	filter := repository.ComplaintFilter{}
+
+	switch actor.Role {
+	case entities.RoleOfficeAdmin:
+	case entities.RoleMessAdmin:
+		admin, err := s.findActorAdmin(ctx, actor)
+		if err != nil {
+			return nil, err
+		}
+		filter.MessNo = admin.MessNo
+	default:
+		filter.UserID = actor.UserID
+	}
+
+	return s.repo.FindAll(ctx, filter, query)
+}
+
+// ComplaintService Methods - 4 move a complaint through its lifecycle
+func (s *ComplaintService) UpdateStatus(ctx context.Context, actor Actor, id uint, status entities.ComplaintStatus, note string) (_ *entities.Complaint, err error) {
+	ctx, span := tracing.Start(ctx, "ComplaintService.UpdateStatus")
+	defer func() { tracing.End(span, err) }()
+
+	complaint, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !complaint.Status.CanTransitionTo(status) {
+		return nil, apperror.ErrUnprocessable
+	}
+
+	// besides the admins, the complainant may close their own resolved complaint
+	ownerClosing := status == entities.ComplaintClosed && complaint.UserID.String() == actor.UserID
+	if !ownerClosing {
+		if err := s.authorizeManage(ctx, actor, complaint); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	complaint.Status = status
+	switch status {
+	case entities.ComplaintAcknowledged:
+		complaint.AcknowledgedAt = &now
+		complaint.DueAt = now.Add(s.sla.Resolve)
+	case entities.ComplaintResolved:
+		complaint.ResolvedAt = &now
+		complaint.ResolutionNote = note
+	case entities.ComplaintClosed:
+		complaint.ClosedAt = &now
+	}
+
+	if err := s.repo.Update(ctx, complaint); err != nil {
+		return nil, err
+	}
+	s.notifyComplainant(ctx, complaint, string(complaint.Status))
+	return complaint, nil
+}
+
+// ComplaintService Methods - 5 attach a file to a complaint
+func (s *ComplaintService) AddAttachment(ctx context.Context, actor Actor, complaintID uint, fileName string, content io.Reader) (_ *entities.ComplaintAttachment, err error) {
+	ctx, span := tracing.Start(ctx, "ComplaintService.AddAttachment")
+	defer func() { tracing.End(span, err) }()
+
+	// DetectContentType looks at no more than the first 512 bytes
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	contentType := http.DetectContentType(head[:n])
+	if !allowedAttachmentTypes[contentType] {
+		return nil, apperror.ErrInvalidFormat
+	}
+	content = io.MultiReader(bytes.NewReader(head[:n]), content)
+
+	complaint, err := s.repo.FindByID(ctx, complaintID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeView(ctx, actor, complaint); err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("complaints/%d/%s%s", complaint.ID, uuid.NewString(), filepath.Ext(fileName))
+	size, err := s.store.Save(key, io.LimitReader(content, MaxAttachmentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if size > MaxAttachmentSize {
+		_ = s.store.Delete(key)
+		return nil, apperror.ErrOutOfRange
+	}
+
+	attachment := &entities.ComplaintAttachment{
+		ComplaintID: complaint.ID,
+		FileName:    filepath.Base(fileName),
+		ContentType: contentType,
+		Size:        size,
+		StorageKey:  key,
+	}
+	if err := s.repo.SaveAttachment(ctx, attachment); err != nil {
+		_ = s.store.Delete(key)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// ComplaintService Methods - 6 open an attachment for download
+func (s *ComplaintService) OpenAttachment(ctx context.Context, actor Actor, complaintID, attachmentID uint) (_ *entities.ComplaintAttachment, _ io.ReadCloser, err error) {
+	ctx, span := tracing.Start(ctx, "ComplaintService.OpenAttachment")
+	defer func() { tracing.End(span, err) }()
+
+	complaint, err := s.repo.FindByID(ctx, complaintID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.authorizeView(ctx, actor, complaint); err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := s.repo.FindAttachment(ctx, complaintID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.store.Open(attachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+// ComplaintService Methods - 7 hand complaints that breached their SLA to the office
+func (s *ComplaintService) EscalateOverdue(ctx context.Context) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "ComplaintService.EscalateOverdue")
+	defer func() { tracing.End(span, err) }()
+
+	overdue, err := s.repo.FindOverdue(ctx, time.Now())
+	if err != nil || len(overdue) == 0 {
+		return 0, err
+	}
+
+	officeAdmins, err := s.adminRepo.FindByType(ctx, entities.Office)
+	if err != nil {
+		return 0, err
+	}
+	if len(officeAdmins) == 0 {
+		log.WarnContext(ctx, "No office admin available, escalated complaints stay unassigned")
+	}
+
+	escalated := 0
+	for _, complaint := range overdue {
+		now := time.Now()
+		complaint.Escalated = true
+		complaint.EscalatedAt = &now
+		if len(officeAdmins) > 0 {
+			complaint.AssignedAdminID = &officeAdmins[0].ID
+		}
+		if err := s.repo.Update(ctx, complaint); err != nil {
+			return escalated, err
+		}
+		s.notifyComplainant(ctx, complaint, "ESCALATED")
+		escalated++
+	}
+	return escalated, nil
+}
+
+// notifyComplainant tells the student about a change, failures never undo the change itself
+func (s *ComplaintService) notifyComplainant(ctx context.Context, complaint *entities.Complaint, status string) {
+	err := s.notifier.Notify(ctx, complaint.UserID.String(), entities.EventComplaintUpdated, map[string]any{
+		"ComplaintID": complaint.ID,
+		"Title":       complaint.Title,
+		"Status":      status,
+		"Note":        complaint.ResolutionNote,
+	})
+	if err != nil {
+		log.ErrorContext(ctx, "Notifying complainant failed", "complaint_id", complaint.ID, "error", err)
+	}
+}
+
+// publishFiled announces a new complaint to the dashboards of its mess,
+// like notifications failures never undo the complaint
+func (s *ComplaintService) publishFiled(ctx context.Context, complaint *entities.Complaint) {
+	payload, err := json.Marshal(entities.ComplaintFiledPayload{
+		ID:        complaint.ID,
+		Title:     complaint.Title,
+		Category:  complaint.Category,
+		MessNo:    complaint.MessNo,
+		CreatedAt: complaint.CreatedAt,
+	})
+	if err == nil {
+		err = s.broker.Publish(context.WithoutCancel(ctx), entities.ComplaintFiledTopic(complaint.MessNo), payload)
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "Publishing complaint failed", "complaint_id", complaint.ID, "error", err)
+	}
+}
+
+// authorizeView allows the complainant and any admin managing the complaint
+func (s *ComplaintService) authorizeView(ctx context.Context, actor Actor, complaint *entities.Complaint) error {
+	if complaint.UserID.String() == actor.UserID {
+		return nil
+	}
+	return s.authorizeManage(ctx, actor, complaint)
+}
+
+// authorizeManage allows office admins and mess admins of the complaint's mess
+func (s *ComplaintService) authorizeManage(ctx context.Context, actor Actor, complaint *entities.Complaint) error {
+	switch actor.Role {
+	case entities.RoleOfficeAdmin:
+		return nil
+	case entities.RoleMessAdmin:
+		admin, err := s.findActorAdmin(ctx, actor)
+		if err != nil {
+			return err
+		}
+		if admin.MessNo == complaint.MessNo {
+			return nil
+		}
+	}
+	return apperror.ErrForbidden
+}
+
+// findActorAdmin links the authenticated user to their admin record by email
+func (s *ComplaintService) findActorAdmin(ctx context.Context, actor Actor) (*entities.Admin, error) {
+	user, err := s.userRepo.FindByID(ctx, actor.UserID)
+	if err != nil {
+		return nil, apperror.ErrForbidden
+	}
+	admin, err := s.adminRepo.FindByEmail(ctx, user.Email)
+	if err != nil {
+		return nil, apperror.ErrForbidden
+	}
+	return admin, nil
+}
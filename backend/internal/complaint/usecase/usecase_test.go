@@ -0,0 +1,227 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	adminRepository "github.com/ePSA-eJya/Mess_Management/internal/admin/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/complaint/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/complaint/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/internal/notification/notifier"
+	notificationRepository "github.com/ePSA-eJya/Mess_Management/internal/notification/repository"
+	notificationUseCase "github.com/ePSA-eJya/Mess_Management/internal/notification/usecase"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/filestore"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pubsub"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+var ctx = context.Background()
+
+// pngBytes starts with the PNG signature, which is what content sniffing looks for
+const pngBytes = "\x89PNG\r\n\x1a\nplate"
+
+type ComplaintUseCaseTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	service usecase.ComplaintUseCase
+	broker  pubsub.Broker
+	cleanup func()
+
+	student     usecase.Actor
+	messAdmin   usecase.Actor
+	officeAdmin usecase.Actor
+	messAdminID uint
+	officeID    uint
+}
+
+func (s *ComplaintUseCaseTestSuite) SetupTest() {
+	s.db, s.cleanup = database.SetupTestDB(s.T())
+
+	userRepo := userRepository.NewGormUserRepository(s.db)
+	notificationRepo := notificationRepository.NewGormNotificationRepository(s.db)
+	s.broker = pubsub.NewMemoryBroker()
+	s.service = usecase.NewComplaintService(
+		repository.NewGormComplaintRepository(s.db),
+		adminRepository.NewGormAdminRepository(s.db),
+		userRepo,
+		filestore.NewLocalFileStore(s.T().TempDir()),
+		notificationUseCase.NewNotificationService(notificationRepo, userRepo, notifier.NewInAppNotifier(notificationRepo)),
+		s.broker,
+		usecase.SLA{Acknowledge: time.Hour, Resolve: 2 * time.Hour},
+	)
+
+	s.student = s.createUser(userRepo, "student@example.com", entities.RoleStudent)
+	s.messAdmin = s.createUser(userRepo, "mess@example.com", entities.RoleMessAdmin)
+	s.officeAdmin = s.createUser(userRepo, "office@example.com", entities.RoleOfficeAdmin)
+
+	messAdmin := &entities.Admin{Name: "Mess Admin", AdminType: entities.Mess, Hostel: "H1", MessNo: 1, Email: "mess@example.com"}
+	officeAdmin := &entities.Admin{Name: "Office Admin", AdminType: entities.Office, Hostel: "H1", MessNo: 1, Email: "office@example.com"}
+	s.Require().NoError(s.db.Create(messAdmin).Error)
+	s.Require().NoError(s.db.Create(officeAdmin).Error)
+	s.messAdminID = messAdmin.ID
+	s.officeID = officeAdmin.ID
+}
+
+func (s *ComplaintUseCaseTestSuite) TearDownTest() {
+	if s.cleanup != nil {
+		s.cleanup()
+	}
+}
+
+func TestComplaintUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ComplaintUseCaseTestSuite))
+}
+
+func (s *ComplaintUseCaseTestSuite) createUser(repo userRepository.UserRepository, email string, role entities.Role) usecase.Actor {
+	user := &entities.User{Email: email, Password: "password123", Name: email, Role: role}
+	s.Require().NoError(repo.Save(ctx, user, nil))
+	return usecase.Actor{UserID: user.ID.String(), Role: role}
+}
+
+func (s *ComplaintUseCaseTestSuite) fileComplaint() *entities.Complaint {
+	complaint := &entities.Complaint{
+		MessNo:   1,
+		Category: entities.CategoryHygiene,
+		Title:    "Dirty plates",
+	}
+	s.Require().NoError(s.service.FileComplaint(ctx, s.student, complaint))
+	return complaint
+}
+
+func (s *ComplaintUseCaseTestSuite) TestFileComplaint_AssignsMessAdmin() {
+	complaint := s.fileComplaint()
+
+	s.NotZero(complaint.ID)
+	s.Equal(entities.ComplaintOpen, complaint.Status)
+	s.Require().NotNil(complaint.AssignedAdminID)
+	s.Equal(s.messAdminID, *complaint.AssignedAdminID)
+	s.WithinDuration(time.Now().Add(time.Hour), complaint.DueAt, time.Minute)
+}
+
+func (s *ComplaintUseCaseTestSuite) TestFileComplaint_PublishesToMess() {
+	updates, err := s.broker.Subscribe(context.Background(), entities.ComplaintFiledTopic(1))
+	s.Require().NoError(err)
+
+	complaint := s.fileComplaint()
+
+	var published entities.ComplaintFiledPayload
+	s.Require().NoError(json.Unmarshal(<-updates, &published))
+	s.Equal(complaint.ID, published.ID)
+	s.Equal("Dirty plates", published.Title)
+	s.Equal(uint(1), published.MessNo)
+}
+
+func (s *ComplaintUseCaseTestSuite) TestFileComplaint_InvalidCategory() {
+	complaint := &entities.Complaint{MessNo: 1, Category: "NOISE", Title: "Loud"}
+	err := s.service.FileComplaint(ctx, s.student, complaint)
+	s.Equal(apperror.ErrInvalidData, err)
+}
+
+func (s *ComplaintUseCaseTestSuite) TestUpdateStatus_Lifecycle() {
+	complaint := s.fileComplaint()
+
+	updated, err := s.service.UpdateStatus(ctx, s.messAdmin, complaint.ID, entities.ComplaintAcknowledged, "")
+	s.NoError(err)
+	s.Equal(entities.ComplaintAcknowledged, updated.Status)
+	s.NotNil(updated.AcknowledgedAt)
+
+	updated, err = s.service.UpdateStatus(ctx, s.messAdmin, complaint.ID, entities.ComplaintResolved, "Plates replaced")
+	s.NoError(err)
+	s.Equal("Plates replaced", updated.ResolutionNote)
+
+	updated, err = s.service.UpdateStatus(ctx, s.student, complaint.ID, entities.ComplaintClosed, "")
+	s.NoError(err)
+	s.Equal(entities.ComplaintClosed, updated.Status)
+
+	// every transition lands in the complainant's inbox
+	var inbox int64
+	s.NoError(s.db.Model(&entities.Notification{}).Where("user_id = ?", s.student.UserID).Count(&inbox).Error)
+	s.Equal(int64(3), inbox)
+}
+
+func (s *ComplaintUseCaseTestSuite) TestUpdateStatus_SkippingStateRejected() {
+	complaint := s.fileComplaint()
+
+	_, err := s.service.UpdateStatus(ctx, s.messAdmin, complaint.ID, entities.ComplaintResolved, "")
+	s.Equal(apperror.ErrUnprocessable, err)
+}
+
+func (s *ComplaintUseCaseTestSuite) TestUpdateStatus_StudentCannotAcknowledge() {
+	complaint := s.fileComplaint()
+
+	_, err := s.service.UpdateStatus(ctx, s.student, complaint.ID, entities.ComplaintAcknowledged, "")
+	s.Equal(apperror.ErrForbidden, err)
+}
+
+func (s *ComplaintUseCaseTestSuite) TestFindComplaints_ScopedToActor() {
+	s.fileComplaint()
+
+	other := s.createUser(userRepository.NewGormUserRepository(s.db), "other@example.com", entities.RoleStudent)
+	complaints, err := s.service.FindComplaints(ctx, other, pagination.Query{})
+	s.NoError(err)
+	s.Empty(complaints.Items)
+
+	complaints, err = s.service.FindComplaints(ctx, s.messAdmin, pagination.Query{})
+	s.NoError(err)
+	s.Len(complaints.Items, 1)
+}
+
+func (s *ComplaintUseCaseTestSuite) TestEscalateOverdue() {
+	complaint := s.fileComplaint()
+	s.Require().NoError(s.db.Model(&entities.Complaint{}).
+		Where("id = ?", complaint.ID).
+		Update("due_at", time.Now().Add(-time.Minute)).Error)
+
+	escalated, err := s.service.EscalateOverdue(ctx)
+	s.NoError(err)
+	s.Equal(1, escalated)
+
+	found, err := s.service.FindComplaintByID(ctx, s.officeAdmin, complaint.ID)
+	s.NoError(err)
+	s.True(found.Escalated)
+	s.Equal(s.officeID, *found.AssignedAdminID)
+
+	// already escalated complaints are not picked up again
+	escalated, err = s.service.EscalateOverdue(ctx)
+	s.NoError(err)
+	s.Zero(escalated)
+}
+
+func (s *ComplaintUseCaseTestSuite) TestAttachment_RoundTrip() {
+	complaint := s.fileComplaint()
+
+	attachment, err := s.service.AddAttachment(ctx, s.student, complaint.ID, "plate.png", strings.NewReader(pngBytes))
+	s.NoError(err)
+	s.Equal(int64(len(pngBytes)), attachment.Size)
+	s.Equal("image/png", attachment.ContentType)
+
+	found, content, err := s.service.OpenAttachment(ctx, s.messAdmin, complaint.ID, attachment.ID)
+	s.NoError(err)
+	defer content.Close()
+	s.Equal("plate.png", found.FileName)
+
+	data, err := io.ReadAll(content)
+	s.NoError(err)
+	s.Equal(pngBytes, string(data))
+}
+
+func (s *ComplaintUseCaseTestSuite) TestAttachment_RejectsUnsupportedType() {
+	complaint := s.fileComplaint()
+
+	_, err := s.service.AddAttachment(ctx, s.student, complaint.ID, "script.sh", strings.NewReader("#!"))
+	s.Equal(apperror.ErrInvalidFormat, err)
+
+	// a .png name does not get an HTML page through
+	_, err = s.service.AddAttachment(ctx, s.student, complaint.ID, "plate.png", strings.NewReader("<html><script>alert(1)</script></html>"))
+	s.Equal(apperror.ErrInvalidFormat, err)
+}
@@ -0,0 +1,127 @@
+package rest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/dashboard/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/logger"
+	"github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/ePSA-eJya/Mess_Management/pkg/token"
+	"github.com/gofiber/fiber/v2"
+)
+
+var log = logger.For("dashboard")
+
+// heartbeatInterval keeps idle streams open through proxies that drop silent connections
+const heartbeatInterval = 25 * time.Second
+
+type HttpDashboardHandler struct {
+	dashboardUseCase usecase.DashboardUseCase
+	revocations      middleware.RevocationChecker
+}
+
+// NewHttpDashboardHandler checks the caller's token against revocations on
+// every heartbeat, so a stream ends once its session does
+func NewHttpDashboardHandler(useCase usecase.DashboardUseCase, revocations middleware.RevocationChecker) *HttpDashboardHandler {
+	return &HttpDashboardHandler{dashboardUseCase: useCase, revocations: revocations}
+}
+
+// Stream godoc
+// @Summary Live dashboard of a mess as server-sent events
+// @Description Streams meal_count, complaint and cancellation_spike events. EventSource clients may pass the token as access_token.
+// @Tags dashboard
+// @Produce text/event-stream
+// @Param mess_no query int false "Mess to watch, required for office admins"
+// @Param access_token query string false "Access token when the Authorization header cannot be set"
+// @Success 200 {string} string "event stream"
+// @Router /dashboard/stream [get]
+func (h *HttpDashboardHandler) Stream(c *fiber.Ctx) error {
+	actor, err := actorFromCtx(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+	claims, ok := c.Locals("claims").(*token.Claims)
+	if !ok {
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+	messNo := c.QueryInt("mess_no")
+	if messNo < 0 {
+		return responses.ErrorWithMessage(c, apperror.ErrInvalidData, "invalid mess_no")
+	}
+
+	// the stream outlives the handler, it is cancelled once the client is gone
+	// and keeps the request ID for its logs
+	ctx, cancel := context.WithCancel(context.WithoutCancel(c.UserContext()))
+	events, err := h.dashboardUseCase.Watch(ctx, actor, uint(messNo))
+	if err != nil {
+		cancel()
+		return responses.Error(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				data, err := json.Marshal(event.Data)
+				if err != nil {
+					log.ErrorContext(ctx, "Encoding dashboard event failed", "event", event.Type, "error", err)
+					continue
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+			case <-heartbeat.C:
+				if !h.stillAuthorized(ctx, claims) {
+					return
+				}
+				fmt.Fprint(w, ": heartbeat\n\n")
+			}
+			// a failed flush means the client disconnected
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+	return nil
+}
+
+// stillAuthorized reports whether the token the stream was opened with has
+// neither expired nor been revoked since, a deleted user's sessions are revoked
+// and a demoted admin's token runs out
+func (h *HttpDashboardHandler) stillAuthorized(ctx context.Context, claims *token.Claims) bool {
+	if claims.ExpiresAt != nil && !time.Now().Before(claims.ExpiresAt.Time) {
+		return false
+	}
+	revoked, err := h.revocations.IsRevoked(ctx, claims)
+	if err != nil {
+		log.ErrorContext(ctx, "Checking dashboard session failed", "error", err)
+		return false
+	}
+	return !revoked
+}
+
+func actorFromCtx(c *fiber.Ctx) (usecase.Actor, error) {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return usecase.Actor{}, apperror.ErrUnauthorized
+	}
+	role, _ := c.Locals("role").(string)
+	return usecase.Actor{UserID: fmt.Sprint(userID), Role: entities.Role(role)}, nil
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+)
+
+// EventType is the name a dashboard update is streamed under
+type EventType string
+
+const (
+	EventMealCount         EventType = "meal_count"
+	EventComplaint         EventType = "complaint"
+	EventCancellationSpike EventType = "cancellation_spike"
+)
+
+// Event is one live update of a mess dashboard, Data is the JSON body
+type Event struct {
+	Type EventType
+	Data any
+}
+
+// CancellationSpike is raised when a meal collects many cancellations in a short window
+type CancellationSpike struct {
+	MessNo        uint              `json:"mess_no"`
+	Date          string            `json:"date"`
+	MealType      entities.MealType `json:"meal_type"`
+	Count         int64             `json:"count"`
+	WindowMinutes int               `json:"window_minutes"`
+}
+
+// Actor is the authenticated caller
+type Actor struct {
+	UserID string
+	Role   entities.Role
+}
+
+type DashboardUseCase interface {
+	// Watch streams the current day's meal counts, new complaints and cancellation spikes
+	// of a mess until ctx is done. Mess admins always watch their own mess,
+	// office admins pick one with messNo.
+	Watch(ctx context.Context, actor Actor, messNo uint) (<-chan Event, error)
+}
@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	adminRepository "github.com/ePSA-eJya/Mess_Management/internal/admin/repository"
+	attendanceUseCase "github.com/ePSA-eJya/Mess_Management/internal/attendance/usecase"
+	cancellationRepository "github.com/ePSA-eJya/Mess_Management/internal/cancellation/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/logger"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pubsub"
+)
+
+var log = logger.For("dashboard")
+
+// SpikeAlert raises a CancellationSpike once a meal collects Threshold
+// cancellations within Window
+type SpikeAlert struct {
+	Threshold int
+	Window    time.Duration
+}
+
+// DashboardService
+type DashboardService struct {
+	adminRepo        adminRepository.AdminRepository
+	userRepo         userRepository.UserRepository
+	cancellationRepo cancellationRepository.CancellationRepository
+	attendance       attendanceUseCase.AttendanceUseCase
+	broker           pubsub.Broker
+	spike            SpikeAlert
+}
+
+// Init DashboardService, updates are read from the topics the attendance,
+// complaint and cancellation services publish on broker
+func NewDashboardService(
+	adminRepo adminRepository.AdminRepository,
+	userRepo userRepository.UserRepository,
+	cancellationRepo cancellationRepository.CancellationRepository,
+	attendance attendanceUseCase.AttendanceUseCase,
+	broker pubsub.Broker,
+	spike SpikeAlert,
+) DashboardUseCase {
+	return &DashboardService{
+		adminRepo:        adminRepo,
+		userRepo:         userRepo,
+		cancellationRepo: cancellationRepo,
+		attendance:       attendance,
+		broker:           broker,
+		spike:            spike,
+	}
+}
+
+// DashboardService Methods - 1 merge the live updates of a mess into one stream
+func (s *DashboardService) Watch(ctx context.Context, actor Actor, messNo uint) (<-chan Event, error) {
+	messNo, err := s.resolveMess(ctx, actor, messNo)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	events := make(chan Event, 16)
+	var wg sync.WaitGroup
+	forward := func(run func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run()
+		}()
+	}
+
+	// counters start with their current value, like WatchMealCount
+	day := time.Now()
+	counts, stopDay, err := s.watchDay(ctx, messNo, day)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// counters belong to one day, at midnight the stream moves on to the next day's
+	forward(func() {
+		for {
+			rollover := time.NewTimer(time.Until(nextDay(day)))
+			for rolled := false; !rolled; {
+				select {
+				case count, ok := <-counts:
+					if !ok {
+						rollover.Stop()
+						stopDay()
+						return
+					}
+					send(ctx, events, Event{Type: EventMealCount, Data: count})
+				case <-rollover.C:
+					rolled = true
+				}
+			}
+			stopDay()
+
+			day = nextDay(day)
+			if counts, stopDay, err = s.watchDay(ctx, messNo, day); err != nil {
+				// ending the stream lets the client reconnect rather than miss the new day
+				log.ErrorContext(ctx, "Watching the next day's meal counts failed", "mess_no", messNo, "error", err)
+				cancel()
+				return
+			}
+		}
+	})
+
+	complaints, err := s.broker.Subscribe(ctx, entities.ComplaintFiledTopic(messNo))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	cancellations, err := s.broker.Subscribe(ctx, entities.MealCancelledTopic(messNo))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	forward(func() {
+		for payload := range complaints {
+			var complaint entities.ComplaintFiledPayload
+			if err := json.Unmarshal(payload, &complaint); err != nil {
+				log.ErrorContext(ctx, "Decoding complaint update failed", "error", err)
+				continue
+			}
+			send(ctx, events, Event{Type: EventComplaint, Data: complaint})
+		}
+	})
+	forward(func() {
+		// one alert per meal and window, further cancellations are part of the same spike
+		alerted := map[string]time.Time{}
+		for payload := range cancellations {
+			var cancellation entities.MealCancelledPayload
+			if err := json.Unmarshal(payload, &cancellation); err != nil {
+				log.ErrorContext(ctx, "Decoding cancellation update failed", "error", err)
+				continue
+			}
+			key := cancellation.Date + "." + string(cancellation.MealType)
+			if time.Since(alerted[key]) < s.spike.Window {
+				continue
+			}
+			spike, err := s.detectSpike(ctx, messNo, cancellation)
+			if err != nil {
+				log.ErrorContext(ctx, "Counting cancellations failed", "mess_no", messNo, "error", err)
+				continue
+			}
+			if spike != nil {
+				alerted[key] = time.Now()
+				send(ctx, events, Event{Type: EventCancellationSpike, Data: spike})
+			}
+		}
+	})
+
+	go func() {
+		wg.Wait()
+		cancel()
+		close(events)
+	}()
+	return events, nil
+}
+
+// watchDay merges the counters of every meal of day into one channel, stop ends them
+func (s *DashboardService) watchDay(ctx context.Context, messNo uint, day time.Time) (<-chan *attendanceUseCase.MealCount, context.CancelFunc, error) {
+	ctx, stop := context.WithCancel(ctx)
+	merged := make(chan *attendanceUseCase.MealCount)
+	var wg sync.WaitGroup
+	for _, mealType := range []entities.MealType{entities.Breakfast, entities.Lunch, entities.Dinner} {
+		counts, err := s.attendance.WatchMealCount(ctx, messNo, day, mealType)
+		if err != nil {
+			stop()
+			return nil, nil, err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for count := range counts {
+				select {
+				case merged <- count:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(merged)
+	}()
+	return merged, stop, nil
+}
+
+// nextDay is the local midnight after day, when attendance starts counting the next day's meals
+func nextDay(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, time.Local)
+}
+
+// detectSpike counts the recent cancellations of the meal a cancellation belongs to
+func (s *DashboardService) detectSpike(ctx context.Context, messNo uint, cancellation entities.MealCancelledPayload) (*CancellationSpike, error) {
+	date, err := time.Parse(attendanceUseCase.DateFormat, cancellation.Date)
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.cancellationRepo.CountRecent(ctx, messNo, date, cancellation.MealType, time.Now().Add(-s.spike.Window))
+	if err != nil {
+		return nil, err
+	}
+	if count < int64(s.spike.Threshold) {
+		return nil, nil
+	}
+	return &CancellationSpike{
+		MessNo:        messNo,
+		Date:          cancellation.Date,
+		MealType:      cancellation.MealType,
+		Count:         count,
+		WindowMinutes: int(s.spike.Window / time.Minute),
+	}, nil
+}
+
+// resolveMess binds mess admins to the mess of their admin record and
+// requires office admins to pick one
+func (s *DashboardService) resolveMess(ctx context.Context, actor Actor, messNo uint) (uint, error) {
+	switch actor.Role {
+	case entities.RoleOfficeAdmin:
+		if messNo == 0 {
+			return 0, fmt.Errorf("%w: mess_no is required", apperror.ErrInvalidData)
+		}
+		return messNo, nil
+	case entities.RoleMessAdmin:
+		user, err := s.userRepo.FindByID(ctx, actor.UserID)
+		if err != nil {
+			return 0, apperror.ErrForbidden
+		}
+		admin, err := s.adminRepo.FindByEmail(ctx, user.Email)
+		if err != nil {
+			return 0, apperror.ErrForbidden
+		}
+		if messNo != 0 && messNo != admin.MessNo {
+			return 0, apperror.ErrForbidden
+		}
+		return admin.MessNo, nil
+	}
+	return 0, apperror.ErrForbidden
+}
+
+func send(ctx context.Context, events chan<- Event, event Event) {
+	select {
+	case events <- event:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,154 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	adminRepository "github.com/ePSA-eJya/Mess_Management/internal/admin/repository"
+	attendanceRepository "github.com/ePSA-eJya/Mess_Management/internal/attendance/repository"
+	attendanceUseCase "github.com/ePSA-eJya/Mess_Management/internal/attendance/usecase"
+	cancellationRepository "github.com/ePSA-eJya/Mess_Management/internal/cancellation/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/dashboard/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	studentRepository "github.com/ePSA-eJya/Mess_Management/internal/student/repository"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pubsub"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+var ctx = context.Background()
+
+type DashboardUseCaseTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	broker  pubsub.Broker
+	service usecase.DashboardUseCase
+	cleanup func()
+
+	messAdmin   usecase.Actor
+	officeAdmin usecase.Actor
+	student     usecase.Actor
+}
+
+func (s *DashboardUseCaseTestSuite) SetupTest() {
+	s.db, s.cleanup = database.SetupTestDB(s.T())
+	s.broker = pubsub.NewMemoryBroker()
+
+	userRepo := userRepository.NewGormUserRepository(s.db)
+	studentRepo := studentRepository.NewGormStudentRepository(s.db)
+	cancellationRepo := cancellationRepository.NewGormCancellationRepository(s.db)
+	s.service = usecase.NewDashboardService(
+		adminRepository.NewGormAdminRepository(s.db),
+		userRepo,
+		cancellationRepo,
+		attendanceUseCase.NewAttendanceService(
+			attendanceRepository.NewGormAttendanceRepository(s.db),
+			studentRepo,
+			cancellationRepo,
+			s.broker,
+		),
+		s.broker,
+		usecase.SpikeAlert{Threshold: 2, Window: time.Hour},
+	)
+
+	s.messAdmin = s.createUser(userRepo, "mess@example.com", entities.RoleMessAdmin)
+	s.officeAdmin = s.createUser(userRepo, "office@example.com", entities.RoleOfficeAdmin)
+	s.student = s.createUser(userRepo, "student@example.com", entities.RoleStudent)
+	s.Require().NoError(s.db.Create(&entities.Admin{Name: "Mess Admin", AdminType: entities.Mess, Hostel: "H1", MessNo: 1, Email: "mess@example.com"}).Error)
+
+	for _, student := range []entities.Student{
+		{Roll: 1, Name: "First", Hostel: "H1", RoomNo: 101, MessNo: 1, Email: "first@example.com"},
+		{Roll: 2, Name: "Second", Hostel: "H1", RoomNo: 102, MessNo: 1, Email: "second@example.com"},
+	} {
+		s.Require().NoError(s.db.Create(&student).Error)
+	}
+}
+
+func (s *DashboardUseCaseTestSuite) TearDownTest() {
+	if s.cleanup != nil {
+		s.cleanup()
+	}
+}
+
+func TestDashboardUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(DashboardUseCaseTestSuite))
+}
+
+func (s *DashboardUseCaseTestSuite) createUser(repo userRepository.UserRepository, email string, role entities.Role) usecase.Actor {
+	user := &entities.User{Email: email, Password: "password123", Name: email, Role: role}
+	s.Require().NoError(repo.Save(ctx, user, nil))
+	return usecase.Actor{UserID: user.ID.String(), Role: role}
+}
+
+// watch opens a dashboard and drains the meal count snapshots sent first
+func (s *DashboardUseCaseTestSuite) watch(actor usecase.Actor, messNo uint) <-chan usecase.Event {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.T().Cleanup(cancel)
+
+	events, err := s.service.Watch(ctx, actor, messNo)
+	s.Require().NoError(err)
+	for range 3 {
+		s.Equal(usecase.EventMealCount, s.next(events).Type)
+	}
+	return events
+}
+
+func (s *DashboardUseCaseTestSuite) next(events <-chan usecase.Event) usecase.Event {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		s.FailNow("no dashboard event")
+		return usecase.Event{}
+	}
+}
+
+func (s *DashboardUseCaseTestSuite) publish(topic string, payload any) {
+	data, err := json.Marshal(payload)
+	s.Require().NoError(err)
+	s.Require().NoError(s.broker.Publish(context.Background(), topic, data))
+}
+
+func (s *DashboardUseCaseTestSuite) TestWatch_Complaints() {
+	events := s.watch(s.messAdmin, 0)
+
+	s.publish(entities.ComplaintFiledTopic(1), entities.ComplaintFiledPayload{ID: 3, Title: "Cold food", MessNo: 1})
+
+	event := s.next(events)
+	s.Equal(usecase.EventComplaint, event.Type)
+	s.Equal("Cold food", event.Data.(entities.ComplaintFiledPayload).Title)
+}
+
+func (s *DashboardUseCaseTestSuite) TestWatch_CancellationSpike() {
+	events := s.watch(s.officeAdmin, 1)
+
+	date := time.Now().AddDate(0, 0, 2)
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	for _, roll := range []uint{1, 2} {
+		s.Require().NoError(s.db.Create(&entities.MealCancellationRecord{Roll: roll, MealType: entities.Dinner, Date: day}).Error)
+	}
+	s.publish(entities.MealCancelledTopic(1), entities.MealCancelledPayload{Roll: 2, Date: day.Format(attendanceUseCase.DateFormat), MealType: entities.Dinner})
+
+	event := s.next(events)
+	s.Equal(usecase.EventCancellationSpike, event.Type)
+	spike := event.Data.(*usecase.CancellationSpike)
+	s.Equal(int64(2), spike.Count)
+	s.Equal(entities.Dinner, spike.MealType)
+	s.Equal(60, spike.WindowMinutes)
+}
+
+func (s *DashboardUseCaseTestSuite) TestWatch_Authorization() {
+	_, err := s.service.Watch(context.Background(), s.student, 1)
+	s.ErrorIs(err, apperror.ErrForbidden)
+
+	_, err = s.service.Watch(context.Background(), s.messAdmin, 2)
+	s.ErrorIs(err, apperror.ErrForbidden)
+
+	_, err = s.service.Watch(context.Background(), s.officeAdmin, 0)
+	s.ErrorIs(err, apperror.ErrInvalidData)
+}
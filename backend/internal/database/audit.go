@@ -0,0 +1,24 @@
+package database
+
+import "gorm.io/gorm"
+
+// auditTriggerSQL rejects every UPDATE and DELETE on audit_logs, so entries can
+// only be appended. TRUNCATE is left to the owner of the database.
+const auditTriggerSQL = `
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_logs_append_only ON audit_logs;
+CREATE TRIGGER audit_logs_append_only
+	BEFORE UPDATE OR DELETE ON audit_logs
+	FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
+`
+
+// CreateAuditTriggers makes audit_logs append-only, it runs after AutoMigrate
+// has created the table
+func CreateAuditTriggers(db *gorm.DB) error {
+	return db.Exec(auditTriggerSQL).Error
+}
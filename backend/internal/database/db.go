@@ -2,20 +2,26 @@ package database
 
 import (
 	"database/sql"
-	"fmt"
-	"log"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/logger"
+	"github.com/ePSA-eJya/Mess_Management/pkg/metrics"
+	"github.com/ePSA-eJya/Mess_Management/pkg/tracing"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
 )
 
+var log = logger.For("database")
+
 var (
 	DB    *gorm.DB
 	sqlDB *sql.DB
 )
 
 func Connect(dsn string) (*gorm.DB, error) {
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: SlogLogger()})
 	if err != nil {
 		return nil, err
 	}
@@ -24,15 +30,33 @@ func Connect(dsn string) (*gorm.DB, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := db.Use(tracing.GormPlugin()); err != nil {
+		return nil, err
+	}
+	if err := metrics.RegisterDB(sqlDB, "postgres"); err != nil {
+		return nil, err
+	}
 
 	DB = db
-	log.Println("✅ Database connected")
+	log.Info("Database connected")
 	return db, nil
 }
 
+// SlogLogger hands the statements GORM runs to the "gorm" logger: every one
+// at info, slow ones at warn and failed ones at error. Parameters are left
+// out so passwords and tokens never reach the logs.
+func SlogLogger() gormlogger.Interface {
+	return gormlogger.NewSlogLogger(logger.For("gorm"), gormlogger.Config{
+		SlowThreshold:             200 * time.Millisecond,
+		LogLevel:                  gormlogger.Info,
+		IgnoreRecordNotFoundError: true,
+		ParameterizedQueries:      true,
+	})
+}
+
 // Close closes the underlying sql.DB connection pool
 func Close() error {
-	fmt.Println("🔒 Closing Database connection...")
+	log.Info("Closing database connection")
 	if sqlDB != nil {
 		return sqlDB.Close()
 	}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// enumTypes lists the Postgres enum types referenced by `gorm:"type:..."` tags
+var enumTypes = []struct {
+	name   string
+	values []string
+}{
+	{name: "admin_type", values: []string{"OFFICE_IN_CHARGE", "MESS_IN_CHARGE"}},
+	{name: "student_status", values: []string{"ACTIVE", "INACTIVE"}},
+	{name: "meal_type", values: []string{"BREAKFAST", "LUNCH", "DINNER"}},
+	{name: "semester_type", values: []string{"ODD", "EVEN"}},
+}
+
+// CreateEnumTypes creates the enum types AutoMigrate relies on but cannot create itself
+func CreateEnumTypes(db *gorm.DB) error {
+	for _, enum := range enumTypes {
+		quoted := make([]string, len(enum.values))
+		for i, v := range enum.values {
+			quoted[i] = "'" + v + "'"
+		}
+		stmt := fmt.Sprintf(
+			`DO $$ BEGIN CREATE TYPE %s AS ENUM (%s); EXCEPTION WHEN duplicate_object THEN NULL; END $$;`,
+			enum.name, strings.Join(quoted, ", "),
+		)
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolation is the SQLSTATE Postgres fails an insert or update with
+// when it would break a unique index
+const uniqueViolation = "23505"
+
+// IsUniqueViolation reports whether err was raised by a unique index, such as
+// a second request slipping in between an existence check and its insert
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
@@ -0,0 +1,28 @@
+package database
+
+import (
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"gorm.io/gorm"
+)
+
+// replacedIndexes were superseded by indexes that skip soft-deleted rows.
+// AutoMigrate creates the new ones but never drops the old.
+var replacedIndexes = []struct {
+	model any
+	name  string
+}{
+	{model: &entities.User{}, name: "idx_users_email"},
+}
+
+// DropReplacedIndexes drops the indexes listed in replacedIndexes that still exist
+func DropReplacedIndexes(db *gorm.DB) error {
+	for _, index := range replacedIndexes {
+		if !db.Migrator().HasIndex(index.model, index.name) {
+			continue
+		}
+		if err := db.Migrator().DropIndex(index.model, index.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
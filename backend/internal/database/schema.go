@@ -0,0 +1,28 @@
+package database
+
+import (
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CurrentSchemaVersion is the schema this build migrates to. Raise it along
+// with any change to the entities or to the SQL run after AutoMigrate, so
+// replicas still on the old schema report not ready.
+const CurrentSchemaVersion = 1
+
+// RecordSchemaVersion marks CurrentSchemaVersion as applied, it runs once
+// every migration step has succeeded
+func RecordSchemaVersion(db *gorm.DB) error {
+	return db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entities.SchemaVersion{Version: CurrentSchemaVersion, AppliedAt: time.Now()}).Error
+}
+
+// AppliedSchemaVersion returns the newest version recorded, 0 when none is
+func AppliedSchemaVersion(db *gorm.DB) (int, error) {
+	var version int
+	err := db.Model(&entities.SchemaVersion{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	return version, err
+}
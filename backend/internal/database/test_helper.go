@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/tracing"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/joho/godotenv"
 	"gorm.io/driver/postgres"
@@ -43,15 +44,58 @@ func SetupTestDB(t *testing.T) (*gorm.DB, func()) {
 		dbHost, dbPort, dbUser, dbPassword, testDBName,
 	)
 
-	db, err := gorm.Open(postgres.Open(testDSN), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(testDSN), &gorm.Config{Logger: SlogLogger()})
 	if err != nil {
 		t.Fatalf("Failed to connect to test database: %v", err)
 	}
+	if err := db.Use(tracing.GormPlugin()); err != nil {
+		t.Fatalf("Failed to instrument test database: %v", err)
+	}
 
 	// Run migrations
-	if err := db.AutoMigrate(&entities.User{}, &entities.Order{}); err != nil {
+	if err := CreateEnumTypes(db); err != nil {
+		t.Fatalf("Failed to create enum types: %v", err)
+	}
+	if err := db.AutoMigrate(
+		&entities.User{},
+		&entities.Order{},
+		&entities.Admin{},
+		&entities.Complaint{},
+		&entities.ComplaintAttachment{},
+		&entities.Notification{},
+		&entities.NotificationPreference{},
+		&entities.Student{},
+		&entities.Semester{},
+		&entities.MealCancellationRecord{},
+		&entities.MonthlyBill{},
+		&entities.JobRun{},
+		&entities.Payment{},
+		&entities.OutboxEvent{},
+		&entities.OutboxDelivery{},
+		&entities.WebhookSubscription{},
+		&entities.Session{},
+		&entities.RefreshToken{},
+		&entities.UserToken{},
+		&entities.LoginThrottle{},
+		&entities.SSOLoginState{},
+		&entities.ExternalIdentity{},
+		&entities.MealAttendance{},
+		&entities.AuditLog{},
+		&entities.IdempotencyKey{},
+		&entities.RateLimitBucket{},
+		&entities.SchemaVersion{},
+	); err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)
 	}
+	if err := CreateAuditTriggers(db); err != nil {
+		t.Fatalf("Failed to create audit triggers: %v", err)
+	}
+	if err := DropReplacedIndexes(db); err != nil {
+		t.Fatalf("Failed to drop replaced indexes: %v", err)
+	}
+	if err := RecordSchemaVersion(db); err != nil {
+		t.Fatalf("Failed to record schema version: %v", err)
+	}
 
 	// Clean up tables before test
 	// This ensures each test starts with a clean database
@@ -70,7 +114,7 @@ func SetupTestDB(t *testing.T) (*gorm.DB, func()) {
 func cleanupTables(db *gorm.DB) {
 	// Truncate tables with CASCADE to handle foreign keys
 	// RESTART IDENTITY resets auto-increment counters
-	_ = db.Exec("TRUNCATE TABLE users, orders RESTART IDENTITY CASCADE")
+	_ = db.Exec("TRUNCATE TABLE users, orders, admins, complaints, complaint_attachments, notifications, notification_preferences, students, semesters, meal_cancellation_records, monthly_bills, job_runs, payments, outbox_events, outbox_deliveries, webhook_subscriptions, sessions, refresh_tokens, user_tokens, login_throttles, sso_login_states, external_identities, meal_attendances, audit_logs, idempotency_keys, rate_limit_buckets RESTART IDENTITY CASCADE")
 }
 
 func getEnv(key, fallback string) string {
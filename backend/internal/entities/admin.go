@@ -3,14 +3,14 @@ package entities
 type AdminType string
 
 const (
-	Office AdminType = "Office"
-	Mess   AdminType = "Mess"
+	Office AdminType = "OFFICE_IN_CHARGE"
+	Mess   AdminType = "MESS_IN_CHARGE"
 )
 
 type Admin struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
 	Name      string    `gorm:"size:100;not null" json:"name"`
-	AdminType AdminType `gorm:"type:admin_type;default:'OFFICE'" json:"admin_type"`
+	AdminType AdminType `gorm:"type:admin_type;default:'OFFICE_IN_CHARGE'" json:"admin_type"`
 	Hostel    string    `gorm:"size:100;not null" json:"hostel"`
 	MessNo    uint      `gorm:"not null" json:"mess_no"`
 	Phone     string    `gorm:"size:15" json:"phone"`
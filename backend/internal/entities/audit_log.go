@@ -0,0 +1,137 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+// Audited actions
+const (
+	AuditUserCreated     = "user.created"
+	AuditUserUpdated     = "user.updated"
+	AuditUserDeleted     = "user.deleted"
+	AuditUserRestored    = "user.restored"
+	AuditStudentRestored = "student.restored"
+	AuditBillGenerated   = "bill.generated"
+	AuditPaymentRecorded = "payment.recorded"
+)
+
+// auditIgnored fields are left out of the audit log, secrets, the
+// timestamps the log keeps itself and row versions
+var auditIgnored = []string{"password", "created_at", "updated_at", "version"}
+
+// AuditActor is who made an audited change and from where, scheduled jobs
+// act as SystemActor
+type AuditActor struct {
+	UserID string
+	IP     string
+}
+
+var SystemActor = AuditActor{UserID: "system"}
+
+// AuditChange is the value of a field before and after a change, nil when
+// the entity did not exist before or after it
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditLog is an append-only record of a change. Each entry hashes its
+// content together with the hash of the previous entry, so editing or
+// removing an entry breaks the chain from there on.
+type AuditLog struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ActorID    string    `gorm:"size:100;not null;index" json:"actor_id"`
+	IP         string    `gorm:"size:64" json:"ip"`
+	Action     string    `gorm:"size:50;not null;index" json:"action"`
+	EntityType string    `gorm:"size:50;not null;index:idx_audit_logs_entity" json:"entity_type"`
+	EntityID   string    `gorm:"size:100;not null;index:idx_audit_logs_entity" json:"entity_id"`
+	Changes    string    `gorm:"type:jsonb;not null" json:"changes"`
+	CreatedAt  time.Time `gorm:"not null" json:"created_at"`
+	PrevHash   string    `gorm:"size:64;not null" json:"prev_hash"`
+	Hash       string    `gorm:"size:64;not null;uniqueIndex" json:"hash"`
+}
+
+// NewAuditLog records the fields that differ between before and after, either
+// of which is nil when the entity is created or deleted. Both are compared in
+// their JSON form.
+func NewAuditLog(actor AuditActor, action, entityType, entityID string, before, after any) (*AuditLog, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]AuditChange)
+	for field, value := range beforeFields {
+		if next, ok := afterFields[field]; !ok || !reflect.DeepEqual(value, next) {
+			changes[field] = AuditChange{Before: value, After: afterFields[field]}
+		}
+	}
+	for field, value := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			changes[field] = AuditChange{After: value}
+		}
+	}
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLog{
+		ActorID:    actor.UserID,
+		IP:         actor.IP,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    string(data),
+	}, nil
+}
+
+// ComputeHash hashes the entry with the hash of the entry before it. Changes
+// are hashed in canonical form since Postgres reformats jsonb.
+func (l *AuditLog) ComputeHash() (string, error) {
+	var changes any
+	if err := json.Unmarshal([]byte(l.Changes), &changes); err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(struct {
+		PrevHash   string `json:"prev_hash"`
+		ActorID    string `json:"actor_id"`
+		IP         string `json:"ip"`
+		Action     string `json:"action"`
+		EntityType string `json:"entity_type"`
+		EntityID   string `json:"entity_id"`
+		Changes    any    `json:"changes"`
+		CreatedAt  string `json:"created_at"`
+	}{l.PrevHash, l.ActorID, l.IP, l.Action, l.EntityType, l.EntityID, changes, l.CreatedAt.UTC().Format(time.RFC3339Nano)})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func auditFields(entity any) (map[string]any, error) {
+	fields := make(map[string]any)
+	if v := reflect.ValueOf(entity); !v.IsValid() || v.Kind() == reflect.Pointer && v.IsNil() {
+		return fields, nil
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, field := range auditIgnored {
+		delete(fields, field)
+	}
+	return fields, nil
+}
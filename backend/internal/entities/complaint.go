@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ComplaintCategory string
+
+const (
+	CategoryHygiene        ComplaintCategory = "HYGIENE"
+	CategoryFoodQuality    ComplaintCategory = "FOOD_QUALITY"
+	CategoryService        ComplaintCategory = "SERVICE"
+	CategoryInfrastructure ComplaintCategory = "INFRASTRUCTURE"
+	CategoryOther          ComplaintCategory = "OTHER"
+)
+
+// Valid reports whether c is one of the known complaint categories
+func (c ComplaintCategory) Valid() bool {
+	switch c {
+	case CategoryHygiene, CategoryFoodQuality, CategoryService, CategoryInfrastructure, CategoryOther:
+		return true
+	}
+	return false
+}
+
+type ComplaintStatus string
+
+const (
+	ComplaintOpen         ComplaintStatus = "OPEN"
+	ComplaintAcknowledged ComplaintStatus = "ACKNOWLEDGED"
+	ComplaintResolved     ComplaintStatus = "RESOLVED"
+	ComplaintClosed       ComplaintStatus = "CLOSED"
+)
+
+// CanTransitionTo enforces the open -> acknowledged -> resolved -> closed lifecycle
+func (s ComplaintStatus) CanTransitionTo(next ComplaintStatus) bool {
+	switch s {
+	case ComplaintOpen:
+		return next == ComplaintAcknowledged
+	case ComplaintAcknowledged:
+		return next == ComplaintResolved
+	case ComplaintResolved:
+		return next == ComplaintClosed
+	}
+	return false
+}
+
+type Complaint struct {
+	ID              uint              `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID          uuid.UUID         `gorm:"type:uuid;not null;index" json:"user_id"`
+	MessNo          uint              `gorm:"not null;index" json:"mess_no"`
+	Category        ComplaintCategory `gorm:"size:30;not null" json:"category"`
+	Title           string            `gorm:"size:200;not null" json:"title"`
+	Description     string            `gorm:"type:text" json:"description"`
+	Status          ComplaintStatus   `gorm:"size:20;not null;default:'OPEN';index" json:"status"`
+	AssignedAdminID *uint             `gorm:"index" json:"assigned_admin_id"`
+	ResolutionNote  string            `gorm:"type:text" json:"resolution_note"`
+	DueAt           time.Time         `gorm:"not null" json:"due_at"`
+	Escalated       bool              `gorm:"not null;default:false" json:"escalated"`
+	EscalatedAt     *time.Time        `json:"escalated_at"`
+	AcknowledgedAt  *time.Time        `json:"acknowledged_at"`
+	ResolvedAt      *time.Time        `json:"resolved_at"`
+	ClosedAt        *time.Time        `json:"closed_at"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+
+	Attachments []ComplaintAttachment `gorm:"foreignKey:ComplaintID" json:"attachments,omitempty"`
+}
+
+type ComplaintAttachment struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	ComplaintID uint      `gorm:"not null;index" json:"complaint_id"`
+	FileName    string    `gorm:"size:255;not null" json:"file_name"`
+	ContentType string    `gorm:"size:100" json:"content_type"`
+	Size        int64     `json:"size"`
+	StorageKey  string    `gorm:"size:500;not null" json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+}
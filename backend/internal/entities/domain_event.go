@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Payloads of the domain events carried by OutboxEvent
+
+type BillGeneratedPayload struct {
+	BillID uuid.UUID `json:"bill_id"`
+	Roll   uint      `json:"roll"`
+	Month  string    `json:"month"`
+	Total  float64   `json:"total"`
+}
+
+type PaymentRecordedPayload struct {
+	PaymentID   uuid.UUID     `json:"payment_id"`
+	BillID      uuid.UUID     `json:"bill_id"`
+	Roll        uint          `json:"roll"`
+	Month       string        `json:"month"`
+	Amount      float64       `json:"amount"`
+	Method      PaymentMethod `json:"method"`
+	Reference   string        `json:"reference"`
+	PaidAt      time.Time     `json:"paid_at"`
+	Outstanding float64       `json:"outstanding"`
+}
+
+type MealCancelledPayload struct {
+	Roll     uint     `json:"roll"`
+	Date     string   `json:"date"`
+	MealType MealType `json:"meal_type"`
+}
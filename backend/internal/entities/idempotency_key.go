@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+// IdempotencyKey remembers the first successful response to a request sent
+// with an Idempotency-Key header, retries with the same key get it replayed
+type IdempotencyKey struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      string     `gorm:"size:64;not null;uniqueIndex:idx_idempotency_keys_user_key" json:"user_id"`
+	Key         string     `gorm:"size:255;not null;uniqueIndex:idx_idempotency_keys_user_key" json:"key"`
+	RequestHash string     `gorm:"size:64;not null" json:"request_hash"`
+	StatusCode  int        `json:"status_code"`
+	Response    []byte     `json:"-"`
+	CompletedAt *time.Time `json:"completed_at"` // nil while the first request is still running
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   time.Time  `gorm:"not null;index" json:"expires_at"`
+}
@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+type JobRunStatus string
+
+const (
+	JobRunning   JobRunStatus = "RUNNING"
+	JobSucceeded JobRunStatus = "SUCCEEDED"
+	JobFailed    JobRunStatus = "FAILED"
+)
+
+// JobRun is one execution of a scheduled job, the unique (job, tick) pair
+// keeps replicas from running the same tick twice
+type JobRun struct {
+	ID          uint         `gorm:"primaryKey;autoIncrement" json:"id"`
+	JobName     string       `gorm:"size:100;not null;uniqueIndex:idx_job_runs_job_tick" json:"job_name"`
+	ScheduledAt time.Time    `gorm:"not null;uniqueIndex:idx_job_runs_job_tick" json:"scheduled_at"`
+	StartedAt   time.Time    `gorm:"not null" json:"started_at"`
+	FinishedAt  *time.Time   `json:"finished_at"`
+	Status      JobRunStatus `gorm:"size:20;not null" json:"status"`
+	Error       string       `gorm:"type:text" json:"error,omitempty"`
+}
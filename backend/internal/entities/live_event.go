@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// Topics of the live updates published on pubsub.Broker, one per mess so
+// dashboards only receive their own mess
+
+func MealCountTopic(messNo uint, date string, mealType MealType) string {
+	return fmt.Sprintf("meal_count.%d.%s.%s", messNo, date, mealType)
+}
+
+func ComplaintFiledTopic(messNo uint) string {
+	return fmt.Sprintf("complaint_filed.%d", messNo)
+}
+
+func MealCancelledTopic(messNo uint) string {
+	return fmt.Sprintf("meal_cancelled.%d", messNo)
+}
+
+// ComplaintFiledPayload is published on ComplaintFiledTopic, cancellations
+// reuse MealCancelledPayload
+type ComplaintFiledPayload struct {
+	ID        uint              `json:"id"`
+	Title     string            `json:"title"`
+	Category  ComplaintCategory `json:"category"`
+	MessNo    uint              `json:"mess_no"`
+	CreatedAt time.Time         `json:"created_at"`
+}
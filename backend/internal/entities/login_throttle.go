@@ -0,0 +1,20 @@
+package entities
+
+import "time"
+
+// LoginThrottle counts recent failed sign-ins for one account or one client
+// address, Key is "account:<email>" or "ip:<address>"
+type LoginThrottle struct {
+	Key           string     `gorm:"primaryKey;size:320" json:"key"`
+	Failures      int        `gorm:"not null;default:0" json:"failures"`
+	LastFailureAt time.Time  `gorm:"not null;index" json:"last_failure_at"`
+	LockedUntil   *time.Time `json:"locked_until"`
+}
+
+// RetryAfter is how long sign-ins stay blocked, zero once the lock has lapsed
+func (t *LoginThrottle) RetryAfter(now time.Time) time.Duration {
+	if t.LockedUntil == nil || !now.Before(*t.LockedUntil) {
+		return 0
+	}
+	return t.LockedUntil.Sub(now)
+}
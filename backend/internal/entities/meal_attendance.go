@@ -0,0 +1,19 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MealAttendance records a student being served a meal, MessNo is copied from
+// the student so live counts per mess do not need a join
+type MealAttendance struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Roll       uint      `gorm:"uniqueIndex:idx_meal_attendances_roll_date_meal" json:"roll"`
+	MessNo     uint      `gorm:"not null;index:idx_meal_attendances_mess_date_meal" json:"mess_no"`
+	MealType   MealType  `gorm:"type:meal_type;not null;uniqueIndex:idx_meal_attendances_roll_date_meal;index:idx_meal_attendances_mess_date_meal" json:"meal_type"`
+	Date       time.Time `gorm:"not null;uniqueIndex:idx_meal_attendances_roll_date_meal;index:idx_meal_attendances_mess_date_meal" json:"date"`
+	RecordedBy uuid.UUID `gorm:"type:uuid" json:"recorded_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
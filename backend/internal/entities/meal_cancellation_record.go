@@ -10,9 +10,19 @@ const (
 	Dinner    MealType = "DINNER"
 )
 
+func (m MealType) Valid() bool {
+	switch m {
+	case Breakfast, Lunch, Dinner:
+		return true
+	}
+	return false
+}
+
 type MealCancellationRecord struct {
-	Roll       uint      `gorm:"" json:"roll"`
+	ID         uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Roll       uint      `gorm:"uniqueIndex:idx_meal_cancellations_roll_date_meal" json:"roll"`
 	SemesterID uint      `gorm:"" json:"semester_id"`
-	MealType   MealType  `gorm:"type:meal_type;default:'BREAKFAST'" json:"meal_type"`
-	Date       time.Time `gorm:"" json:"date"`
+	MealType   MealType  `gorm:"type:meal_type;default:'BREAKFAST';uniqueIndex:idx_meal_cancellations_roll_date_meal" json:"meal_type"`
+	Date       time.Time `gorm:"uniqueIndex:idx_meal_cancellations_roll_date_meal" json:"date"`
+	CreatedAt  time.Time `json:"created_at"`
 }
@@ -1,16 +1,27 @@
 package entities
 
 import (
+	"time"
+
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type MonthlyBill struct {
-	BillID         uuid.UUID `gorm:"primaryKey" json:"bill_id"`
-	Roll           uint      `gorm:"" json:"roll"`
-	Month          string    `gorm:"size:100" json:"month"`
+	BillID         uuid.UUID `gorm:"type:uuid;primaryKey" json:"bill_id"`
+	Roll           uint      `gorm:"uniqueIndex:idx_monthly_bills_roll_month" json:"roll"`
+	Month          string    `gorm:"size:100;uniqueIndex:idx_monthly_bills_roll_month" json:"month"`
 	SemesterID     uint      `gorm:"" json:"semester_id"`
 	BreakfastCount uint      `gorm:"" json:"breakfast_count"`
 	LunchCount     uint      `gorm:"" json:"lunch_count"`
 	DinnerCount    uint      `gorm:"" json:"dinner_count"`
 	TotalBill      float64   `gorm:"type:decimal(10,2);" json:"total_bill"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (b *MonthlyBill) BeforeCreate(tx *gorm.DB) (err error) {
+	if b.BillID == uuid.Nil {
+		b.BillID = uuid.New()
+	}
+	return
 }
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationEvent string
+
+const (
+	EventBillGenerated      NotificationEvent = "BILL_GENERATED"
+	EventCancellationCutoff NotificationEvent = "CANCELLATION_CUTOFF"
+	EventComplaintUpdated   NotificationEvent = "COMPLAINT_UPDATED"
+	EventPaymentRecorded    NotificationEvent = "PAYMENT_RECORDED"
+)
+
+type NotificationChannel string
+
+const (
+	ChannelEmail NotificationChannel = "EMAIL"
+	ChannelInApp NotificationChannel = "IN_APP"
+)
+
+type Notification struct {
+	ID        uint              `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    uuid.UUID         `gorm:"type:uuid;not null;index" json:"user_id"`
+	Event     NotificationEvent `gorm:"size:50;not null" json:"event"`
+	Title     string            `gorm:"size:200;not null" json:"title"`
+	Body      string            `gorm:"type:text" json:"body"`
+	ReadAt    *time.Time        `json:"read_at"`
+	CreatedAt time.Time         `gorm:"index" json:"created_at"`
+}
+
+// NotificationPreference opts a user in or out of one event on one channel,
+// a missing row means the channel is enabled
+type NotificationPreference struct {
+	UserID  uuid.UUID           `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Event   NotificationEvent   `gorm:"size:50;primaryKey" json:"event"`
+	Channel NotificationChannel `gorm:"size:20;primaryKey" json:"channel"`
+	Enabled bool                `gorm:"not null" json:"enabled"`
+}
@@ -1,6 +1,14 @@
 package entities
 
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
 type Order struct {
-	ID    uint    `gorm:"primaryKey;autoIncrement" json:"id"`
-	Total float64 `json:"total"`
+	ID        uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+	Total     float64        `json:"total"`
+	UserID    *uuid.UUID     `gorm:"type:uuid;index" json:"user_id"` // owner, nil on orders placed before ownership existed
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+	Version   uint           `gorm:"not null;default:1" json:"version"` // grows with every update
 }
@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Domain event types published through the outbox
+const (
+	EventTypeBillGenerated   = "bill.generated"
+	EventTypePaymentRecorded = "payment.recorded"
+	EventTypeMealCancelled   = "meal.cancelled"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "PENDING"
+	OutboxDelivered OutboxStatus = "DELIVERED"
+	OutboxDead      OutboxStatus = "DEAD"
+)
+
+// OutboxEvent is a domain event written in the same transaction as the change
+// that raised it, the dispatcher delivers it afterwards
+type OutboxEvent struct {
+	ID            uint         `gorm:"primaryKey;autoIncrement" json:"id"`
+	EventType     string       `gorm:"size:100;not null;index" json:"event_type"`
+	AggregateType string       `gorm:"size:50;not null" json:"aggregate_type"`
+	AggregateID   string       `gorm:"size:100;not null" json:"aggregate_id"`
+	Payload       string       `gorm:"type:jsonb;not null" json:"payload"`
+	Status        OutboxStatus `gorm:"size:20;not null;default:'PENDING';index:idx_outbox_events_due" json:"status"`
+	Attempts      int          `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time    `gorm:"not null;index:idx_outbox_events_due" json:"next_attempt_at"`
+	LastError     string       `gorm:"type:text" json:"last_error,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+	DeliveredAt   *time.Time   `json:"delivered_at"`
+}
+
+// NewOutboxEvent builds a pending event with payload encoded as JSON
+func NewOutboxEvent(eventType, aggregateType, aggregateID string, payload any) (*OutboxEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxEvent{
+		EventType:     eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       string(data),
+		Status:        OutboxPending,
+		NextAttemptAt: time.Now(),
+	}, nil
+}
+
+// OutboxDelivery records a subscriber that already received an event,
+// retries of a partially delivered event skip it
+type OutboxDelivery struct {
+	EventID     uint      `gorm:"primaryKey" json:"event_id"`
+	Subscriber  string    `gorm:"size:150;primaryKey" json:"subscriber"`
+	DeliveredAt time.Time `gorm:"not null" json:"delivered_at"`
+}
+
+// WebhookSubscription is an outbound endpoint that receives signed domain events
+type WebhookSubscription struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	URL        string    `gorm:"size:500;not null" json:"url"`
+	Secret     string    `gorm:"size:255;not null" json:"-"`
+	EventTypes string    `gorm:"size:500" json:"event_types"` // comma separated, empty means every event
+	Active     bool      `gorm:"not null;default:true" json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Accepts reports whether the subscription wants events of eventType
+func (w *WebhookSubscription) Accepts(eventType string) bool {
+	if w.EventTypes == "" {
+		return true
+	}
+	for _, t := range strings.Split(w.EventTypes, ",") {
+		if strings.TrimSpace(t) == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PaymentMethod string
+
+const (
+	PaymentCash         PaymentMethod = "CASH"
+	PaymentUPI          PaymentMethod = "UPI"
+	PaymentCard         PaymentMethod = "CARD"
+	PaymentBankTransfer PaymentMethod = "BANK_TRANSFER"
+)
+
+func (m PaymentMethod) Valid() bool {
+	switch m {
+	case PaymentCash, PaymentUPI, PaymentCard, PaymentBankTransfer:
+		return true
+	}
+	return false
+}
+
+// Payment settles all or part of a monthly bill, Reference is the receipt or
+// transaction number and may only be recorded once
+type Payment struct {
+	PaymentID  uuid.UUID     `gorm:"type:uuid;primaryKey" json:"payment_id"`
+	BillID     uuid.UUID     `gorm:"type:uuid;not null;index" json:"bill_id"`
+	Roll       uint          `gorm:"not null;index" json:"roll"`
+	Amount     float64       `gorm:"type:decimal(10,2);not null" json:"amount"`
+	Method     PaymentMethod `gorm:"size:20;not null" json:"method"`
+	Reference  string        `gorm:"size:100;not null;uniqueIndex" json:"reference"`
+	RecordedBy uuid.UUID     `gorm:"type:uuid" json:"recorded_by"`
+	PaidAt     time.Time     `gorm:"not null" json:"paid_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+func (p *Payment) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.PaymentID == uuid.Nil {
+		p.PaymentID = uuid.New()
+	}
+	return
+}
@@ -0,0 +1,12 @@
+package entities
+
+import "time"
+
+// RateLimitBucket is the token bucket of one caller under one policy, shared
+// by every replica when RATE_LIMIT_STORE=postgres
+type RateLimitBucket struct {
+	Key        string    `gorm:"primaryKey;size:200" json:"key"`
+	Tokens     float64   `gorm:"not null" json:"tokens"`
+	RefilledAt time.Time `gorm:"not null" json:"refilled_at"`
+	FullAt     time.Time `gorm:"not null;index" json:"full_at"` // the row can be dropped from then on
+}
@@ -0,0 +1,10 @@
+package entities
+
+import "time"
+
+// SchemaVersion records that the migrations of a version ran to completion,
+// the newest row is the version the database is at
+type SchemaVersion struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false" json:"version"`
+	AppliedAt time.Time `gorm:"not null" json:"applied_at"`
+}
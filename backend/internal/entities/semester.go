@@ -14,7 +14,7 @@ const (
 type Semester struct {
 	SemesterID   uint         `gorm:"primaryKey" json:"semester_id"`
 	AcademicYear string       `gorm:"size:100" json:"academic_year"`
-	SemesterType SemesterType `gorm:"semester_type" json:"semester_type"`
+	SemesterType SemesterType `gorm:"type:semester_type;default:'ODD'" json:"semester_type"`
 	StartDate    time.Time    `gorm:"type:date;" json:"start_date"`
 	EndDate      time.Time    `gorm:"type:date;" json:"end_date"`
 }
@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SSOLoginState remembers a sign-in sent to the identity provider until it
+// returns, only the hash of the state parameter is stored
+type SSOLoginState struct {
+	StateHash    string    `gorm:"primaryKey;size:64" json:"-"`
+	Nonce        string    `gorm:"size:100;not null" json:"-"`
+	CodeVerifier string    `gorm:"size:128;not null" json:"-"`
+	ExpiresAt    time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ExternalIdentity links an identity provider account to a user, so the link
+// survives an email change on either side
+type ExternalIdentity struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Issuer    string    `gorm:"size:255;not null;uniqueIndex:idx_external_identity" json:"issuer"`
+	Subject   string    `gorm:"size:255;not null;uniqueIndex:idx_external_identity" json:"subject"`
+	Email     string    `gorm:"size:255" json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -1,5 +1,7 @@
 package entities
 
+import "gorm.io/gorm"
+
 type StudentStatus string
 
 const (
@@ -16,4 +18,6 @@ type Student struct {
 	Phone  string        `gorm:"size:15" json:"phone"`
 	Email  string        `gorm:"size:255;unique;not null" json:"email"`
 	Status StudentStatus `gorm:"type:student_status;default:'ACTIVE'" json:"status"`
+
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 }
@@ -1,18 +1,49 @@
 package entities
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+type Role string
+
+const (
+	RoleStudent     Role = "STUDENT"
+	RoleMessAdmin   Role = "MESS_ADMIN"
+	RoleOfficeAdmin Role = "OFFICE_ADMIN"
+)
+
+// IsAdmin reports whether the role belongs to mess or office staff
+func (r Role) IsAdmin() bool {
+	return r == RoleMessAdmin || r == RoleOfficeAdmin
+}
+
 type User struct {
 	ID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
-	Email    string    `gorm:"uniqueIndex" json:"email"`
+	Email    string    `gorm:"uniqueIndex:idx_users_email_live,where:deleted_at IS NULL" json:"email"`
 	Password string    `json:"password"`
 	Name     string    `json:"name"`
+	Role     Role      `gorm:"size:20;not null;default:'STUDENT'" json:"role"`
+
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+
+	// Version grows with every update, a PATCH sent with an older one is rejected
+	Version uint `gorm:"not null;default:1" json:"version"`
+
+	// DeletedAt is set instead of removing the row, the email is free again
+	// once the user is deleted
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
 }
 
 func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
-	u.ID = uuid.New()
+	// callers that audit the new user pick its ID up front
+	if u.ID == uuid.Nil {
+		u.ID = uuid.New()
+	}
+	if u.Role == "" {
+		u.Role = RoleStudent
+	}
 	return
 }
@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserTokenPurpose string
+
+const (
+	TokenEmailVerification UserTokenPurpose = "EMAIL_VERIFICATION"
+	TokenPasswordReset     UserTokenPurpose = "PASSWORD_RESET"
+)
+
+// UserToken is a single-use emailed token, only its hash is stored
+type UserToken struct {
+	ID        uint             `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID    uuid.UUID        `gorm:"type:uuid;not null;index" json:"user_id"`
+	Purpose   UserTokenPurpose `gorm:"size:30;not null" json:"purpose"`
+	TokenHash string           `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	ExpiresAt time.Time        `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time       `json:"used_at"`
+	CreatedAt time.Time        `json:"created_at"`
+}
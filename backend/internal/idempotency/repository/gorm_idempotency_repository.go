@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormIdempotencyRepository persists idempotency keys in Postgres and
+// implements middleware.IdempotencyStore
+type GormIdempotencyRepository struct {
+	db  *gorm.DB
+	ttl time.Duration
+}
+
+// NewGormIdempotencyRepository keeps each key for ttl after its first request
+func NewGormIdempotencyRepository(db *gorm.DB, ttl time.Duration) IdempotencyRepository {
+	return &GormIdempotencyRepository{db: db, ttl: ttl}
+}
+
+// Reserve inserts the key unless the user already has it, the unique index
+// makes a concurrent retry wait for the first insert and then find it
+func (r *GormIdempotencyRepository) Reserve(userID, key, requestHash string) (*middleware.IdempotentResponse, error) {
+	now := time.Now()
+	var prior *middleware.IdempotentResponse
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// an expired key is claimed again as if it was never used
+		if err := tx.Where("user_id = ? AND key = ? AND expires_at <= ?", userID, key, now).
+			Delete(&entities.IdempotencyKey{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entities.IdempotencyKey{
+			UserID:      userID,
+			Key:         key,
+			RequestHash: requestHash,
+			ExpiresAt:   now.Add(r.ttl),
+		})
+		if result.Error != nil || result.RowsAffected == 1 {
+			return result.Error
+		}
+
+		var existing entities.IdempotencyKey
+		if err := tx.Where("user_id = ? AND key = ?", userID, key).First(&existing).Error; err != nil {
+			return err
+		}
+		prior = &middleware.IdempotentResponse{
+			RequestHash: existing.RequestHash,
+			Completed:   existing.CompletedAt != nil,
+			StatusCode:  existing.StatusCode,
+			Body:        existing.Response,
+		}
+		return nil
+	})
+	return prior, err
+}
+
+func (r *GormIdempotencyRepository) Complete(userID, key string, statusCode int, body []byte) error {
+	return r.db.Model(&entities.IdempotencyKey{}).
+		Where("user_id = ? AND key = ?", userID, key).
+		Updates(map[string]interface{}{
+			"status_code":  statusCode,
+			"response":     body,
+			"completed_at": time.Now(),
+		}).Error
+}
+
+func (r *GormIdempotencyRepository) Release(userID, key string) error {
+	return r.db.Where("user_id = ? AND key = ? AND completed_at IS NULL", userID, key).
+		Delete(&entities.IdempotencyKey{}).Error
+}
+
+func (r *GormIdempotencyRepository) DeleteExpired(before time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", before).Delete(&entities.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,98 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/internal/idempotency/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type IdempotencyRepositoryTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	repo    repository.IdempotencyRepository
+	cleanup func()
+}
+
+func (s *IdempotencyRepositoryTestSuite) SetupTest() {
+	s.db, s.cleanup = database.SetupTestDB(s.T())
+	s.repo = repository.NewGormIdempotencyRepository(s.db, 24*time.Hour)
+}
+
+func (s *IdempotencyRepositoryTestSuite) TearDownTest() {
+	if s.cleanup != nil {
+		s.cleanup()
+	}
+}
+
+func TestIdempotencyRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyRepositoryTestSuite))
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestReserveAndComplete() {
+	prior, err := s.repo.Reserve("user-1", "key-1", "hash-1")
+	s.Require().NoError(err)
+	s.Nil(prior)
+
+	// the first request is still running
+	prior, err = s.repo.Reserve("user-1", "key-1", "hash-1")
+	s.Require().NoError(err)
+	s.Require().NotNil(prior)
+	s.False(prior.Completed)
+
+	s.Require().NoError(s.repo.Complete("user-1", "key-1", 201, []byte(`{"id":1}`)))
+	prior, err = s.repo.Reserve("user-1", "key-1", "hash-1")
+	s.Require().NoError(err)
+	s.Require().NotNil(prior)
+	s.True(prior.Completed)
+	s.Equal("hash-1", prior.RequestHash)
+	s.Equal(201, prior.StatusCode)
+	s.JSONEq(`{"id":1}`, string(prior.Body))
+
+	// keys belong to one user
+	prior, err = s.repo.Reserve("user-2", "key-1", "hash-2")
+	s.NoError(err)
+	s.Nil(prior)
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestRelease_OnlyUnfinished() {
+	_, err := s.repo.Reserve("user-1", "failed", "hash-1")
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.Release("user-1", "failed"))
+	prior, err := s.repo.Reserve("user-1", "failed", "hash-2")
+	s.NoError(err)
+	s.Nil(prior)
+
+	_, err = s.repo.Reserve("user-1", "done", "hash-1")
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.Complete("user-1", "done", 200, []byte(`{}`)))
+	s.Require().NoError(s.repo.Release("user-1", "done"))
+	prior, err = s.repo.Reserve("user-1", "done", "hash-1")
+	s.NoError(err)
+	s.NotNil(prior)
+}
+
+func (s *IdempotencyRepositoryTestSuite) TestExpiredKeys() {
+	_, err := s.repo.Reserve("user-1", "old", "hash-1")
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.Complete("user-1", "old", 201, []byte(`{}`)))
+	s.Require().NoError(s.db.Model(&entities.IdempotencyKey{}).
+		Where("key = ?", "old").
+		Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+	// an expired key starts over, even with another request
+	prior, err := s.repo.Reserve("user-1", "old", "hash-2")
+	s.NoError(err)
+	s.Nil(prior)
+
+	s.Require().NoError(s.db.Model(&entities.IdempotencyKey{}).
+		Where("key = ?", "old").
+		Update("expires_at", time.Now().Add(-time.Minute)).Error)
+	deleted, err := s.repo.DeleteExpired(time.Now())
+	s.NoError(err)
+	s.Equal(int64(1), deleted)
+}
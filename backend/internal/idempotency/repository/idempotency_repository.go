@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+)
+
+type IdempotencyRepository interface {
+	middleware.IdempotencyStore
+	// DeleteExpired removes keys that expired before the given time
+	DeleteExpired(before time.Time) (int64, error)
+}
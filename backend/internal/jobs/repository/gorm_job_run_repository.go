@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"gorm.io/gorm"
+)
+
+// GormJobRunRepository persists job runs in Postgres and implements scheduler.RunStore
+type GormJobRunRepository struct {
+	db *gorm.DB
+}
+
+func NewGormJobRunRepository(db *gorm.DB) JobRunRepository {
+	return &GormJobRunRepository{db: db}
+}
+
+// Claim takes a transaction-scoped advisory lock on the job name so only one
+// replica at a time can check and record a tick, the unique (job, tick) index
+// then makes sure a tick recorded once is never run again
+func (r *GormJobRunRepository) Claim(job string, scheduledAt time.Time) (uint, bool, error) {
+	var run *entities.JobRun
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", "job:"+job).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		var existing int64
+		if err := tx.Model(&entities.JobRun{}).
+			Where("job_name = ? AND scheduled_at = ?", job, scheduledAt).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return nil
+		}
+
+		run = &entities.JobRun{
+			JobName:     job,
+			ScheduledAt: scheduledAt,
+			StartedAt:   time.Now(),
+			Status:      entities.JobRunning,
+		}
+		return tx.Create(run).Error
+	})
+	if err != nil || run == nil {
+		return 0, false, err
+	}
+	return run.ID, true, nil
+}
+
+func (r *GormJobRunRepository) Finish(runID uint, runErr error) error {
+	updates := map[string]any{
+		"finished_at": time.Now(),
+		"status":      entities.JobSucceeded,
+		"error":       "",
+	}
+	if runErr != nil {
+		updates["status"] = entities.JobFailed
+		updates["error"] = runErr.Error()
+	}
+	return r.db.Model(&entities.JobRun{}).Where("id = ?", runID).Updates(updates).Error
+}
+
+func (r *GormJobRunRepository) DeleteFinished(before time.Time) (int64, error) {
+	result := r.db.Where("finished_at < ?", before).Delete(&entities.JobRun{})
+	return result.RowsAffected, result.Error
+}
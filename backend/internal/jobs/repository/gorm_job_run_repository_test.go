@@ -0,0 +1,86 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/internal/jobs/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type JobRunRepositoryTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	store   repository.JobRunRepository
+	cleanup func()
+}
+
+func (s *JobRunRepositoryTestSuite) SetupTest() {
+	s.db, s.cleanup = database.SetupTestDB(s.T())
+	s.store = repository.NewGormJobRunRepository(s.db)
+}
+
+func (s *JobRunRepositoryTestSuite) TearDownTest() {
+	if s.cleanup != nil {
+		s.cleanup()
+	}
+}
+
+func TestJobRunRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(JobRunRepositoryTestSuite))
+}
+
+func (s *JobRunRepositoryTestSuite) TestClaim_OnlyOncePerTick() {
+	tick := time.Date(2026, 10, 1, 2, 0, 0, 0, time.UTC)
+
+	runID, claimed, err := s.store.Claim("monthly-billing", tick)
+	s.NoError(err)
+	s.True(claimed)
+	s.NotZero(runID)
+
+	_, claimed, err = s.store.Claim("monthly-billing", tick)
+	s.NoError(err)
+	s.False(claimed)
+
+	// the next tick is a new run
+	_, claimed, err = s.store.Claim("monthly-billing", tick.AddDate(0, 1, 0))
+	s.NoError(err)
+	s.True(claimed)
+}
+
+func (s *JobRunRepositoryTestSuite) TestFinish_RecordsFailure() {
+	runID, _, err := s.store.Claim("complaint-escalation", time.Now().Truncate(time.Minute))
+	s.NoError(err)
+
+	s.NoError(s.store.Finish(runID, errors.New("db timeout")))
+
+	var run entities.JobRun
+	s.NoError(s.db.First(&run, runID).Error)
+	s.Equal(entities.JobFailed, run.Status)
+	s.Equal("db timeout", run.Error)
+	s.NotNil(run.FinishedAt)
+}
+
+func (s *JobRunRepositoryTestSuite) TestDeleteFinished() {
+	old, _, err := s.store.Claim("outbox-dispatch", time.Date(2026, 10, 1, 2, 0, 0, 0, time.UTC))
+	s.Require().NoError(err)
+	s.Require().NoError(s.store.Finish(old, nil))
+	s.Require().NoError(s.db.Model(&entities.JobRun{}).Where("id = ?", old).Update("finished_at", time.Now().AddDate(0, 0, -30)).Error)
+	recent, _, err := s.store.Claim("outbox-dispatch", time.Date(2026, 10, 1, 2, 0, 15, 0, time.UTC))
+	s.Require().NoError(err)
+	s.Require().NoError(s.store.Finish(recent, nil))
+	running, _, err := s.store.Claim("monthly-billing", time.Date(2026, 9, 1, 2, 0, 0, 0, time.UTC))
+	s.Require().NoError(err)
+
+	deleted, err := s.store.DeleteFinished(time.Now().AddDate(0, 0, -7))
+	s.NoError(err)
+	s.Equal(int64(1), deleted)
+
+	var left []uint
+	s.NoError(s.db.Model(&entities.JobRun{}).Order("id").Pluck("id", &left).Error)
+	s.Equal([]uint{recent, running}, left)
+}
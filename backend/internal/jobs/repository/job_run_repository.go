@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/scheduler"
+)
+
+type JobRunRepository interface {
+	scheduler.RunStore
+	// DeleteFinished removes runs that finished before the given time, runs
+	// still in progress are kept
+	DeleteFinished(before time.Time) (int64, error)
+}
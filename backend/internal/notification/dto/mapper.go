@@ -0,0 +1,47 @@
+package dto
+
+import "github.com/ePSA-eJya/Mess_Management/internal/entities"
+
+func ToNotificationResponse(n *entities.Notification) *NotificationResponse {
+	return &NotificationResponse{
+		ID:        n.ID,
+		Event:     string(n.Event),
+		Title:     n.Title,
+		Body:      n.Body,
+		Read:      n.ReadAt != nil,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+func ToNotificationResponseList(notifications []*entities.Notification) []*NotificationResponse {
+	result := make([]*NotificationResponse, 0, len(notifications))
+	for _, n := range notifications {
+		result = append(result, ToNotificationResponse(n))
+	}
+	return result
+}
+
+func ToPreferenceResponseList(prefs []*entities.NotificationPreference) []*PreferenceResponse {
+	result := make([]*PreferenceResponse, 0, len(prefs))
+	for _, p := range prefs {
+		result = append(result, &PreferenceResponse{
+			Event:   string(p.Event),
+			Channel: string(p.Channel),
+			Enabled: p.Enabled,
+		})
+	}
+	return result
+}
+
+func ToPreferenceEntities(req *UpdatePreferencesRequest) []*entities.NotificationPreference {
+	prefs := make([]*entities.NotificationPreference, 0, len(req.Preferences))
+	for _, p := range req.Preferences {
+		prefs = append(prefs, &entities.NotificationPreference{
+			Event:   entities.NotificationEvent(p.Event),
+			Channel: entities.NotificationChannel(p.Channel),
+			Enabled: p.Enabled,
+		})
+	}
+	return prefs
+}
@@ -0,0 +1,11 @@
+package dto
+
+type PreferenceRequest struct {
+	Event   string `json:"event" validate:"required,oneof=BILL_GENERATED CANCELLATION_CUTOFF COMPLAINT_UPDATED PAYMENT_RECORDED"`
+	Channel string `json:"channel" validate:"required,oneof=EMAIL IN_APP"`
+	Enabled bool   `json:"enabled"`
+}
+
+type UpdatePreferencesRequest struct {
+	Preferences []PreferenceRequest `json:"preferences" validate:"required,dive"`
+}
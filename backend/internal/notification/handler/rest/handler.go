@@ -0,0 +1,153 @@
+package rest
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/notification/dto"
+	"github.com/ePSA-eJya/Mess_Management/internal/notification/usecase"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/ePSA-eJya/Mess_Management/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+)
+
+type HttpNotificationHandler struct {
+	notificationUseCase usecase.NotificationUseCase
+}
+
+func NewHttpNotificationHandler(useCase usecase.NotificationUseCase) *HttpNotificationHandler {
+	return &HttpNotificationHandler{notificationUseCase: useCase}
+}
+
+// FindInbox godoc
+// @Summary List the current user's notifications
+// @Tags notifications
+// @Produce json
+// @Param unread query bool false "Only unread notifications"
+// @Success 200 {array} dto.NotificationResponse
+// @Router /notifications [get]
+func (h *HttpNotificationHandler) FindInbox(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	notifications, err := h.notificationUseCase.FindInbox(fmt.Sprint(userID), c.QueryBool("unread"))
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToNotificationResponseList(notifications))
+}
+
+// CountUnread godoc
+// @Summary Count the current user's unread notifications
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} dto.UnreadCountResponse
+// @Router /notifications/unread-count [get]
+func (h *HttpNotificationHandler) CountUnread(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	count, err := h.notificationUseCase.CountUnread(fmt.Sprint(userID))
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.UnreadCountResponse{Unread: count})
+}
+
+// MarkRead godoc
+// @Summary Mark a notification as read
+// @Tags notifications
+// @Produce json
+// @Param id path int true "Notification ID"
+// @Success 200 {object} responses.MessageResponse
+// @Router /notifications/{id}/read [patch]
+func (h *HttpNotificationHandler) MarkRead(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return responses.ErrorWithMessage(c, apperror.ErrInvalidID, "invalid id")
+	}
+
+	if err := h.notificationUseCase.MarkRead(fmt.Sprint(userID), uint(id)); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Message(c, fiber.StatusOK, "notification marked as read")
+}
+
+// MarkAllRead godoc
+// @Summary Mark all notifications as read
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} responses.MessageResponse
+// @Router /notifications/read-all [post]
+func (h *HttpNotificationHandler) MarkAllRead(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	if err := h.notificationUseCase.MarkAllRead(fmt.Sprint(userID)); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Message(c, fiber.StatusOK, "all notifications marked as read")
+}
+
+// FindPreferences godoc
+// @Summary Get notification preferences
+// @Tags notifications
+// @Produce json
+// @Success 200 {array} dto.PreferenceResponse
+// @Router /notifications/preferences [get]
+func (h *HttpNotificationHandler) FindPreferences(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	prefs, err := h.notificationUseCase.FindPreferences(fmt.Sprint(userID))
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToPreferenceResponseList(prefs))
+}
+
+// UpdatePreferences godoc
+// @Summary Enable or disable notification channels per event
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param preferences body dto.UpdatePreferencesRequest true "Preference changes"
+// @Success 200 {array} dto.PreferenceResponse
+// @Router /notifications/preferences [put]
+func (h *HttpNotificationHandler) UpdatePreferences(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	var req dto.UpdatePreferencesRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	prefs, err := h.notificationUseCase.UpdatePreferences(fmt.Sprint(userID), dto.ToPreferenceEntities(&req))
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToPreferenceResponseList(prefs))
+}
@@ -0,0 +1,27 @@
+package notifier
+
+import (
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/mailer"
+)
+
+// EmailNotifier sends notifications to the user's email address
+type EmailNotifier struct {
+	sender mailer.Sender
+}
+
+func NewEmailNotifier(sender mailer.Sender) Notifier {
+	return &EmailNotifier{sender: sender}
+}
+
+func (n *EmailNotifier) Channel() entities.NotificationChannel {
+	return entities.ChannelEmail
+}
+
+func (n *EmailNotifier) Notify(user *entities.User, notification *entities.Notification) error {
+	return n.sender.Send(mailer.Message{
+		To:      []string{user.Email},
+		Subject: notification.Title,
+		Body:    notification.Body,
+	})
+}
@@ -0,0 +1,25 @@
+package notifier
+
+import (
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/internal/notification/repository"
+)
+
+// InAppNotifier stores notifications in the user's inbox
+type InAppNotifier struct {
+	repo repository.NotificationRepository
+}
+
+func NewInAppNotifier(repo repository.NotificationRepository) Notifier {
+	return &InAppNotifier{repo: repo}
+}
+
+func (n *InAppNotifier) Channel() entities.NotificationChannel {
+	return entities.ChannelInApp
+}
+
+func (n *InAppNotifier) Notify(user *entities.User, notification *entities.Notification) error {
+	inbox := *notification
+	inbox.UserID = user.ID
+	return n.repo.Save(&inbox)
+}
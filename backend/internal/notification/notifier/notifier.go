@@ -0,0 +1,24 @@
+package notifier
+
+import (
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/internal/notification/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/config"
+	"github.com/ePSA-eJya/Mess_Management/pkg/mailer"
+)
+
+// Notifier delivers a rendered notification to a user over one channel
+type Notifier interface {
+	Channel() entities.NotificationChannel
+	Notify(user *entities.User, notification *entities.Notification) error
+}
+
+// FromConfig returns the in-app notifier plus email delivery when SMTP is configured
+func FromConfig(repo repository.NotificationRepository, cfg *config.Config) []Notifier {
+	notifiers := []Notifier{NewInAppNotifier(repo)}
+	if cfg.SMTPHost != "" {
+		sender := mailer.NewSMTPSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+		notifiers = append(notifiers, NewEmailNotifier(sender))
+	}
+	return notifiers
+}
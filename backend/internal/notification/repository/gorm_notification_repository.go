@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormNotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewGormNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &GormNotificationRepository{db: db}
+}
+
+func (r *GormNotificationRepository) Save(notification *entities.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+func (r *GormNotificationRepository) FindByUser(userID string, unreadOnly bool) ([]*entities.Notification, error) {
+	query := r.db.Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var notificationValues []entities.Notification
+	if err := query.Order("created_at DESC").Find(&notificationValues).Error; err != nil {
+		return nil, err
+	}
+	notifications := make([]*entities.Notification, len(notificationValues))
+	for i := range notificationValues {
+		notifications[i] = &notificationValues[i]
+	}
+	return notifications, nil
+}
+
+func (r *GormNotificationRepository) CountUnread(userID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *GormNotificationRepository) MarkRead(userID string, id uint) error {
+	result := r.db.Model(&entities.Notification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", time.Now()))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *GormNotificationRepository) MarkAllRead(userID string) error {
+	return r.db.Model(&entities.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now()).Error
+}
+
+func (r *GormNotificationRepository) FindPreferences(userID string) ([]*entities.NotificationPreference, error) {
+	var prefValues []entities.NotificationPreference
+	if err := r.db.Where("user_id = ?", userID).Find(&prefValues).Error; err != nil {
+		return nil, err
+	}
+	prefs := make([]*entities.NotificationPreference, len(prefValues))
+	for i := range prefValues {
+		prefs[i] = &prefValues[i]
+	}
+	return prefs, nil
+}
+
+func (r *GormNotificationRepository) SavePreference(pref *entities.NotificationPreference) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "event"}, {Name: "channel"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled"}),
+	}).Create(pref).Error
+}
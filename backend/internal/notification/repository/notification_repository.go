@@ -0,0 +1,13 @@
+package repository
+
+import "github.com/ePSA-eJya/Mess_Management/internal/entities"
+
+type NotificationRepository interface {
+	Save(notification *entities.Notification) error
+	FindByUser(userID string, unreadOnly bool) ([]*entities.Notification, error)
+	CountUnread(userID string) (int64, error)
+	MarkRead(userID string, id uint) error
+	MarkAllRead(userID string) error
+	FindPreferences(userID string) ([]*entities.NotificationPreference, error)
+	SavePreference(pref *entities.NotificationPreference) error
+}
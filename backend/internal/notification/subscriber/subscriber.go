@@ -0,0 +1,87 @@
+package subscriber
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	notificationUseCase "github.com/ePSA-eJya/Mess_Management/internal/notification/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/outbox/dispatcher"
+	studentRepository "github.com/ePSA-eJya/Mess_Management/internal/student/repository"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	"gorm.io/gorm"
+)
+
+// studentNotifier notifies the user account linked to a student by email,
+// students without an account are skipped
+type studentNotifier struct {
+	studentRepo studentRepository.StudentRepository
+	userRepo    userRepository.UserRepository
+	notifier    notificationUseCase.NotificationUseCase
+}
+
+func (n *studentNotifier) notify(ctx context.Context, roll uint, event entities.NotificationEvent, data map[string]any) error {
+	student, err := n.studentRepo.FindByRoll(ctx, roll)
+	if err != nil {
+		return err
+	}
+	user, err := n.userRepo.FindByEmail(ctx, student.Email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return n.notifier.Notify(ctx, user.ID.String(), event, data)
+}
+
+type billGenerated struct{ studentNotifier }
+
+// NewBillGeneratedHandler tells students their monthly bill is ready
+func NewBillGeneratedHandler(
+	studentRepo studentRepository.StudentRepository,
+	userRepo userRepository.UserRepository,
+	notifier notificationUseCase.NotificationUseCase,
+) dispatcher.Handler {
+	return &billGenerated{studentNotifier{studentRepo: studentRepo, userRepo: userRepo, notifier: notifier}}
+}
+
+func (h *billGenerated) Name() string { return "notify-bill-generated" }
+
+func (h *billGenerated) Handle(ctx context.Context, event *entities.OutboxEvent) error {
+	var payload entities.BillGeneratedPayload
+	if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+		return err
+	}
+	return h.notify(ctx, payload.Roll, entities.EventBillGenerated, map[string]any{
+		"Month": payload.Month,
+		"Total": payload.Total,
+	})
+}
+
+type paymentRecorded struct{ studentNotifier }
+
+// NewPaymentRecordedHandler sends students a receipt for their payment
+func NewPaymentRecordedHandler(
+	studentRepo studentRepository.StudentRepository,
+	userRepo userRepository.UserRepository,
+	notifier notificationUseCase.NotificationUseCase,
+) dispatcher.Handler {
+	return &paymentRecorded{studentNotifier{studentRepo: studentRepo, userRepo: userRepo, notifier: notifier}}
+}
+
+func (h *paymentRecorded) Name() string { return "notify-payment-recorded" }
+
+func (h *paymentRecorded) Handle(ctx context.Context, event *entities.OutboxEvent) error {
+	var payload entities.PaymentRecordedPayload
+	if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+		return err
+	}
+	return h.notify(ctx, payload.Roll, entities.EventPaymentRecorded, map[string]any{
+		"Month":       payload.Month,
+		"Amount":      payload.Amount,
+		"Reference":   payload.Reference,
+		"Outstanding": payload.Outstanding,
+	})
+}
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+)
+
+type NotificationUseCase interface {
+	// Notify and NotifyRole run within ctx, they are part of the request or job
+	// that caused the notification
+	Notify(ctx context.Context, userID string, event entities.NotificationEvent, data map[string]any) error
+	NotifyRole(ctx context.Context, role entities.Role, event entities.NotificationEvent, data map[string]any) (int, error)
+	FindInbox(userID string, unreadOnly bool) ([]*entities.Notification, error)
+	CountUnread(userID string) (int64, error)
+	MarkRead(userID string, id uint) error
+	MarkAllRead(userID string) error
+	FindPreferences(userID string) ([]*entities.NotificationPreference, error)
+	UpdatePreferences(userID string, prefs []*entities.NotificationPreference) ([]*entities.NotificationPreference, error)
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+)
+
+type messageTemplate struct {
+	title *template.Template
+	body  *template.Template
+}
+
+func mustTemplate(event entities.NotificationEvent, title, body string) messageTemplate {
+	return messageTemplate{
+		title: template.Must(template.New(string(event) + ".title").Parse(title)),
+		body:  template.Must(template.New(string(event) + ".body").Parse(body)),
+	}
+}
+
+var templates = map[entities.NotificationEvent]messageTemplate{
+	entities.EventBillGenerated: mustTemplate(entities.EventBillGenerated,
+		"Mess bill for {{.Month}} generated",
+		"Hello {{.Name}},\n\nYour mess bill for {{.Month}} is ready. Amount due: {{printf \"%.2f\" .Total}}.\n",
+	),
+	entities.EventCancellationCutoff: mustTemplate(entities.EventCancellationCutoff,
+		"Meal cancellation closes at {{.Cutoff}}",
+		"Hello {{.Name}},\n\nMeal cancellations for {{.Date}} close at {{.Cutoff}}. Cancel before then to avoid being billed.\n",
+	),
+	entities.EventComplaintUpdated: mustTemplate(entities.EventComplaintUpdated,
+		"Complaint #{{.ComplaintID}} is now {{.Status}}",
+		"Hello {{.Name}},\n\nYour complaint \"{{.Title}}\" is now {{.Status}}.{{if .Note}}\n\nNote: {{.Note}}{{end}}\n",
+	),
+	entities.EventPaymentRecorded: mustTemplate(entities.EventPaymentRecorded,
+		"Payment of {{printf \"%.2f\" .Amount}} received",
+		"Hello {{.Name}},\n\nWe received your payment of {{printf \"%.2f\" .Amount}} (ref. {{.Reference}}) for your {{.Month}} mess bill. Outstanding: {{printf \"%.2f\" .Outstanding}}.\n",
+	),
+}
+
+// render fills the event template with data, the recipient's name is always available as .Name
+func render(event entities.NotificationEvent, name string, data map[string]any) (string, string, error) {
+	tmpl, ok := templates[event]
+	if !ok {
+		return "", "", apperror.ErrInvalidData
+	}
+
+	values := map[string]any{"Name": name}
+	for k, v := range data {
+		values[k] = v
+	}
+
+	var title, body bytes.Buffer
+	if err := tmpl.title.Execute(&title, values); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.body.Execute(&body, values); err != nil {
+		return "", "", err
+	}
+	return title.String(), body.String(), nil
+}
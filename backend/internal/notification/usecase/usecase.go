@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/internal/notification/notifier"
+	"github.com/ePSA-eJya/Mess_Management/internal/notification/repository"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/tracing"
+	"github.com/google/uuid"
+)
+
+var (
+	events   = []entities.NotificationEvent{entities.EventBillGenerated, entities.EventCancellationCutoff, entities.EventComplaintUpdated, entities.EventPaymentRecorded}
+	channels = []entities.NotificationChannel{entities.ChannelEmail, entities.ChannelInApp}
+)
+
+// NotificationService
+type NotificationService struct {
+	repo      repository.NotificationRepository
+	userRepo  userRepository.UserRepository
+	notifiers []notifier.Notifier
+}
+
+// Init NotificationService with the channels notifications are fanned out to
+func NewNotificationService(
+	repo repository.NotificationRepository,
+	userRepo userRepository.UserRepository,
+	notifiers ...notifier.Notifier,
+) NotificationUseCase {
+	return &NotificationService{repo: repo, userRepo: userRepo, notifiers: notifiers}
+}
+
+// NotificationService Methods - 1 render and deliver an event on every channel the user has enabled
+func (s *NotificationService) Notify(ctx context.Context, userID string, event entities.NotificationEvent, data map[string]any) (err error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.Notify")
+	defer func() { tracing.End(span, err) }()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	title, body, err := render(event, user.Name, data)
+	if err != nil {
+		return err
+	}
+
+	prefs, err := s.repo.FindPreferences(userID)
+	if err != nil {
+		return err
+	}
+	disabled := make(map[entities.NotificationChannel]bool)
+	for _, p := range prefs {
+		if p.Event == event && !p.Enabled {
+			disabled[p.Channel] = true
+		}
+	}
+
+	notification := &entities.Notification{UserID: user.ID, Event: event, Title: title, Body: body}
+
+	// keep delivering on the remaining channels when one of them fails
+	var errs []error
+	for _, n := range s.notifiers {
+		if disabled[n.Channel()] {
+			continue
+		}
+		if err := n.Notify(user, notification); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NotificationService Methods - 1b broadcast an event to every user holding role,
+// returns how many users were notified without errors
+func (s *NotificationService) NotifyRole(ctx context.Context, role entities.Role, event entities.NotificationEvent, data map[string]any) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.NotifyRole")
+	defer func() { tracing.End(span, err) }()
+
+	users, err := s.userRepo.FindByRole(ctx, role)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	notified := 0
+	for _, user := range users {
+		if err := s.Notify(ctx, user.ID.String(), event, data); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		notified++
+	}
+	return notified, errors.Join(errs...)
+}
+
+// NotificationService Methods - 2 inbox
+func (s *NotificationService) FindInbox(userID string, unreadOnly bool) ([]*entities.Notification, error) {
+	return s.repo.FindByUser(userID, unreadOnly)
+}
+
+// NotificationService Methods - 3 unread badge count
+func (s *NotificationService) CountUnread(userID string) (int64, error) {
+	return s.repo.CountUnread(userID)
+}
+
+// NotificationService Methods - 4 mark one as read
+func (s *NotificationService) MarkRead(userID string, id uint) error {
+	return s.repo.MarkRead(userID, id)
+}
+
+// NotificationService Methods - 5 mark all as read
+func (s *NotificationService) MarkAllRead(userID string) error {
+	return s.repo.MarkAllRead(userID)
+}
+
+// NotificationService Methods - 6 preferences, filled with defaults for events without a stored row
+func (s *NotificationService) FindPreferences(userID string) ([]*entities.NotificationPreference, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, apperror.ErrInvalidID
+	}
+
+	stored, err := s.repo.FindPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+	type key struct {
+		event   entities.NotificationEvent
+		channel entities.NotificationChannel
+	}
+	byKey := make(map[key]*entities.NotificationPreference, len(stored))
+	for _, p := range stored {
+		byKey[key{p.Event, p.Channel}] = p
+	}
+
+	prefs := make([]*entities.NotificationPreference, 0, len(events)*len(channels))
+	for _, event := range events {
+		for _, channel := range channels {
+			if p, ok := byKey[key{event, channel}]; ok {
+				prefs = append(prefs, p)
+				continue
+			}
+			prefs = append(prefs, &entities.NotificationPreference{UserID: uid, Event: event, Channel: channel, Enabled: true})
+		}
+	}
+	return prefs, nil
+}
+
+// NotificationService Methods - 7 update preferences
+func (s *NotificationService) UpdatePreferences(userID string, prefs []*entities.NotificationPreference) ([]*entities.NotificationPreference, error) {
+	uid, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, apperror.ErrInvalidID
+	}
+
+	for _, p := range prefs {
+		if _, ok := templates[p.Event]; !ok {
+			return nil, apperror.ErrInvalidData
+		}
+		if !slices.Contains(channels, p.Channel) {
+			return nil, apperror.ErrInvalidData
+		}
+	}
+	for _, p := range prefs {
+		p.UserID = uid
+		if err := s.repo.SavePreference(p); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.FindPreferences(userID)
+}
@@ -0,0 +1,136 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/internal/notification/notifier"
+	"github.com/ePSA-eJya/Mess_Management/internal/notification/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/notification/usecase"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+var ctx = context.Background()
+
+// recordingNotifier stands in for the email channel
+type recordingNotifier struct {
+	sent []*entities.Notification
+}
+
+func (n *recordingNotifier) Channel() entities.NotificationChannel {
+	return entities.ChannelEmail
+}
+
+func (n *recordingNotifier) Notify(user *entities.User, notification *entities.Notification) error {
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+type NotificationUseCaseTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	email   *recordingNotifier
+	service usecase.NotificationUseCase
+	user    *entities.User
+	cleanup func()
+}
+
+func (s *NotificationUseCaseTestSuite) SetupTest() {
+	s.db, s.cleanup = database.SetupTestDB(s.T())
+
+	userRepo := userRepository.NewGormUserRepository(s.db)
+	repo := repository.NewGormNotificationRepository(s.db)
+	s.email = &recordingNotifier{}
+	s.service = usecase.NewNotificationService(repo, userRepo, notifier.NewInAppNotifier(repo), s.email)
+
+	s.user = &entities.User{Email: "student@example.com", Password: "password123", Name: "Asha"}
+	s.Require().NoError(userRepo.Save(ctx, s.user, nil))
+}
+
+func (s *NotificationUseCaseTestSuite) TearDownTest() {
+	if s.cleanup != nil {
+		s.cleanup()
+	}
+}
+
+func TestNotificationUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(NotificationUseCaseTestSuite))
+}
+
+func (s *NotificationUseCaseTestSuite) TestNotify_RendersTemplateOnAllChannels() {
+	err := s.service.Notify(ctx, s.user.ID.String(), entities.EventBillGenerated, map[string]any{
+		"Month": "2026-09",
+		"Total": 3150.5,
+	})
+	s.NoError(err)
+
+	s.Require().Len(s.email.sent, 1)
+	s.Equal("Mess bill for 2026-09 generated", s.email.sent[0].Title)
+	s.Contains(s.email.sent[0].Body, "Hello Asha")
+	s.Contains(s.email.sent[0].Body, "3150.50")
+
+	inbox, err := s.service.FindInbox(s.user.ID.String(), false)
+	s.NoError(err)
+	s.Len(inbox, 1)
+}
+
+func (s *NotificationUseCaseTestSuite) TestNotify_RespectsPreferences() {
+	_, err := s.service.UpdatePreferences(s.user.ID.String(), []*entities.NotificationPreference{
+		{Event: entities.EventComplaintUpdated, Channel: entities.ChannelEmail, Enabled: false},
+	})
+	s.NoError(err)
+
+	err = s.service.Notify(ctx, s.user.ID.String(), entities.EventComplaintUpdated, map[string]any{
+		"ComplaintID": 7,
+		"Title":       "Cold food",
+		"Status":      "ACKNOWLEDGED",
+	})
+	s.NoError(err)
+
+	s.Empty(s.email.sent)
+	count, err := s.service.CountUnread(s.user.ID.String())
+	s.NoError(err)
+	s.Equal(int64(1), count)
+}
+
+func (s *NotificationUseCaseTestSuite) TestMarkRead() {
+	s.NoError(s.service.Notify(ctx, s.user.ID.String(), entities.EventCancellationCutoff, map[string]any{
+		"Date":   "2026-10-20",
+		"Cutoff": "22:00",
+	}))
+	inbox, err := s.service.FindInbox(s.user.ID.String(), true)
+	s.NoError(err)
+	s.Require().Len(inbox, 1)
+
+	s.NoError(s.service.MarkRead(s.user.ID.String(), inbox[0].ID))
+
+	unread, err := s.service.FindInbox(s.user.ID.String(), true)
+	s.NoError(err)
+	s.Empty(unread)
+}
+
+func (s *NotificationUseCaseTestSuite) TestMarkRead_OtherUsersNotification() {
+	s.NoError(s.service.Notify(ctx, s.user.ID.String(), entities.EventCancellationCutoff, map[string]any{
+		"Date":   "2026-10-20",
+		"Cutoff": "22:00",
+	}))
+	inbox, err := s.service.FindInbox(s.user.ID.String(), false)
+	s.NoError(err)
+	s.Require().Len(inbox, 1)
+
+	err = s.service.MarkRead("9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", inbox[0].ID)
+	s.Equal(gorm.ErrRecordNotFound, err)
+}
+
+func (s *NotificationUseCaseTestSuite) TestFindPreferences_DefaultsToEnabled() {
+	prefs, err := s.service.FindPreferences(s.user.ID.String())
+	s.NoError(err)
+	s.Len(prefs, 8)
+	for _, p := range prefs {
+		s.True(p.Enabled)
+	}
+}
@@ -1,5 +1,9 @@
 package dto
 
 type CreateOrderRequest struct {
-	Total float64 `json:"total" validate:"required,gt=0"`
+	Total float64 `json:"total" validate:"gt=0"`
+}
+
+type PatchOrderRequest struct {
+	Total float64 `json:"total" validate:"gt=0"`
 }
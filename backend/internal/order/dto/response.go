@@ -1,6 +0,0 @@
-package dto
-
-type OrderResponse struct {
-	ID    uint    `json:"id"`
-	Total float64 `json:"total"`
-}
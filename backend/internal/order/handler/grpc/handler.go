@@ -2,11 +2,18 @@ package grpc
 
 import (
 	"context"
+	"time"
 
 	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/internal/order/dto"
 	"github.com/ePSA-eJya/Mess_Management/internal/order/usecase"
 	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/etag"
+	"github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"github.com/ePSA-eJya/Mess_Management/pkg/validation"
 	orderpb "github.com/ePSA-eJya/Mess_Management/proto/order"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
@@ -20,55 +27,136 @@ func NewGrpcOrderHandler(uc usecase.OrderUseCase) *GrpcOrderHandler {
 }
 
 func (h *GrpcOrderHandler) CreateOrder(ctx context.Context, req *orderpb.CreateOrderRequest) (*orderpb.CreateOrderResponse, error) {
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.Struct(&dto.CreateOrderRequest{Total: req.Total}); err != nil {
+		return nil, apperror.GRPCError(err)
+	}
 	order := &entities.Order{Total: float64(req.Total)}
-	if err := h.orderUseCase.CreateOrder(order); err != nil {
-		return nil, status.Errorf(apperror.GRPCCode(err), "%s", err.Error())
+	if err := h.orderUseCase.CreateOrder(ctx, actor, order); err != nil {
+		return nil, apperror.GRPCError(err)
 	}
+	etag.SetHeader(ctx, order.Version)
 	return &orderpb.CreateOrderResponse{Order: toProtoOrder(order)}, nil
 }
 
 func (h *GrpcOrderHandler) FindOrderByID(ctx context.Context, req *orderpb.FindOrderByIDRequest) (*orderpb.FindOrderByIDResponse, error) {
-	order, err := h.orderUseCase.FindOrderByID(int(req.Id))
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	order, err := h.orderUseCase.FindOrderByID(ctx, actor, int(req.Id))
 	if err != nil {
-		return nil, status.Errorf(apperror.GRPCCode(err), "%s", err.Error())
+		return nil, apperror.GRPCError(err)
 	}
+	etag.SetHeader(ctx, order.Version)
 	return &orderpb.FindOrderByIDResponse{Order: toProtoOrder(order)}, nil
 }
 
 func (h *GrpcOrderHandler) FindAllOrders(ctx context.Context, req *orderpb.FindAllOrdersRequest) (*orderpb.FindAllOrdersResponse, error) {
-	orders, err := h.orderUseCase.FindAllOrders()
+	actor, err := actorFromContext(ctx)
 	if err != nil {
-		return nil, status.Errorf(apperror.GRPCCode(err), "%s", err.Error())
+		return nil, err
 	}
-
-	var protoOrders []*orderpb.Order
-	for _, o := range orders {
-		protoOrders = append(protoOrders, toProtoOrder(o))
+	query := pagination.Query{Cursor: req.Cursor, Limit: int(req.Limit), Sort: req.Sort, Filter: req.Filter}
+	page, err := h.orderUseCase.FindAllOrders(ctx, actor, query)
+	if err != nil {
+		return nil, apperror.GRPCError(err)
 	}
 
-	return &orderpb.FindAllOrdersResponse{Orders: protoOrders}, nil
+	return toProtoOrderPage(page), nil
 }
 
 func (h *GrpcOrderHandler) PatchOrder(ctx context.Context, req *orderpb.PatchOrderRequest) (*orderpb.PatchOrderResponse, error) {
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.Struct(&dto.PatchOrderRequest{Total: req.Total}); err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	// REST clients of the gateway may send If-Match instead of the version field
+	version := uint(req.Version)
+	if version == 0 {
+		if version, err = etag.FromIncomingContext(ctx); err != nil {
+			return nil, apperror.GRPCError(err)
+		}
+	}
 	order := &entities.Order{Total: float64(req.Total)}
-	updatedOrder, err := h.orderUseCase.PatchOrder(int(req.Id), order)
+	updatedOrder, err := h.orderUseCase.PatchOrder(ctx, actor, int(req.Id), order, version)
 	if err != nil {
-		return nil, status.Errorf(apperror.GRPCCode(err), "%s", err.Error())
+		return nil, apperror.GRPCError(err)
 	}
+	etag.SetHeader(ctx, updatedOrder.Version)
 	return &orderpb.PatchOrderResponse{Order: toProtoOrder(updatedOrder)}, nil
 }
 
 func (h *GrpcOrderHandler) DeleteOrder(ctx context.Context, req *orderpb.DeleteOrderRequest) (*orderpb.DeleteOrderResponse, error) {
-	if err := h.orderUseCase.DeleteOrder(int(req.Id)); err != nil {
-		return nil, status.Errorf(apperror.GRPCCode(err), "%s", err.Error())
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.orderUseCase.DeleteOrder(ctx, actor, int(req.Id)); err != nil {
+		return nil, apperror.GRPCError(err)
 	}
 	return &orderpb.DeleteOrderResponse{Message: "order deleted"}, nil
 }
 
+func (h *GrpcOrderHandler) FindDeletedOrders(ctx context.Context, req *orderpb.FindAllOrdersRequest) (*orderpb.FindAllOrdersResponse, error) {
+	if err := middleware.RequireGRPCRole(ctx, string(entities.RoleOfficeAdmin)); err != nil {
+		return nil, err
+	}
+	query := pagination.Query{Cursor: req.Cursor, Limit: int(req.Limit), Sort: req.Sort, Filter: req.Filter}
+	page, err := h.orderUseCase.FindDeletedOrders(ctx, query)
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	return toProtoOrderPage(page), nil
+}
+
+func (h *GrpcOrderHandler) RestoreOrder(ctx context.Context, req *orderpb.RestoreOrderRequest) (*orderpb.RestoreOrderResponse, error) {
+	if err := middleware.RequireGRPCRole(ctx, string(entities.RoleOfficeAdmin)); err != nil {
+		return nil, err
+	}
+	order, err := h.orderUseCase.RestoreOrder(ctx, int(req.Id))
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	etag.SetHeader(ctx, order.Version)
+	return &orderpb.RestoreOrderResponse{Order: toProtoOrder(order)}, nil
+}
+
+// actorFromContext reads the caller authenticated by middleware.GRPCAuthInterceptor
+func actorFromContext(ctx context.Context) (usecase.Actor, error) {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		return usecase.Actor{}, status.Error(codes.Unauthenticated, "missing token")
+	}
+	return usecase.Actor{UserID: claims.UserID, Role: entities.Role(claims.Role)}, nil
+}
+
 // helper function convert entities.Order to orderpb.Order
 func toProtoOrder(o *entities.Order) *orderpb.Order {
-	return &orderpb.Order{
-		Id:    int32(o.ID),
-		Total: float64(o.Total),
+	protoOrder := &orderpb.Order{
+		Id:      int32(o.ID),
+		Total:   float64(o.Total),
+		Version: uint32(o.Version),
+	}
+	if o.UserID != nil {
+		protoOrder.UserId = o.UserID.String()
+	}
+	if o.DeletedAt.Valid {
+		protoOrder.DeletedAt = o.DeletedAt.Time.Format(time.RFC3339)
+	}
+	return protoOrder
+}
+
+func toProtoOrderPage(page *pagination.Page[*entities.Order]) *orderpb.FindAllOrdersResponse {
+	protoOrders := make([]*orderpb.Order, len(page.Items))
+	for i, o := range page.Items {
+		protoOrders[i] = toProtoOrder(o)
 	}
+	return &orderpb.FindAllOrdersResponse{Items: protoOrders, NextCursor: page.NextCursor, Total: int32(page.Total)}
 }
@@ -1,7 +1,13 @@
 package repository
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
 	"gorm.io/gorm"
 )
 
@@ -13,33 +19,49 @@ func NewGormOrderRepository(db *gorm.DB) OrderRepository {
 	return &GormOrderRepository{db: db}
 }
 
-func (r *GormOrderRepository) Save(order *entities.Order) error {
-	return r.db.Create(&order).Error
+func (r *GormOrderRepository) Save(ctx context.Context, order *entities.Order) error {
+	return r.db.WithContext(ctx).Create(&order).Error
 }
 
-func (r *GormOrderRepository) FindAll() ([]*entities.Order, error) {
-	var orderValues []entities.Order
-	if err := r.db.Find(&orderValues).Error; err != nil {
-		return nil, err
-	}
-
-	orders := make([]*entities.Order, len(orderValues))
-	for i := range orderValues {
-		orders[i] = &orderValues[i]
-	}
-	return orders, nil
+func (r *GormOrderRepository) FindAll(ctx context.Context, query pagination.Query) (*pagination.Page[*entities.Order], error) {
+	return pagination.Find[entities.Order](r.db.WithContext(ctx), query, OrderFields)
 }
 
-func (r *GormOrderRepository) FindByID(id int) (*entities.Order, error) {
+func (r *GormOrderRepository) FindByID(ctx context.Context, id int) (*entities.Order, error) {
 	var order entities.Order
-	if err := r.db.First(&order, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&order, id).Error; err != nil {
 		return &entities.Order{}, err
 	}
 	return &order, nil
 }
 
-func (r *GormOrderRepository) Patch(id int, order *entities.Order) error {
-	result := r.db.Model(&entities.Order{}).Where("id = ?", id).Updates(order)
+// Patch updates the order and bumps its version in one transaction
+func (r *GormOrderRepository) Patch(ctx context.Context, id int, order *entities.Order, version uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&entities.Order{}).Where("id = ?", id)
+		if version != 0 {
+			query = query.Where("version = ?", version)
+		}
+		result := query.Updates(order)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			var count int64
+			if err := tx.Model(&entities.Order{}).Where("id = ?", id).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return gorm.ErrRecordNotFound
+			}
+			return fmt.Errorf("%w: order %d is no longer at version %d", apperror.ErrStaleVersion, id, version)
+		}
+		return tx.Model(&entities.Order{}).Where("id = ?", id).UpdateColumn("version", gorm.Expr("version + 1")).Error
+	})
+}
+
+func (r *GormOrderRepository) Delete(ctx context.Context, id int) error {
+	result := r.db.WithContext(ctx).Delete(&entities.Order{}, id)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -49,8 +71,14 @@ func (r *GormOrderRepository) Patch(id int, order *entities.Order) error {
 	return nil
 }
 
-func (r *GormOrderRepository) Delete(id int) error {
-	result := r.db.Delete(&entities.Order{}, id)
+func (r *GormOrderRepository) FindDeleted(ctx context.Context, query pagination.Query, since time.Time) (*pagination.Page[*entities.Order], error) {
+	return pagination.Find[entities.Order](r.db.WithContext(ctx).Unscoped().Where("deleted_at > ?", since), query, OrderFields)
+}
+
+func (r *GormOrderRepository) Restore(ctx context.Context, id int, since time.Time) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&entities.Order{}).
+		Where("id = ? AND deleted_at > ?", id, since).
+		Update("deleted_at", nil)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -59,3 +87,8 @@ func (r *GormOrderRepository) Delete(id int) error {
 	}
 	return nil
 }
+
+func (r *GormOrderRepository) Purge(before time.Time) (int64, error) {
+	result := r.db.Unscoped().Where("deleted_at < ?", before).Delete(&entities.Order{})
+	return result.RowsAffected, result.Error
+}
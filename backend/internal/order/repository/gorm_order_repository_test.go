@@ -1,15 +1,21 @@
 package repository_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/ePSA-eJya/Mess_Management/internal/database"
 	"github.com/ePSA-eJya/Mess_Management/internal/entities"
 	"github.com/ePSA-eJya/Mess_Management/internal/order/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
 )
 
+var ctx = context.Background()
+
 type OrderRepositoryTestSuite struct {
 	suite.Suite
 	db      *gorm.DB
@@ -37,7 +43,7 @@ func (s *OrderRepositoryTestSuite) TestSave() {
 		Total: 100.50,
 	}
 
-	err := s.repo.Save(order)
+	err := s.repo.Save(ctx, order)
 	s.NoError(err)
 	s.NotZero(order.ID)
 }
@@ -47,11 +53,11 @@ func (s *OrderRepositoryTestSuite) TestFindByID() {
 	order := &entities.Order{
 		Total: 200.75,
 	}
-	err := s.repo.Save(order)
+	err := s.repo.Save(ctx, order)
 	s.NoError(err)
 
 	// Find by ID
-	found, err := s.repo.FindByID(int(order.ID))
+	found, err := s.repo.FindByID(ctx, int(order.ID))
 	s.NoError(err)
 	s.NotNil(found)
 	s.Equal(order.ID, found.ID)
@@ -59,10 +65,56 @@ func (s *OrderRepositoryTestSuite) TestFindByID() {
 }
 
 func (s *OrderRepositoryTestSuite) TestFindByID_NotFound() {
-	_, err := s.repo.FindByID(99999)
+	_, err := s.repo.FindByID(ctx, 99999)
 	s.Error(err)
 }
 
+func (s *OrderRepositoryTestSuite) TestFindAll_FilterByUser() {
+	owner, other := uuid.New(), uuid.New()
+	s.NoError(s.repo.Save(ctx, &entities.Order{Total: 10, UserID: &owner}))
+	s.NoError(s.repo.Save(ctx, &entities.Order{Total: 20, UserID: &owner}))
+	s.NoError(s.repo.Save(ctx, &entities.Order{Total: 30, UserID: &other}))
+
+	found, err := s.repo.FindAll(ctx, pagination.Query{Filter: map[string]string{"user_id": owner.String()}})
+	s.NoError(err)
+	s.Len(found.Items, 2)
+	s.Equal(int64(2), found.Total)
+}
+
+func (s *OrderRepositoryTestSuite) TestFindAll_Pages() {
+	for _, total := range []float64{300, 100, 200, 100, 500} {
+		s.Require().NoError(s.repo.Save(ctx, &entities.Order{Total: total}))
+	}
+
+	// equal totals are ordered by id so no order is skipped or repeated
+	var totals []float64
+	query := pagination.Query{Limit: 2, Sort: "-total"}
+	for {
+		page, err := s.repo.FindAll(ctx, query)
+		s.Require().NoError(err)
+		s.Equal(int64(5), page.Total)
+		for _, order := range page.Items {
+			totals = append(totals, order.Total)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	s.Equal([]float64{500, 300, 200, 100, 100}, totals)
+}
+
+func (s *OrderRepositoryTestSuite) TestFindAll_RejectsUnknownFields() {
+	_, err := s.repo.FindAll(ctx, pagination.Query{Sort: "user_id"})
+	s.ErrorIs(err, apperror.ErrInvalidData)
+
+	_, err = s.repo.FindAll(ctx, pagination.Query{Filter: map[string]string{"total": "10"}})
+	s.ErrorIs(err, apperror.ErrInvalidData)
+
+	_, err = s.repo.FindAll(ctx, pagination.Query{Cursor: "not-a-cursor"})
+	s.ErrorIs(err, apperror.ErrInvalidData)
+}
+
 func (s *OrderRepositoryTestSuite) TestFindAll() {
 	// Create multiple orders
 	orders := []*entities.Order{
@@ -72,20 +124,20 @@ func (s *OrderRepositoryTestSuite) TestFindAll() {
 	}
 
 	for _, order := range orders {
-		err := s.repo.Save(order)
+		err := s.repo.Save(ctx, order)
 		s.NoError(err)
 	}
 
 	// Find all
-	allOrders, err := s.repo.FindAll()
+	allOrders, err := s.repo.FindAll(ctx, pagination.Query{})
 	s.NoError(err)
-	s.Len(allOrders, 3)
+	s.Len(allOrders.Items, 3)
 }
 
 func (s *OrderRepositoryTestSuite) TestFindAll_Empty() {
-	allOrders, err := s.repo.FindAll()
+	allOrders, err := s.repo.FindAll(ctx, pagination.Query{})
 	s.NoError(err)
-	s.Empty(allOrders)
+	s.Empty(allOrders.Items)
 }
 
 func (s *OrderRepositoryTestSuite) TestPatch() {
@@ -93,18 +145,18 @@ func (s *OrderRepositoryTestSuite) TestPatch() {
 	order := &entities.Order{
 		Total: 150.0,
 	}
-	err := s.repo.Save(order)
+	err := s.repo.Save(ctx, order)
 	s.NoError(err)
 
 	// Update order
 	updateData := &entities.Order{
 		Total: 250.0,
 	}
-	err = s.repo.Patch(int(order.ID), updateData)
+	err = s.repo.Patch(ctx, int(order.ID), updateData, 0)
 	s.NoError(err)
 
 	// Verify update
-	updated, err := s.repo.FindByID(int(order.ID))
+	updated, err := s.repo.FindByID(ctx, int(order.ID))
 	s.NoError(err)
 	s.Equal(250.0, updated.Total)
 }
@@ -113,32 +165,47 @@ func (s *OrderRepositoryTestSuite) TestPatch_NotFound() {
 	updateData := &entities.Order{
 		Total: 999.0,
 	}
-	err := s.repo.Patch(99999, updateData)
+	err := s.repo.Patch(ctx, 99999, updateData, 0)
 	s.Error(err)
 	s.Equal(gorm.ErrRecordNotFound, err)
 }
 
+func (s *OrderRepositoryTestSuite) TestPatch_StaleVersion() {
+	order := &entities.Order{Total: 150.0}
+	s.Require().NoError(s.repo.Save(ctx, order))
+	s.Equal(uint(1), order.Version)
+
+	s.Require().NoError(s.repo.Patch(ctx, int(order.ID), &entities.Order{Total: 200.0}, 1))
+	err := s.repo.Patch(ctx, int(order.ID), &entities.Order{Total: 300.0}, 1)
+	s.ErrorIs(err, apperror.ErrStaleVersion)
+
+	updated, err := s.repo.FindByID(ctx, int(order.ID))
+	s.Require().NoError(err)
+	s.Equal(200.0, updated.Total)
+	s.Equal(uint(2), updated.Version)
+}
+
 func (s *OrderRepositoryTestSuite) TestDelete() {
 	// Create an order first
 	order := &entities.Order{
 		Total: 500.0,
 	}
-	err := s.repo.Save(order)
+	err := s.repo.Save(ctx, order)
 	s.NoError(err)
 
 	orderID := int(order.ID)
 
 	// Delete order
-	err = s.repo.Delete(orderID)
+	err = s.repo.Delete(ctx, orderID)
 	s.NoError(err)
 
 	// Verify deletion
-	_, err = s.repo.FindByID(orderID)
+	_, err = s.repo.FindByID(ctx, orderID)
 	s.Error(err)
 }
 
 func (s *OrderRepositoryTestSuite) TestDelete_NotFound() {
-	err := s.repo.Delete(99999)
+	err := s.repo.Delete(ctx, 99999)
 	s.Error(err)
 	s.Equal(gorm.ErrRecordNotFound, err)
 }
@@ -149,11 +216,11 @@ func (s *OrderRepositoryTestSuite) TestSave_MultipleOrders() {
 	order2 := &entities.Order{Total: 200.0}
 	order3 := &entities.Order{Total: 300.0}
 
-	err := s.repo.Save(order1)
+	err := s.repo.Save(ctx, order1)
 	s.NoError(err)
-	err = s.repo.Save(order2)
+	err = s.repo.Save(ctx, order2)
 	s.NoError(err)
-	err = s.repo.Save(order3)
+	err = s.repo.Save(ctx, order3)
 	s.NoError(err)
 
 	s.NotEqual(order1.ID, order2.ID)
@@ -1,11 +1,34 @@
 package repository
 
-import "github.com/ePSA-eJya/Mess_Management/internal/entities"
+import (
+	"context"
+	"time"
 
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+)
+
+// OrderFields are the fields orders can be listed by
+var OrderFields = pagination.Fields{
+	Filter:      map[string]string{"user_id": "user_id"},
+	Sort:        map[string]string{"id": "id", "total": "total"},
+	DefaultSort: "id",
+}
+
+// OrderRepository queries run within ctx, so they are traced as part of the
+// request. Purge runs from a job and has none.
 type OrderRepository interface {
-	Save(order *entities.Order) error
-	FindAll() ([]*entities.Order, error)
-	FindByID(id int) (*entities.Order, error)
-	Patch(id int, order *entities.Order) error
-	Delete(id int) error
+	Save(ctx context.Context, order *entities.Order) error
+	FindAll(ctx context.Context, query pagination.Query) (*pagination.Page[*entities.Order], error)
+	FindByID(ctx context.Context, id int) (*entities.Order, error)
+	// Patch fails with apperror.ErrStaleVersion unless the order is still at
+	// version, 0 skips the check
+	Patch(ctx context.Context, id int, order *entities.Order, version uint) error
+	Delete(ctx context.Context, id int) error
+
+	// deleted orders, only those deleted after since can be found and restored
+	FindDeleted(ctx context.Context, query pagination.Query, since time.Time) (*pagination.Page[*entities.Order], error)
+	Restore(ctx context.Context, id int, since time.Time) error
+	// Purge removes orders deleted before before for good
+	Purge(before time.Time) (int64, error)
 }
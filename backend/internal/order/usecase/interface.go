@@ -1,11 +1,25 @@
 package usecase
 
-import "github.com/ePSA-eJya/Mess_Management/internal/entities"
+import (
+	"context"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+)
+
+// Actor identifies the authenticated user performing an order action
+type Actor struct {
+	UserID string
+	Role   entities.Role
+}
 
 type OrderUseCase interface {
-	FindAllOrders() ([]*entities.Order, error)
-	CreateOrder(order *entities.Order) error
-	PatchOrder(id int, order *entities.Order) (*entities.Order, error)
-	DeleteOrder(id int) error
-	FindOrderByID(id int) (*entities.Order, error)
+	FindAllOrders(ctx context.Context, actor Actor, query pagination.Query) (*pagination.Page[*entities.Order], error)
+	CreateOrder(ctx context.Context, actor Actor, order *entities.Order) error
+	// PatchOrder applies on top of version, the one the caller read, which is required
+	PatchOrder(ctx context.Context, actor Actor, id int, order *entities.Order, version uint) (*entities.Order, error)
+	DeleteOrder(ctx context.Context, actor Actor, id int) error
+	FindOrderByID(ctx context.Context, actor Actor, id int) (*entities.Order, error)
+	FindDeletedOrders(ctx context.Context, query pagination.Query) (*pagination.Page[*entities.Order], error)
+	RestoreOrder(ctx context.Context, id int) (*entities.Order, error)
 }
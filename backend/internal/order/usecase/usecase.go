@@ -1,54 +1,98 @@
 package usecase
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/ePSA-eJya/Mess_Management/internal/entities"
 	"github.com/ePSA-eJya/Mess_Management/internal/order/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"github.com/ePSA-eJya/Mess_Management/pkg/tracing"
+	"github.com/google/uuid"
 )
 
 // OrderService
 type OrderService struct {
 	repo repository.OrderRepository
+	// retention is how long a deleted order can be restored
+	retention time.Duration
 }
 
 // Init OrderService function
-func NewOrderService(repo repository.OrderRepository) OrderUseCase {
-	return &OrderService{repo: repo}
+func NewOrderService(repo repository.OrderRepository, retention time.Duration) OrderUseCase {
+	return &OrderService{repo: repo, retention: retention}
 }
 
-// OrderService Methods - 1 create
-func (s *OrderService) CreateOrder(order *entities.Order) error {
-	if err := s.repo.Save(order); err != nil {
+// OrderService Methods - 1 create, the order belongs to the actor
+func (s *OrderService) CreateOrder(ctx context.Context, actor Actor, order *entities.Order) (err error) {
+	ctx, span := tracing.Start(ctx, "OrderService.CreateOrder")
+	defer func() { tracing.End(span, err) }()
+
+	owner, err := uuid.Parse(actor.UserID)
+	if err != nil {
+		return apperror.ErrUnauthorized
+	}
+	order.UserID = &owner
+
+	if err := s.repo.Save(ctx, order); err != nil {
 		return err
 	}
 	return nil
 }
 
-// OrderService Methods - 2 find all
-func (s *OrderService) FindAllOrders() ([]*entities.Order, error) {
-	orders, err := s.repo.FindAll()
+// OrderService Methods - 2 find a page of orders, admins see every order and others their own
+func (s *OrderService) FindAllOrders(ctx context.Context, actor Actor, query pagination.Query) (_ *pagination.Page[*entities.Order], err error) {
+	ctx, span := tracing.Start(ctx, "OrderService.FindAllOrders")
+	defer func() { tracing.End(span, err) }()
+
+	if !actor.Role.IsAdmin() {
+		query = query.WithFilter("user_id", actor.UserID)
+	}
+
+	page, err := s.repo.FindAll(ctx, query)
 	if err != nil {
 		return nil, err
 	}
-	return orders, nil
+	return page, nil
 }
 
 // OrderService Methods - 3 find by id
-func (s *OrderService) FindOrderByID(id int) (*entities.Order, error) {
-	order, err := s.repo.FindByID(id)
+func (s *OrderService) FindOrderByID(ctx context.Context, actor Actor, id int) (_ *entities.Order, err error) {
+	ctx, span := tracing.Start(ctx, "OrderService.FindOrderByID")
+	defer func() { tracing.End(span, err) }()
+
+	order, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return &entities.Order{}, err
 	}
+	if err := authorize(actor, order); err != nil {
+		return nil, err
+	}
 
 	return order, nil
 }
 
 // OrderService Methods - 4 patch
-func (s *OrderService) PatchOrder(id int, order *entities.Order) (*entities.Order, error) {
-	if err := s.repo.Patch(id, order); err != nil {
+func (s *OrderService) PatchOrder(ctx context.Context, actor Actor, id int, order *entities.Order, version uint) (_ *entities.Order, err error) {
+	ctx, span := tracing.Start(ctx, "OrderService.PatchOrder")
+	defer func() { tracing.End(span, err) }()
+
+	if version == 0 {
+		return nil, fmt.Errorf("%w: send the version of the order the update is based on, If-Match over REST", apperror.ErrPreconditionRequired)
+	}
+	if _, err := s.FindOrderByID(ctx, actor, id); err != nil {
 		return nil, err
 	}
+	// the owner never changes
+	order.UserID = nil
 
-	updatedOrder, err := s.repo.FindByID(id)
+	if err := s.repo.Patch(ctx, id, order, version); err != nil {
+		return nil, err
+	}
+
+	updatedOrder, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -57,10 +101,47 @@ func (s *OrderService) PatchOrder(id int, order *entities.Order) (*entities.Orde
 }
 
 // OrderService Methods - 5 delete
-func (s *OrderService) DeleteOrder(id int) error {
-	if err := s.repo.Delete(id); err != nil {
+func (s *OrderService) DeleteOrder(ctx context.Context, actor Actor, id int) (err error) {
+	ctx, span := tracing.Start(ctx, "OrderService.DeleteOrder")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := s.FindOrderByID(ctx, actor, id); err != nil {
 		return err
 	}
 
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// OrderService Methods - 6 find a page of orders deleted within the retention window
+func (s *OrderService) FindDeletedOrders(ctx context.Context, query pagination.Query) (_ *pagination.Page[*entities.Order], err error) {
+	ctx, span := tracing.Start(ctx, "OrderService.FindDeletedOrders")
+	defer func() { tracing.End(span, err) }()
+
+	return s.repo.FindDeleted(ctx, query, time.Now().Add(-s.retention))
+}
+
+// OrderService Methods - 7 restore an order deleted within the retention window
+func (s *OrderService) RestoreOrder(ctx context.Context, id int) (_ *entities.Order, err error) {
+	ctx, span := tracing.Start(ctx, "OrderService.RestoreOrder")
+	defer func() { tracing.End(span, err) }()
+
+	if err := s.repo.Restore(ctx, id, time.Now().Add(-s.retention)); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(ctx, id)
+}
+
+// authorize lets admins act on any order and everyone else on their own
+func authorize(actor Actor, order *entities.Order) error {
+	if actor.Role.IsAdmin() {
+		return nil
+	}
+	if order.UserID == nil || order.UserID.String() != actor.UserID {
+		return apperror.ErrForbidden
+	}
 	return nil
 }
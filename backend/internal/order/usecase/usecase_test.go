@@ -1,28 +1,39 @@
 package usecase_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/ePSA-eJya/Mess_Management/internal/database"
 	"github.com/ePSA-eJya/Mess_Management/internal/entities"
 	"github.com/ePSA-eJya/Mess_Management/internal/order/repository"
 	"github.com/ePSA-eJya/Mess_Management/internal/order/usecase"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
 )
 
+var ctx = context.Background()
+
+const retention = 30 * 24 * time.Hour
+
 type OrderUseCaseTestSuite struct {
 	suite.Suite
 	db      *gorm.DB
 	repo    repository.OrderRepository
 	service usecase.OrderUseCase
+	owner   usecase.Actor
 	cleanup func()
 }
 
 func (s *OrderUseCaseTestSuite) SetupTest() {
 	s.db, s.cleanup = database.SetupTestDB(s.T())
 	s.repo = repository.NewGormOrderRepository(s.db)
-	s.service = usecase.NewOrderService(s.repo)
+	s.service = usecase.NewOrderService(s.repo, retention)
+	s.owner = usecase.Actor{UserID: uuid.NewString(), Role: entities.RoleStudent}
 }
 
 func (s *OrderUseCaseTestSuite) TearDownTest() {
@@ -40,9 +51,44 @@ func (s *OrderUseCaseTestSuite) TestCreateOrder() {
 		Total: 150.50,
 	}
 
-	err := s.service.CreateOrder(order)
+	err := s.service.CreateOrder(ctx, s.owner, order)
 	s.NoError(err)
 	s.NotZero(order.ID)
+	s.Require().NotNil(order.UserID)
+	s.Equal(s.owner.UserID, order.UserID.String())
+}
+
+func (s *OrderUseCaseTestSuite) TestCreateOrder_Unauthenticated() {
+	err := s.service.CreateOrder(ctx, usecase.Actor{}, &entities.Order{Total: 10})
+	s.ErrorIs(err, apperror.ErrUnauthorized)
+}
+
+func (s *OrderUseCaseTestSuite) TestOrders_OnlyOwnerOrAdmin() {
+	order := &entities.Order{Total: 120}
+	s.Require().NoError(s.service.CreateOrder(ctx, s.owner, order))
+	orderID := int(order.ID)
+
+	other := usecase.Actor{UserID: uuid.NewString(), Role: entities.RoleStudent}
+	_, err := s.service.FindOrderByID(ctx, other, orderID)
+	s.ErrorIs(err, apperror.ErrForbidden)
+	_, err = s.service.PatchOrder(ctx, other, orderID, &entities.Order{Total: 1}, 1)
+	s.ErrorIs(err, apperror.ErrForbidden)
+	s.ErrorIs(s.service.DeleteOrder(ctx, other, orderID), apperror.ErrForbidden)
+
+	otherOrders, err := s.service.FindAllOrders(ctx, other, pagination.Query{})
+	s.NoError(err)
+	s.Empty(otherOrders.Items)
+
+	admin := usecase.Actor{UserID: uuid.NewString(), Role: entities.RoleMessAdmin}
+	allOrders, err := s.service.FindAllOrders(ctx, admin, pagination.Query{})
+	s.NoError(err)
+	s.Len(allOrders.Items, 1)
+
+	updated, err := s.service.PatchOrder(ctx, admin, orderID, &entities.Order{Total: 90}, 1)
+	s.NoError(err)
+	s.Equal(90.0, updated.Total)
+	s.Equal(s.owner.UserID, updated.UserID.String())
+	s.NoError(s.service.DeleteOrder(ctx, admin, orderID))
 }
 
 func (s *OrderUseCaseTestSuite) TestFindAllOrders() {
@@ -54,20 +100,20 @@ func (s *OrderUseCaseTestSuite) TestFindAllOrders() {
 	}
 
 	for _, order := range orders {
-		err := s.service.CreateOrder(order)
+		err := s.service.CreateOrder(ctx, s.owner, order)
 		s.NoError(err)
 	}
 
 	// Find all
-	allOrders, err := s.service.FindAllOrders()
+	allOrders, err := s.service.FindAllOrders(ctx, s.owner, pagination.Query{})
 	s.NoError(err)
-	s.Len(allOrders, 3)
+	s.Len(allOrders.Items, 3)
 }
 
 func (s *OrderUseCaseTestSuite) TestFindAllOrders_Empty() {
-	allOrders, err := s.service.FindAllOrders()
+	allOrders, err := s.service.FindAllOrders(ctx, s.owner, pagination.Query{})
 	s.NoError(err)
-	s.Empty(allOrders)
+	s.Empty(allOrders.Items)
 }
 
 func (s *OrderUseCaseTestSuite) TestFindOrderByID() {
@@ -75,11 +121,11 @@ func (s *OrderUseCaseTestSuite) TestFindOrderByID() {
 	order := &entities.Order{
 		Total: 250.75,
 	}
-	err := s.service.CreateOrder(order)
+	err := s.service.CreateOrder(ctx, s.owner, order)
 	s.NoError(err)
 
 	// Find by ID
-	found, err := s.service.FindOrderByID(int(order.ID))
+	found, err := s.service.FindOrderByID(ctx, s.owner, int(order.ID))
 	s.NoError(err)
 	s.NotNil(found)
 	s.Equal(order.ID, found.ID)
@@ -87,7 +133,7 @@ func (s *OrderUseCaseTestSuite) TestFindOrderByID() {
 }
 
 func (s *OrderUseCaseTestSuite) TestFindOrderByID_NotFound() {
-	_, err := s.service.FindOrderByID(99999)
+	_, err := s.service.FindOrderByID(ctx, s.owner, 99999)
 	s.Error(err)
 }
 
@@ -96,7 +142,7 @@ func (s *OrderUseCaseTestSuite) TestPatchOrder() {
 	order := &entities.Order{
 		Total: 100.0,
 	}
-	err := s.service.CreateOrder(order)
+	err := s.service.CreateOrder(ctx, s.owner, order)
 	s.NoError(err)
 
 	orderID := int(order.ID)
@@ -105,18 +151,30 @@ func (s *OrderUseCaseTestSuite) TestPatchOrder() {
 	updateData := &entities.Order{
 		Total: 500.0,
 	}
-	updated, err := s.service.PatchOrder(orderID, updateData)
+	updated, err := s.service.PatchOrder(ctx, s.owner, orderID, updateData, 1)
 	s.NoError(err)
 	s.NotNil(updated)
 	s.Equal(500.0, updated.Total)
 	s.Equal(orderID, int(updated.ID))
 }
 
+func (s *OrderUseCaseTestSuite) TestPatchOrder_VersionRequired() {
+	order := &entities.Order{Total: 100.0}
+	s.Require().NoError(s.service.CreateOrder(ctx, s.owner, order))
+
+	_, err := s.service.PatchOrder(ctx, s.owner, int(order.ID), &entities.Order{Total: 500.0}, 0)
+	s.ErrorIs(err, apperror.ErrPreconditionRequired)
+
+	found, err := s.service.FindOrderByID(ctx, s.owner, int(order.ID))
+	s.Require().NoError(err)
+	s.Equal(100.0, found.Total)
+}
+
 func (s *OrderUseCaseTestSuite) TestPatchOrder_NotFound() {
 	updateData := &entities.Order{
 		Total: 999.0,
 	}
-	updated, err := s.service.PatchOrder(99999, updateData)
+	updated, err := s.service.PatchOrder(ctx, s.owner, 99999, updateData, 1)
 	s.Error(err)
 	s.Nil(updated)
 	s.Equal(gorm.ErrRecordNotFound, err)
@@ -127,22 +185,64 @@ func (s *OrderUseCaseTestSuite) TestDeleteOrder() {
 	order := &entities.Order{
 		Total: 600.0,
 	}
-	err := s.service.CreateOrder(order)
+	err := s.service.CreateOrder(ctx, s.owner, order)
 	s.NoError(err)
 
 	orderID := int(order.ID)
 
 	// Delete order
-	err = s.service.DeleteOrder(orderID)
+	err = s.service.DeleteOrder(ctx, s.owner, orderID)
 	s.NoError(err)
 
 	// Verify deletion
-	_, err = s.service.FindOrderByID(orderID)
+	_, err = s.service.FindOrderByID(ctx, s.owner, orderID)
 	s.Error(err)
 }
 
+func (s *OrderUseCaseTestSuite) TestDeleteOrder_KeepsOrderForRestore() {
+	order := &entities.Order{Total: 600.0}
+	s.Require().NoError(s.service.CreateOrder(ctx, s.owner, order))
+	s.Require().NoError(s.service.DeleteOrder(ctx, s.owner, int(order.ID)))
+
+	page, err := s.service.FindAllOrders(ctx, s.owner, pagination.Query{})
+	s.NoError(err)
+	s.Empty(page.Items)
+
+	deleted, err := s.service.FindDeletedOrders(ctx, pagination.Query{})
+	s.NoError(err)
+	s.Require().Len(deleted.Items, 1)
+	s.True(deleted.Items[0].DeletedAt.Valid)
+
+	restored, err := s.service.RestoreOrder(ctx, int(order.ID))
+	s.NoError(err)
+	s.False(restored.DeletedAt.Valid)
+	_, err = s.service.FindOrderByID(ctx, s.owner, int(order.ID))
+	s.NoError(err)
+}
+
+func (s *OrderUseCaseTestSuite) TestRestoreOrder_AfterRetentionIsPurged() {
+	order := &entities.Order{Total: 600.0}
+	s.Require().NoError(s.service.CreateOrder(ctx, s.owner, order))
+	s.Require().NoError(s.service.DeleteOrder(ctx, s.owner, int(order.ID)))
+	s.Require().NoError(s.db.Unscoped().Model(&entities.Order{}).Where("id = ?", order.ID).
+		Update("deleted_at", time.Now().Add(-retention-time.Hour)).Error)
+
+	deleted, err := s.service.FindDeletedOrders(ctx, pagination.Query{})
+	s.NoError(err)
+	s.Empty(deleted.Items)
+	_, err = s.service.RestoreOrder(ctx, int(order.ID))
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	purged, err := s.repo.Purge(time.Now().Add(-retention))
+	s.NoError(err)
+	s.Equal(int64(1), purged)
+	var count int64
+	s.NoError(s.db.Unscoped().Model(&entities.Order{}).Count(&count).Error)
+	s.Zero(count)
+}
+
 func (s *OrderUseCaseTestSuite) TestDeleteOrder_NotFound() {
-	err := s.service.DeleteOrder(99999)
+	err := s.service.DeleteOrder(ctx, s.owner, 99999)
 	s.Error(err)
 	s.Equal(gorm.ErrRecordNotFound, err)
 }
@@ -152,7 +252,7 @@ func (s *OrderUseCaseTestSuite) TestCreateOrder_ZeroTotal() {
 		Total: 0.0,
 	}
 
-	err := s.service.CreateOrder(order)
+	err := s.service.CreateOrder(ctx, s.owner, order)
 	s.NoError(err)
 	s.NotZero(order.ID)
 	s.Equal(0.0, order.Total)
@@ -163,7 +263,7 @@ func (s *OrderUseCaseTestSuite) TestCreateOrder_LargeTotal() {
 		Total: 999999.99,
 	}
 
-	err := s.service.CreateOrder(order)
+	err := s.service.CreateOrder(ctx, s.owner, order)
 	s.NoError(err)
 	s.NotZero(order.ID)
 	s.Equal(999999.99, order.Total)
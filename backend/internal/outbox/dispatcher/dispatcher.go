@@ -0,0 +1,171 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/internal/outbox/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/logger"
+)
+
+var log = logger.For("outbox")
+
+// Handler is an in-process subscriber to domain events. Delivery is at least
+// once, so handlers must tolerate seeing the same event again.
+type Handler interface {
+	Name() string
+	Handle(ctx context.Context, event *entities.OutboxEvent) error
+}
+
+type Config struct {
+	BatchSize      int
+	MaxAttempts    int
+	BaseBackoff    time.Duration
+	MaxBackoff     time.Duration
+	WebhookTimeout time.Duration
+	// ClaimLease is how long a batch is reserved for this dispatcher, events it
+	// has not reached by then are left for the next run
+	ClaimLease time.Duration
+}
+
+type Dispatcher struct {
+	repo     repository.OutboxRepository
+	handlers map[string][]Handler
+	client   *http.Client
+	cfg      Config
+}
+
+func New(repo repository.OutboxRepository, cfg Config) *Dispatcher {
+	return &Dispatcher{
+		repo:     repo,
+		handlers: make(map[string][]Handler),
+		client:   &http.Client{Timeout: cfg.WebhookTimeout},
+		cfg:      cfg,
+	}
+}
+
+// Subscribe registers h for events of eventType
+func (d *Dispatcher) Subscribe(eventType string, h Handler) {
+	d.handlers[eventType] = append(d.handlers[eventType], h)
+}
+
+// DispatchDue delivers one batch of due events and returns how many were fully delivered.
+// Subscribers that already received an event are skipped when it is retried, an event
+// that keeps failing is dead-lettered after MaxAttempts. The batch is leased, so
+// dispatchers running at the same time never deliver the same event.
+func (d *Dispatcher) DispatchDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	// Postgres keeps microseconds, Release matches the lease by equality
+	leaseUntil := now.Add(d.cfg.ClaimLease).Truncate(time.Microsecond)
+	events, err := d.repo.ClaimDue(now, leaseUntil, d.cfg.BatchSize)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	webhooks, err := d.repo.FindWebhooks(true)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, event := range events {
+		if err := ctx.Err(); err != nil {
+			return delivered, err
+		}
+		// the rest of the batch is due again and may already be claimed elsewhere
+		if !time.Now().Before(leaseUntil) {
+			return delivered, nil
+		}
+
+		deliverErr := d.deliver(ctx, event, webhooks)
+		now := time.Now()
+		event.Attempts++
+		if deliverErr == nil {
+			event.Status = entities.OutboxDelivered
+			event.DeliveredAt = &now
+			event.LastError = ""
+			delivered++
+		} else {
+			event.LastError = deliverErr.Error()
+			if event.Attempts >= d.cfg.MaxAttempts {
+				event.Status = entities.OutboxDead
+				log.ErrorContext(ctx, "Outbox event dead-lettered", "event_id", event.ID, "event_type", event.EventType, "attempts", event.Attempts, "error", deliverErr)
+			} else {
+				event.NextAttemptAt = now.Add(d.backoff(event.Attempts))
+			}
+		}
+
+		released, err := d.repo.Release(event, leaseUntil)
+		if err != nil {
+			return delivered, err
+		}
+		if !released {
+			log.WarnContext(ctx, "Outbox lease ran out before the event was released", "event_id", event.ID)
+		}
+	}
+	return delivered, nil
+}
+
+// deliver sends event to every subscriber that has not received it yet
+func (d *Dispatcher) deliver(ctx context.Context, event *entities.OutboxEvent, webhooks []*entities.WebhookSubscription) error {
+	done, err := d.repo.FindDeliveredSubscribers(event.ID)
+	if err != nil {
+		return err
+	}
+	received := make(map[string]bool, len(done))
+	for _, name := range done {
+		received[name] = true
+	}
+
+	var errs []error
+	attempt := func(subscriber string, send func() error) {
+		if received[subscriber] {
+			return
+		}
+		if err := send(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", subscriber, err))
+			return
+		}
+		err := d.repo.SaveDelivery(&entities.OutboxDelivery{EventID: event.ID, Subscriber: subscriber, DeliveredAt: time.Now()})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, h := range d.handlers[event.EventType] {
+		attempt("handler:"+h.Name(), func() error { return d.handle(ctx, h, event) })
+	}
+	for _, w := range webhooks {
+		if !w.Accepts(event.EventType) {
+			continue
+		}
+		attempt(fmt.Sprintf("webhook:%d", w.ID), func() error { return d.postWebhook(ctx, w, event) })
+	}
+
+	return errors.Join(errs...)
+}
+
+// handle runs a handler, a panic counts as a failed delivery
+func (d *Dispatcher) handle(ctx context.Context, h Handler, event *entities.OutboxEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return h.Handle(ctx, event)
+}
+
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	wait := d.cfg.BaseBackoff
+	for i := 1; i < attempts && wait < d.cfg.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if d.cfg.MaxBackoff > 0 && wait > d.cfg.MaxBackoff {
+		return d.cfg.MaxBackoff
+	}
+	return wait
+}
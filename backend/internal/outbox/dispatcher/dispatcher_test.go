@@ -0,0 +1,300 @@
+package dispatcher_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/internal/outbox/dispatcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRepo keeps outbox state in memory for dispatcher tests
+type memoryRepo struct {
+	mu         sync.Mutex
+	events     map[uint]*entities.OutboxEvent
+	deliveries map[uint][]string
+	webhooks   []*entities.WebhookSubscription
+}
+
+func newMemoryRepo(events ...*entities.OutboxEvent) *memoryRepo {
+	r := &memoryRepo{events: map[uint]*entities.OutboxEvent{}, deliveries: map[uint][]string{}}
+	for i, e := range events {
+		e.ID = uint(i + 1)
+		r.events[e.ID] = e
+	}
+	return r
+}
+
+// ClaimDue hands out copies like a query would, Release writes them back
+func (r *memoryRepo) ClaimDue(now, leaseUntil time.Time, limit int) ([]*entities.OutboxEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []*entities.OutboxEvent
+	for id := uint(1); id <= uint(len(r.events)) && len(due) < limit; id++ {
+		e := r.events[id]
+		if e.Status == entities.OutboxPending && !e.NextAttemptAt.After(now) {
+			e.NextAttemptAt = leaseUntil
+			claimed := *e
+			due = append(due, &claimed)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryRepo) Release(event *entities.OutboxEvent, leasedUntil time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := r.events[event.ID]
+	if stored.Status != entities.OutboxPending || !stored.NextAttemptAt.Equal(leasedUntil) {
+		return false, nil
+	}
+	*stored = *event
+	return true, nil
+}
+
+func (r *memoryRepo) FindByID(id uint) (*entities.OutboxEvent, error) { return r.events[id], nil }
+
+func (r *memoryRepo) FindByStatus(status entities.OutboxStatus, limit int) ([]*entities.OutboxEvent, error) {
+	return nil, nil
+}
+
+func (r *memoryRepo) Update(event *entities.OutboxEvent) error { return nil }
+
+func (r *memoryRepo) FindDeliveredSubscribers(eventID uint) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.deliveries[eventID]...), nil
+}
+
+func (r *memoryRepo) SaveDelivery(d *entities.OutboxDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries[d.EventID] = append(r.deliveries[d.EventID], d.Subscriber)
+	return nil
+}
+
+func (r *memoryRepo) SaveWebhook(w *entities.WebhookSubscription) error {
+	r.webhooks = append(r.webhooks, w)
+	return nil
+}
+
+func (r *memoryRepo) FindWebhooks(activeOnly bool) ([]*entities.WebhookSubscription, error) {
+	return r.webhooks, nil
+}
+
+func (r *memoryRepo) DeleteWebhook(id uint) error { return nil }
+
+type countingHandler struct {
+	mu    sync.Mutex
+	calls int
+	fail  bool
+}
+
+func (h *countingHandler) Name() string { return "counting" }
+
+func (h *countingHandler) Handle(ctx context.Context, event *entities.OutboxEvent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls++
+	if h.fail {
+		return errors.New("handler failed")
+	}
+	return nil
+}
+
+func newEvent(t *testing.T, eventType string) *entities.OutboxEvent {
+	event, err := entities.NewOutboxEvent(eventType, "monthly_bill", "bill-1", map[string]any{"roll": 7})
+	require.NoError(t, err)
+	event.NextAttemptAt = time.Now().Add(-time.Second)
+	return event
+}
+
+func testConfig() dispatcher.Config {
+	return dispatcher.Config{BatchSize: 10, MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour, WebhookTimeout: time.Second, ClaimLease: time.Minute}
+}
+
+func TestDispatchDue_DeliversSignedWebhook(t *testing.T) {
+	var gotBody []byte
+	var gotHeaders http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotHeaders = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	event := newEvent(t, entities.EventTypeBillGenerated)
+	repo := newMemoryRepo(event)
+	repo.webhooks = []*entities.WebhookSubscription{
+		{ID: 1, URL: server.URL, Secret: "s3cret", EventTypes: entities.EventTypeBillGenerated, Active: true},
+	}
+	handler := &countingHandler{}
+	d := dispatcher.New(repo, testConfig())
+	d.Subscribe(entities.EventTypeBillGenerated, handler)
+
+	delivered, err := d.DispatchDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, 1, handler.calls)
+	assert.Equal(t, entities.OutboxDelivered, event.Status)
+	assert.NotNil(t, event.DeliveredAt)
+
+	timestamp, err := strconv.ParseInt(gotHeaders.Get(dispatcher.HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, entities.EventTypeBillGenerated, gotHeaders.Get(dispatcher.HeaderEvent))
+	assert.Equal(t, dispatcher.Sign("s3cret", timestamp, gotBody), gotHeaders.Get(dispatcher.HeaderSignature))
+	assert.Contains(t, string(gotBody), `"data":{"roll":7}`)
+}
+
+func TestDispatchDue_RetriesOnlyFailedSubscribers(t *testing.T) {
+	failing := true
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if failing {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	event := newEvent(t, entities.EventTypePaymentRecorded)
+	repo := newMemoryRepo(event)
+	repo.webhooks = []*entities.WebhookSubscription{{ID: 1, URL: server.URL, Secret: "s", Active: true}}
+	handler := &countingHandler{}
+	d := dispatcher.New(repo, testConfig())
+	d.Subscribe(entities.EventTypePaymentRecorded, handler)
+
+	delivered, err := d.DispatchDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Equal(t, entities.OutboxPending, event.Status)
+	assert.Equal(t, 1, event.Attempts)
+	assert.Contains(t, event.LastError, "unexpected status 502")
+	assert.True(t, event.NextAttemptAt.After(time.Now().Add(50*time.Second)))
+
+	failing = false
+	event.NextAttemptAt = time.Now().Add(-time.Second)
+	delivered, err = d.DispatchDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, 2, hits)
+	assert.Equal(t, 1, handler.calls, "handler already received the event")
+}
+
+func TestDispatchDue_DeadLettersAfterMaxAttempts(t *testing.T) {
+	event := newEvent(t, entities.EventTypeMealCancelled)
+	repo := newMemoryRepo(event)
+	handler := &countingHandler{fail: true}
+	d := dispatcher.New(repo, testConfig())
+	d.Subscribe(entities.EventTypeMealCancelled, handler)
+
+	for i := 0; i < 3; i++ {
+		event.NextAttemptAt = time.Now().Add(-time.Second)
+		_, err := d.DispatchDue(context.Background())
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, entities.OutboxDead, event.Status)
+	assert.Equal(t, 3, event.Attempts)
+	assert.Equal(t, 3, handler.calls)
+
+	delivered, err := d.DispatchDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Equal(t, 3, handler.calls)
+}
+
+func TestDispatchDue_ConcurrentDispatchersDeliverOnce(t *testing.T) {
+	var mu sync.Mutex
+	hits := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// slow enough that both dispatchers are busy at the same time
+		time.Sleep(20 * time.Millisecond)
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		hits[string(body)]++
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	events := make([]*entities.OutboxEvent, 6)
+	for i := range events {
+		events[i] = newEvent(t, entities.EventTypeBillGenerated)
+	}
+	repo := newMemoryRepo(events...)
+	repo.webhooks = []*entities.WebhookSubscription{{ID: 1, URL: server.URL, Secret: "s", Active: true}}
+	handler := &countingHandler{}
+
+	// each dispatcher claims half of the events
+	cfg := testConfig()
+	cfg.BatchSize = len(events) / 2
+	var wg sync.WaitGroup
+	counts := make([]int, 2)
+	for i := range counts {
+		d := dispatcher.New(repo, cfg)
+		d.Subscribe(entities.EventTypeBillGenerated, handler)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			delivered, err := d.DispatchDue(context.Background())
+			assert.NoError(t, err)
+			counts[i] = delivered
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, []int{3, 3}, counts)
+	assert.Equal(t, len(events), handler.calls)
+	assert.Len(t, hits, len(events))
+	for body, n := range hits {
+		assert.Equal(t, 1, n, body)
+	}
+	for _, event := range events {
+		assert.Equal(t, entities.OutboxDelivered, event.Status)
+		assert.Equal(t, 1, event.Attempts)
+	}
+}
+
+func TestDispatchDue_StopsOnceLeaseRunsOut(t *testing.T) {
+	first, second := newEvent(t, entities.EventTypeMealCancelled), newEvent(t, entities.EventTypeMealCancelled)
+	repo := newMemoryRepo(first, second)
+	cfg := testConfig()
+	cfg.ClaimLease = 30 * time.Millisecond
+	d := dispatcher.New(repo, cfg)
+	d.Subscribe(entities.EventTypeMealCancelled, &slowHandler{wait: 50 * time.Millisecond})
+
+	delivered, err := d.DispatchDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	// nobody claimed the first event meanwhile so its outcome is kept,
+	// the second was never started once the lease had run out
+	assert.Equal(t, entities.OutboxDelivered, first.Status)
+	assert.Equal(t, 0, second.Attempts)
+	assert.Equal(t, entities.OutboxPending, second.Status)
+}
+
+type slowHandler struct{ wait time.Duration }
+
+func (h *slowHandler) Name() string { return "slow" }
+
+func (h *slowHandler) Handle(ctx context.Context, event *entities.OutboxEvent) error {
+	time.Sleep(h.wait)
+	return nil
+}
+
+func TestWebhookSubscription_Accepts(t *testing.T) {
+	all := &entities.WebhookSubscription{}
+	some := &entities.WebhookSubscription{EventTypes: "bill.generated, payment.recorded"}
+
+	assert.True(t, all.Accepts(entities.EventTypeMealCancelled))
+	assert.True(t, some.Accepts(entities.EventTypePaymentRecorded))
+	assert.False(t, some.Accepts(entities.EventTypeMealCancelled))
+}
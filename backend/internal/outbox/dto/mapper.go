@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"strings"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+)
+
+func ToWebhookEntity(req *CreateWebhookRequest) *entities.WebhookSubscription {
+	return &entities.WebhookSubscription{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: strings.Join(req.EventTypes, ","),
+	}
+}
+
+func ToWebhookResponse(webhook *entities.WebhookSubscription) *WebhookResponse {
+	eventTypes := make([]string, 0)
+	if webhook.EventTypes != "" {
+		eventTypes = strings.Split(webhook.EventTypes, ",")
+	}
+	return &WebhookResponse{
+		ID:         webhook.ID,
+		URL:        webhook.URL,
+		EventTypes: eventTypes,
+		Active:     webhook.Active,
+		CreatedAt:  webhook.CreatedAt,
+	}
+}
+
+func ToWebhookResponseList(webhooks []*entities.WebhookSubscription) []*WebhookResponse {
+	result := make([]*WebhookResponse, 0, len(webhooks))
+	for _, w := range webhooks {
+		result = append(result, ToWebhookResponse(w))
+	}
+	return result
+}
+
+func ToOutboxEventResponse(event *entities.OutboxEvent) *OutboxEventResponse {
+	return &OutboxEventResponse{
+		ID:            event.ID,
+		EventType:     event.EventType,
+		AggregateType: event.AggregateType,
+		AggregateID:   event.AggregateID,
+		Status:        string(event.Status),
+		Attempts:      event.Attempts,
+		NextAttemptAt: event.NextAttemptAt,
+		LastError:     event.LastError,
+		CreatedAt:     event.CreatedAt,
+		DeliveredAt:   event.DeliveredAt,
+	}
+}
+
+func ToOutboxEventResponseList(events []*entities.OutboxEvent) []*OutboxEventResponse {
+	result := make([]*OutboxEventResponse, 0, len(events))
+	for _, e := range events {
+		result = append(result, ToOutboxEventResponse(e))
+	}
+	return result
+}
@@ -0,0 +1,7 @@
+package dto
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types" validate:"dive,oneof=bill.generated payment.recorded meal.cancelled"`
+}
@@ -0,0 +1,124 @@
+package rest
+
+import (
+	"strconv"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/outbox/dto"
+	"github.com/ePSA-eJya/Mess_Management/internal/outbox/usecase"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/ePSA-eJya/Mess_Management/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+)
+
+type HttpOutboxHandler struct {
+	outboxUseCase usecase.OutboxUseCase
+}
+
+func NewHttpOutboxHandler(useCase usecase.OutboxUseCase) *HttpOutboxHandler {
+	return &HttpOutboxHandler{outboxUseCase: useCase}
+}
+
+// RegisterWebhook godoc
+// @Summary Register an outbound webhook for domain events
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook body dto.CreateWebhookRequest true "Webhook payload"
+// @Success 201 {object} dto.CreatedWebhookResponse
+// @Router /webhooks [post]
+func (h *HttpOutboxHandler) RegisterWebhook(c *fiber.Ctx) error {
+	var req dto.CreateWebhookRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return responses.Error(c, err)
+	}
+
+	webhook := dto.ToWebhookEntity(&req)
+	if err := h.outboxUseCase.RegisterWebhook(webhook); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.CreatedWebhookResponse{
+		WebhookResponse: *dto.ToWebhookResponse(webhook),
+		Secret:          webhook.Secret,
+	})
+}
+
+// FindWebhooks godoc
+// @Summary List registered webhooks
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} dto.WebhookResponse
+// @Router /webhooks [get]
+func (h *HttpOutboxHandler) FindWebhooks(c *fiber.Ctx) error {
+	webhooks, err := h.outboxUseCase.FindWebhooks()
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToWebhookResponseList(webhooks))
+}
+
+// DeleteWebhook godoc
+// @Summary Remove a webhook
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} responses.MessageResponse
+// @Router /webhooks/{id} [delete]
+func (h *HttpOutboxHandler) DeleteWebhook(c *fiber.Ctx) error {
+	id, err := parseID(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	if err := h.outboxUseCase.DeleteWebhook(id); err != nil {
+		return responses.Error(c, err)
+	}
+
+	return responses.Message(c, fiber.StatusOK, "webhook deleted")
+}
+
+// FindDeadLetters godoc
+// @Summary List events that exhausted their delivery attempts
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} dto.OutboxEventResponse
+// @Router /webhooks/dead-letters [get]
+func (h *HttpOutboxHandler) FindDeadLetters(c *fiber.Ctx) error {
+	events, err := h.outboxUseCase.FindDeadLetters()
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToOutboxEventResponseList(events))
+}
+
+// RetryEvent godoc
+// @Summary Requeue a dead-lettered event
+// @Tags webhooks
+// @Produce json
+// @Param id path int true "Event ID"
+// @Success 200 {object} dto.OutboxEventResponse
+// @Router /webhooks/dead-letters/{id}/retry [post]
+func (h *HttpOutboxHandler) RetryEvent(c *fiber.Ctx) error {
+	id, err := parseID(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	event, err := h.outboxUseCase.RetryEvent(id)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToOutboxEventResponse(event))
+}
+
+func parseID(c *fiber.Ctx) (uint, error) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || id == 0 {
+		return 0, apperror.ErrInvalidID
+	}
+	return uint(id), nil
+}
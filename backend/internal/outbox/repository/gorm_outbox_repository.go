@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormOutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewGormOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &GormOutboxRepository{db: db}
+}
+
+// Enqueue writes events through tx, so they are committed or rolled back
+// together with the change that raised them
+func Enqueue(tx *gorm.DB, events ...*entities.OutboxEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return tx.Create(events).Error
+}
+
+func (r *GormOutboxRepository) ClaimDue(now, leaseUntil time.Time, limit int) ([]*entities.OutboxEvent, error) {
+	var eventValues []entities.OutboxEvent
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// rows another dispatcher is claiming right now are skipped rather than waited for
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", entities.OutboxPending, now).
+			Order("id").
+			Limit(limit).
+			Find(&eventValues).Error
+		if err != nil || len(eventValues) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(eventValues))
+		for i := range eventValues {
+			ids[i] = eventValues[i].ID
+			eventValues[i].NextAttemptAt = leaseUntil
+		}
+		return tx.Model(&entities.OutboxEvent{}).Where("id IN ?", ids).Update("next_attempt_at", leaseUntil).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toEventPointers(eventValues), nil
+}
+
+func (r *GormOutboxRepository) Release(event *entities.OutboxEvent, leasedUntil time.Time) (bool, error) {
+	result := r.db.Model(event).
+		Where("status = ? AND next_attempt_at = ?", entities.OutboxPending, leasedUntil).
+		Select("status", "attempts", "next_attempt_at", "last_error", "delivered_at").
+		Updates(event)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *GormOutboxRepository) FindByID(id uint) (*entities.OutboxEvent, error) {
+	var event entities.OutboxEvent
+	if err := r.db.First(&event, id).Error; err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *GormOutboxRepository) FindByStatus(status entities.OutboxStatus, limit int) ([]*entities.OutboxEvent, error) {
+	var eventValues []entities.OutboxEvent
+	if err := r.db.Where("status = ?", status).Order("id DESC").Limit(limit).Find(&eventValues).Error; err != nil {
+		return nil, err
+	}
+	return toEventPointers(eventValues), nil
+}
+
+func (r *GormOutboxRepository) Update(event *entities.OutboxEvent) error {
+	result := r.db.Save(event)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *GormOutboxRepository) FindDeliveredSubscribers(eventID uint) ([]string, error) {
+	var subscribers []string
+	err := r.db.Model(&entities.OutboxDelivery{}).Where("event_id = ?", eventID).Pluck("subscriber", &subscribers).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscribers, nil
+}
+
+func (r *GormOutboxRepository) SaveDelivery(delivery *entities.OutboxDelivery) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(delivery).Error
+}
+
+func (r *GormOutboxRepository) SaveWebhook(webhook *entities.WebhookSubscription) error {
+	return r.db.Create(webhook).Error
+}
+
+func (r *GormOutboxRepository) FindWebhooks(activeOnly bool) ([]*entities.WebhookSubscription, error) {
+	query := r.db.Model(&entities.WebhookSubscription{})
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+
+	var webhookValues []entities.WebhookSubscription
+	if err := query.Order("id").Find(&webhookValues).Error; err != nil {
+		return nil, err
+	}
+	webhooks := make([]*entities.WebhookSubscription, len(webhookValues))
+	for i := range webhookValues {
+		webhooks[i] = &webhookValues[i]
+	}
+	return webhooks, nil
+}
+
+func (r *GormOutboxRepository) DeleteWebhook(id uint) error {
+	result := r.db.Delete(&entities.WebhookSubscription{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func toEventPointers(values []entities.OutboxEvent) []*entities.OutboxEvent {
+	events := make([]*entities.OutboxEvent, len(values))
+	for i := range values {
+		events[i] = &values[i]
+	}
+	return events
+}
@@ -0,0 +1,113 @@
+package repository_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/internal/outbox/repository"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type OutboxRepositoryTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	repo    repository.OutboxRepository
+	cleanup func()
+}
+
+func (s *OutboxRepositoryTestSuite) SetupTest() {
+	s.db, s.cleanup = database.SetupTestDB(s.T())
+	s.repo = repository.NewGormOutboxRepository(s.db)
+}
+
+func (s *OutboxRepositoryTestSuite) TearDownTest() {
+	if s.cleanup != nil {
+		s.cleanup()
+	}
+}
+
+func TestOutboxRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(OutboxRepositoryTestSuite))
+}
+
+func (s *OutboxRepositoryTestSuite) enqueue(count int) {
+	for i := 0; i < count; i++ {
+		event, err := entities.NewOutboxEvent(entities.EventTypeBillGenerated, "monthly_bill", "bill", map[string]any{"n": i})
+		s.Require().NoError(err)
+		event.NextAttemptAt = time.Now().Add(-time.Minute)
+		s.Require().NoError(repository.Enqueue(s.db, event))
+	}
+}
+
+func (s *OutboxRepositoryTestSuite) TestClaimDue_ConcurrentClaimsDoNotOverlap() {
+	s.enqueue(10)
+	now := time.Now()
+	leaseUntil := now.Add(time.Minute).Truncate(time.Microsecond)
+
+	var wg sync.WaitGroup
+	claims := make([][]*entities.OutboxEvent, 4)
+	for i := range claims {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			events, err := s.repo.ClaimDue(now, leaseUntil, 3)
+			s.NoError(err)
+			claims[i] = events
+		}()
+	}
+	wg.Wait()
+
+	seen := map[uint]bool{}
+	for _, events := range claims {
+		for _, event := range events {
+			s.False(seen[event.ID], "event %d claimed twice", event.ID)
+			seen[event.ID] = true
+		}
+	}
+	s.Len(seen, 10)
+
+	// leased events are not due again until the lease runs out
+	events, err := s.repo.ClaimDue(now, leaseUntil, 10)
+	s.NoError(err)
+	s.Empty(events)
+	events, err = s.repo.ClaimDue(leaseUntil, leaseUntil.Add(time.Minute), 10)
+	s.NoError(err)
+	s.Len(events, 10)
+}
+
+func (s *OutboxRepositoryTestSuite) TestRelease_OnlyWhileLeaseHeld() {
+	s.enqueue(1)
+	now := time.Now()
+	leaseUntil := now.Add(time.Minute).Truncate(time.Microsecond)
+
+	claimed, err := s.repo.ClaimDue(now, leaseUntil, 1)
+	s.Require().NoError(err)
+	s.Require().Len(claimed, 1)
+	stale := *claimed[0]
+
+	// the lease runs out and another dispatcher claims the event
+	reclaimed, err := s.repo.ClaimDue(leaseUntil, leaseUntil.Add(time.Minute), 1)
+	s.Require().NoError(err)
+	s.Require().Len(reclaimed, 1)
+
+	stale.Status = entities.OutboxDelivered
+	stale.Attempts = 1
+	released, err := s.repo.Release(&stale, leaseUntil)
+	s.NoError(err)
+	s.False(released)
+
+	reclaimed[0].Status = entities.OutboxDelivered
+	reclaimed[0].Attempts = 1
+	released, err = s.repo.Release(reclaimed[0], leaseUntil.Add(time.Minute))
+	s.NoError(err)
+	s.True(released)
+
+	event, err := s.repo.FindByID(reclaimed[0].ID)
+	s.NoError(err)
+	s.Equal(entities.OutboxDelivered, event.Status)
+	s.Equal(1, event.Attempts)
+}
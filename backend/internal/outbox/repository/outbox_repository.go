@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+)
+
+type OutboxRepository interface {
+	// ClaimDue leases up to limit events due at now by moving their next attempt
+	// to leaseUntil, so other dispatchers skip them until the lease runs out
+	ClaimDue(now, leaseUntil time.Time, limit int) ([]*entities.OutboxEvent, error)
+	// Release stores the outcome of a claimed event and returns false when the
+	// lease taken until leasedUntil ran out and the event was claimed again
+	Release(event *entities.OutboxEvent, leasedUntil time.Time) (bool, error)
+	FindByID(id uint) (*entities.OutboxEvent, error)
+	FindByStatus(status entities.OutboxStatus, limit int) ([]*entities.OutboxEvent, error)
+	Update(event *entities.OutboxEvent) error
+	FindDeliveredSubscribers(eventID uint) ([]string, error)
+	SaveDelivery(delivery *entities.OutboxDelivery) error
+
+	SaveWebhook(webhook *entities.WebhookSubscription) error
+	FindWebhooks(activeOnly bool) ([]*entities.WebhookSubscription, error)
+	DeleteWebhook(id uint) error
+}
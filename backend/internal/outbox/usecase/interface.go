@@ -0,0 +1,11 @@
+package usecase
+
+import "github.com/ePSA-eJya/Mess_Management/internal/entities"
+
+type OutboxUseCase interface {
+	RegisterWebhook(webhook *entities.WebhookSubscription) error
+	FindWebhooks() ([]*entities.WebhookSubscription, error)
+	DeleteWebhook(id uint) error
+	FindDeadLetters() ([]*entities.OutboxEvent, error)
+	RetryEvent(id uint) (*entities.OutboxEvent, error)
+}
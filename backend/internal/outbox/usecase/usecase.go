@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/internal/outbox/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+)
+
+const deadLetterLimit = 100
+
+var eventTypes = map[string]bool{
+	entities.EventTypeBillGenerated:   true,
+	entities.EventTypePaymentRecorded: true,
+	entities.EventTypeMealCancelled:   true,
+}
+
+// OutboxService
+type OutboxService struct {
+	repo repository.OutboxRepository
+}
+
+// Init OutboxService
+func NewOutboxService(repo repository.OutboxRepository) OutboxUseCase {
+	return &OutboxService{repo: repo}
+}
+
+// OutboxService Methods - 1 register a webhook, a signing secret is generated when none is given
+func (s *OutboxService) RegisterWebhook(webhook *entities.WebhookSubscription) error {
+	target, err := url.Parse(webhook.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return apperror.ErrInvalidFormat
+	}
+
+	types := make([]string, 0)
+	for _, t := range strings.Split(webhook.EventTypes, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		if !eventTypes[t] {
+			return apperror.ErrInvalidData
+		}
+		types = append(types, t)
+	}
+
+	if webhook.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return err
+		}
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+
+	webhook.ID = 0
+	webhook.EventTypes = strings.Join(types, ",")
+	webhook.Active = true
+	return s.repo.SaveWebhook(webhook)
+}
+
+// OutboxService Methods - 2 list webhooks
+func (s *OutboxService) FindWebhooks() ([]*entities.WebhookSubscription, error) {
+	return s.repo.FindWebhooks(false)
+}
+
+// OutboxService Methods - 3 remove a webhook
+func (s *OutboxService) DeleteWebhook(id uint) error {
+	return s.repo.DeleteWebhook(id)
+}
+
+// OutboxService Methods - 4 events that exhausted their delivery attempts
+func (s *OutboxService) FindDeadLetters() ([]*entities.OutboxEvent, error) {
+	return s.repo.FindByStatus(entities.OutboxDead, deadLetterLimit)
+}
+
+// OutboxService Methods - 5 put a dead-lettered event back in the queue,
+// subscribers that already received it are not called again
+func (s *OutboxService) RetryEvent(id uint) (*entities.OutboxEvent, error) {
+	event, err := s.repo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if event.Status != entities.OutboxDead {
+		return nil, apperror.ErrUnprocessable
+	}
+
+	event.Status = entities.OutboxPending
+	event.Attempts = 0
+	event.NextAttemptAt = time.Now()
+	if err := s.repo.Update(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
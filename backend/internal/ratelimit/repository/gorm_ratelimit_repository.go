@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/ratelimit"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormRateLimitRepository keeps token buckets in Postgres and implements
+// ratelimit.Store, so every replica takes from the same buckets
+type GormRateLimitRepository struct {
+	db *gorm.DB
+}
+
+func NewGormRateLimitRepository(db *gorm.DB) RateLimitRepository {
+	return &GormRateLimitRepository{db: db}
+}
+
+// Take locks the bucket row for the duration of the transaction, concurrent
+// requests of the same caller take their tokens one after the other
+func (r *GormRateLimitRepository) Take(key string, policy ratelimit.Policy, now time.Time) (ratelimit.Result, error) {
+	var result ratelimit.Result
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		full := ratelimit.NewBucket(policy, now)
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entities.RateLimitBucket{
+			Key:        key,
+			Tokens:     full.Tokens,
+			RefilledAt: full.RefilledAt,
+			FullAt:     full.RefilledAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		var row entities.RateLimitBucket
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("key = ?", key).First(&row).Error; err != nil {
+			return err
+		}
+
+		bucket := ratelimit.Bucket{Tokens: row.Tokens, RefilledAt: row.RefilledAt}
+		result = bucket.Take(policy, now)
+		return tx.Model(&row).Updates(map[string]interface{}{
+			"tokens":      bucket.Tokens,
+			"refilled_at": bucket.RefilledAt,
+			"full_at":     bucket.FullAt(policy),
+		}).Error
+	})
+	return result, err
+}
+
+func (r *GormRateLimitRepository) DeleteFull(at time.Time) (int64, error) {
+	result := r.db.Where("full_at <= ?", at).Delete(&entities.RateLimitBucket{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,74 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/internal/ratelimit/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/ratelimit"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type RateLimitRepositoryTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	repo    repository.RateLimitRepository
+	cleanup func()
+}
+
+func (s *RateLimitRepositoryTestSuite) SetupTest() {
+	s.db, s.cleanup = database.SetupTestDB(s.T())
+	s.repo = repository.NewGormRateLimitRepository(s.db)
+}
+
+func (s *RateLimitRepositoryTestSuite) TearDownTest() {
+	if s.cleanup != nil {
+		s.cleanup()
+	}
+}
+
+func TestRateLimitRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitRepositoryTestSuite))
+}
+
+func (s *RateLimitRepositoryTestSuite) TestTake() {
+	policy := ratelimit.PerMinute("auth", 2)
+	now := time.Now()
+
+	for remaining := 1; remaining >= 0; remaining-- {
+		result, err := s.repo.Take("auth:ip:10.0.0.1", policy, now)
+		s.Require().NoError(err)
+		s.True(result.Allowed)
+		s.Equal(remaining, result.Remaining)
+	}
+	result, err := s.repo.Take("auth:ip:10.0.0.1", policy, now)
+	s.Require().NoError(err)
+	s.False(result.Allowed)
+	s.Equal(30*time.Second, result.RetryAfter)
+
+	// callers have their own buckets
+	result, err = s.repo.Take("auth:ip:10.0.0.2", policy, now)
+	s.Require().NoError(err)
+	s.True(result.Allowed)
+
+	result, err = s.repo.Take("auth:ip:10.0.0.1", policy, now.Add(30*time.Second))
+	s.Require().NoError(err)
+	s.True(result.Allowed)
+}
+
+func (s *RateLimitRepositoryTestSuite) TestDeleteFull() {
+	policy := ratelimit.PerMinute("auth", 2)
+	now := time.Now()
+	_, err := s.repo.Take("auth:ip:10.0.0.1", policy, now)
+	s.Require().NoError(err)
+
+	deleted, err := s.repo.DeleteFull(now)
+	s.NoError(err)
+	s.Zero(deleted)
+
+	deleted, err = s.repo.DeleteFull(now.Add(30 * time.Second))
+	s.NoError(err)
+	s.Equal(int64(1), deleted)
+}
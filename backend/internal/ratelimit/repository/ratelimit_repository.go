@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/ratelimit"
+)
+
+type RateLimitRepository interface {
+	ratelimit.Store
+	// DeleteFull removes buckets that are full again at the given time, a
+	// missing bucket behaves the same
+	DeleteFull(at time.Time) (int64, error)
+}
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+)
+
+// From entity.Student to StudentResponse
+func ToStudentResponse(student *entities.Student) *StudentResponse {
+	response := &StudentResponse{
+		Roll:   student.Roll,
+		Name:   student.Name,
+		Hostel: student.Hostel,
+		RoomNo: student.RoomNo,
+		MessNo: student.MessNo,
+		Phone:  student.Phone,
+		Email:  student.Email,
+		Status: student.Status,
+	}
+	if student.DeletedAt.Valid {
+		response.DeletedAt = &student.DeletedAt.Time
+	}
+	return response
+}
+
+func ToStudentPageResponse(page *pagination.Page[*entities.Student]) *StudentPageResponse {
+	return pagination.Map(page, ToStudentResponse)
+}
@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+)
+
+type StudentResponse struct {
+	Roll   uint                   `json:"roll"`
+	Name   string                 `json:"name"`
+	Hostel string                 `json:"hostel"`
+	RoomNo uint                   `json:"room_no"`
+	MessNo uint                   `json:"mess_no"`
+	Phone  string                 `json:"phone"`
+	Email  string                 `json:"email"`
+	Status entities.StudentStatus `json:"status"`
+
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type StudentPageResponse = pagination.Page[*StudentResponse]
@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/internal/student/usecase"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+	studentpb "github.com/ePSA-eJya/Mess_Management/proto/student"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GrpcStudentHandler struct {
+	studentUseCase usecase.StudentUseCase
+	studentpb.UnimplementedStudentServiceServer
+}
+
+func NewGrpcStudentHandler(uc usecase.StudentUseCase) *GrpcStudentHandler {
+	return &GrpcStudentHandler{studentUseCase: uc}
+}
+
+func (h *GrpcStudentHandler) GetMyStudent(ctx context.Context, req *studentpb.GetMyStudentRequest) (*studentpb.GetMyStudentResponse, error) {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing token")
+	}
+	student, err := h.studentUseCase.FindMyStudent(ctx, claims.UserID)
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	return &studentpb.GetMyStudentResponse{Student: toProtoStudent(student)}, nil
+}
+
+func (h *GrpcStudentHandler) FindStudentByRoll(ctx context.Context, req *studentpb.FindStudentByRollRequest) (*studentpb.FindStudentByRollResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	student, err := h.studentUseCase.FindStudentByRoll(ctx, uint(req.Roll))
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	return &studentpb.FindStudentByRollResponse{Student: toProtoStudent(student)}, nil
+}
+
+func (h *GrpcStudentHandler) FindActiveStudents(ctx context.Context, req *studentpb.FindActiveStudentsRequest) (*studentpb.FindActiveStudentsResponse, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	students, err := h.studentUseCase.FindActiveStudents(ctx)
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+
+	protoStudents := make([]*studentpb.Student, len(students))
+	for i, st := range students {
+		protoStudents[i] = toProtoStudent(st)
+	}
+	return &studentpb.FindActiveStudentsResponse{Students: protoStudents}, nil
+}
+
+func requireAdmin(ctx context.Context) error {
+	return middleware.RequireGRPCRole(ctx, string(entities.RoleOfficeAdmin), string(entities.RoleMessAdmin))
+}
+
+// helper function convert entities.Student to studentpb.Student
+func toProtoStudent(st *entities.Student) *studentpb.Student {
+	return &studentpb.Student{
+		Roll:   uint32(st.Roll),
+		Name:   st.Name,
+		Hostel: st.Hostel,
+		RoomNo: uint32(st.RoomNo),
+		MessNo: uint32(st.MessNo),
+		Phone:  st.Phone,
+		Email:  st.Email,
+		Status: string(st.Status),
+	}
+}
@@ -0,0 +1,75 @@
+package rest
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/internal/student/dto"
+	"github.com/ePSA-eJya/Mess_Management/internal/student/usecase"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/gofiber/fiber/v2"
+)
+
+type HttpStudentHandler struct {
+	studentUseCase usecase.StudentUseCase
+}
+
+func NewHttpStudentHandler(useCase usecase.StudentUseCase) *HttpStudentHandler {
+	return &HttpStudentHandler{studentUseCase: useCase}
+}
+
+// FindDeletedStudents godoc
+// @Summary Get a page of students deleted within the retention window
+// @Tags students
+// @Produce json
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Page size, at most 100"
+// @Param sort query string false "roll or name, prefix with - for descending"
+// @Param filter[mess_no] query int false "Only students of this mess"
+// @Param filter[hostel] query string false "Only students of this hostel"
+// @Param filter[email] query string false "Only the student with this email"
+// @Success 200 {object} dto.StudentPageResponse
+// @Router /students/deleted [get]
+func (h *HttpStudentHandler) FindDeletedStudents(c *fiber.Ctx) error {
+	query, err := pagination.Parse(c.Queries())
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	page, err := h.studentUseCase.FindDeletedStudents(c.UserContext(), query)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToStudentPageResponse(page))
+}
+
+// RestoreStudent godoc
+// @Summary Restore a student deleted within the retention window
+// @Tags students
+// @Produce json
+// @Param roll path int true "Roll number"
+// @Success 200 {object} dto.StudentResponse
+// @Router /students/{roll}/restore [post]
+func (h *HttpStudentHandler) RestoreStudent(c *fiber.Ctx) error {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return responses.Error(c, apperror.ErrUnauthorized)
+	}
+
+	roll, err := strconv.ParseUint(c.Params("roll"), 10, 64)
+	if err != nil {
+		return responses.ErrorWithMessage(c, apperror.ErrInvalidID, "invalid roll")
+	}
+
+	actor := entities.AuditActor{UserID: fmt.Sprint(userID), IP: c.IP()}
+	student, err := h.studentUseCase.RestoreStudent(c.UserContext(), actor, uint(roll))
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToStudentResponse(student))
+}
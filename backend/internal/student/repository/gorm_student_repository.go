@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	auditRepository "github.com/ePSA-eJya/Mess_Management/internal/audit/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GormStudentRepository struct {
+	db *gorm.DB
+}
+
+func NewGormStudentRepository(db *gorm.DB) StudentRepository {
+	return &GormStudentRepository{db: db}
+}
+
+func (r *GormStudentRepository) FindActive(ctx context.Context) ([]*entities.Student, error) {
+	var studentValues []entities.Student
+	if err := r.db.WithContext(ctx).Where("status = ?", entities.Active).Order("roll").Find(&studentValues).Error; err != nil {
+		return nil, err
+	}
+	students := make([]*entities.Student, len(studentValues))
+	for i := range studentValues {
+		students[i] = &studentValues[i]
+	}
+	return students, nil
+}
+
+func (r *GormStudentRepository) FindByRoll(ctx context.Context, roll uint) (*entities.Student, error) {
+	var student entities.Student
+	if err := r.db.WithContext(ctx).First(&student, roll).Error; err != nil {
+		return nil, err
+	}
+	return &student, nil
+}
+
+func (r *GormStudentRepository) FindByEmail(ctx context.Context, email string) (*entities.Student, error) {
+	var student entities.Student
+	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&student).Error; err != nil {
+		return nil, err
+	}
+	return &student, nil
+}
+
+func (r *GormStudentRepository) FindDeleted(ctx context.Context, query pagination.Query, since time.Time) (*pagination.Page[*entities.Student], error) {
+	return pagination.Find[entities.Student](r.db.WithContext(ctx).Unscoped().Where("deleted_at > ?", since), query, StudentFields)
+}
+
+func (r *GormStudentRepository) FindDeletedByRoll(ctx context.Context, roll uint, since time.Time) (*entities.Student, error) {
+	var student entities.Student
+	if err := r.db.WithContext(ctx).Unscoped().Where("deleted_at > ?", since).First(&student, roll).Error; err != nil {
+		return nil, err
+	}
+	return &student, nil
+}
+
+// Restore undeletes the student and appends audit in the same transaction
+func (r *GormStudentRepository) Restore(ctx context.Context, roll uint, since time.Time, audit *entities.AuditLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&entities.Student{}).
+			Where("roll = ? AND deleted_at > ?", roll, since).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return auditRepository.Append(tx, audit)
+	})
+}
+
+func (r *GormStudentRepository) Purge(before time.Time) (int64, error) {
+	result := r.db.Unscoped().
+		Where("deleted_at < ?", before).
+		Where("NOT (?)", HasBillingHistory(r.db, "students.roll")).
+		Delete(&entities.Student{})
+	return result.RowsAffected, result.Error
+}
+
+// HasBillingHistory holds when the student whose roll is in the column roll of
+// the outer query has bills or payments, which keeps them from being purged
+func HasBillingHistory(db *gorm.DB, roll string) clause.Expr {
+	bills := db.Model(&entities.MonthlyBill{}).Select("1").Where("monthly_bills.roll = " + roll)
+	payments := db.Model(&entities.Payment{}).Select("1").Where("payments.roll = " + roll)
+	return gorm.Expr("EXISTS (?) OR EXISTS (?)", bills, payments)
+}
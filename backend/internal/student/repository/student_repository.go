@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+)
+
+// StudentFields are the fields students can be listed by
+var StudentFields = pagination.Fields{
+	Filter:      map[string]string{"mess_no": "mess_no", "hostel": "hostel", "email": "email"},
+	Sort:        map[string]string{"roll": "roll", "name": "name"},
+	DefaultSort: "roll",
+}
+
+// StudentRepository runs each query within ctx. Purge is left without one,
+// it belongs to the retention job rather than a request.
+type StudentRepository interface {
+	FindActive(ctx context.Context) ([]*entities.Student, error)
+	FindByRoll(ctx context.Context, roll uint) (*entities.Student, error)
+	FindByEmail(ctx context.Context, email string) (*entities.Student, error)
+
+	// deleted students, only those deleted after since can be found and restored
+	FindDeleted(ctx context.Context, query pagination.Query, since time.Time) (*pagination.Page[*entities.Student], error)
+	FindDeletedByRoll(ctx context.Context, roll uint, since time.Time) (*entities.Student, error)
+	Restore(ctx context.Context, roll uint, since time.Time, audit *entities.AuditLog) error
+	// Purge removes students deleted before before for good, except those
+	// still referenced by bills or payments
+	Purge(before time.Time) (int64, error)
+}
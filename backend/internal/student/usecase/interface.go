@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+)
+
+type StudentUseCase interface {
+	FindMyStudent(ctx context.Context, userID string) (*entities.Student, error)
+	FindStudentByRoll(ctx context.Context, roll uint) (*entities.Student, error)
+	FindActiveStudents(ctx context.Context) ([]*entities.Student, error)
+	FindDeletedStudents(ctx context.Context, query pagination.Query) (*pagination.Page[*entities.Student], error)
+	RestoreStudent(ctx context.Context, actor entities.AuditActor, roll uint) (*entities.Student, error)
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/internal/student/repository"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"github.com/ePSA-eJya/Mess_Management/pkg/tracing"
+	"gorm.io/gorm"
+)
+
+// StudentService
+type StudentService struct {
+	repo     repository.StudentRepository
+	userRepo userRepository.UserRepository
+	// retention is how long a deleted student can be restored
+	retention time.Duration
+}
+
+// Init StudentService
+func NewStudentService(repo repository.StudentRepository, userRepo userRepository.UserRepository, retention time.Duration) StudentUseCase {
+	return &StudentService{repo: repo, userRepo: userRepo, retention: retention}
+}
+
+// StudentService Methods - 1 the student record linked to a user by email
+func (s *StudentService) FindMyStudent(ctx context.Context, userID string) (_ *entities.Student, err error) {
+	ctx, span := tracing.Start(ctx, "StudentService.FindMyStudent")
+	defer func() { tracing.End(span, err) }()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, apperror.ErrUnauthorized
+	}
+	return s.repo.FindByEmail(ctx, user.Email)
+}
+
+// StudentService Methods - 2 find by roll number
+func (s *StudentService) FindStudentByRoll(ctx context.Context, roll uint) (_ *entities.Student, err error) {
+	ctx, span := tracing.Start(ctx, "StudentService.FindStudentByRoll")
+	defer func() { tracing.End(span, err) }()
+
+	if roll == 0 {
+		return nil, apperror.ErrInvalidID
+	}
+	return s.repo.FindByRoll(ctx, roll)
+}
+
+// StudentService Methods - 3 students currently on the mess roll
+func (s *StudentService) FindActiveStudents(ctx context.Context) (_ []*entities.Student, err error) {
+	ctx, span := tracing.Start(ctx, "StudentService.FindActiveStudents")
+	defer func() { tracing.End(span, err) }()
+
+	return s.repo.FindActive(ctx)
+}
+
+// StudentService Methods - 4 find a page of students deleted within the retention window
+func (s *StudentService) FindDeletedStudents(ctx context.Context, query pagination.Query) (_ *pagination.Page[*entities.Student], err error) {
+	ctx, span := tracing.Start(ctx, "StudentService.FindDeletedStudents")
+	defer func() { tracing.End(span, err) }()
+
+	return s.repo.FindDeleted(ctx, query, time.Now().Add(-s.retention))
+}
+
+// StudentService Methods - 5 restore a student deleted within the retention window
+func (s *StudentService) RestoreStudent(ctx context.Context, actor entities.AuditActor, roll uint) (_ *entities.Student, err error) {
+	ctx, span := tracing.Start(ctx, "StudentService.RestoreStudent")
+	defer func() { tracing.End(span, err) }()
+
+	if roll == 0 {
+		return nil, apperror.ErrInvalidID
+	}
+	since := time.Now().Add(-s.retention)
+	before, err := s.repo.FindDeletedByRoll(ctx, roll, since)
+	if err != nil {
+		return nil, err
+	}
+
+	after := *before
+	after.DeletedAt = gorm.DeletedAt{}
+	audit, err := entities.NewAuditLog(actor, entities.AuditStudentRestored, "student", strconv.FormatUint(uint64(roll), 10), before, &after)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Restore(ctx, roll, since, audit); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByRoll(ctx, roll)
+}
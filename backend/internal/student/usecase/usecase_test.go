@@ -0,0 +1,112 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/internal/student/repository"
+	"github.com/ePSA-eJya/Mess_Management/internal/student/usecase"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+var ctx = context.Background()
+
+const retention = 30 * 24 * time.Hour
+
+type StudentUseCaseTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	service usecase.StudentUseCase
+	user    *entities.User
+	cleanup func()
+}
+
+func (s *StudentUseCaseTestSuite) SetupTest() {
+	s.db, s.cleanup = database.SetupTestDB(s.T())
+
+	userRepo := userRepository.NewGormUserRepository(s.db)
+	s.service = usecase.NewStudentService(repository.NewGormStudentRepository(s.db), userRepo, retention)
+
+	s.Require().NoError(s.db.Create(&entities.Student{
+		Roll: 7, Name: "Student", Hostel: "H1", RoomNo: 101, MessNo: 1, Email: "student@example.com",
+	}).Error)
+	s.Require().NoError(s.db.Create(&entities.Student{
+		Roll: 8, Name: "Former", Hostel: "H1", RoomNo: 102, MessNo: 1, Email: "former@example.com", Status: entities.Inactive,
+	}).Error)
+	s.user = &entities.User{Email: "student@example.com", Password: "password123", Name: "Student"}
+	s.Require().NoError(userRepo.Save(ctx, s.user, nil))
+}
+
+func (s *StudentUseCaseTestSuite) TearDownTest() {
+	if s.cleanup != nil {
+		s.cleanup()
+	}
+}
+
+func TestStudentUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(StudentUseCaseTestSuite))
+}
+
+func (s *StudentUseCaseTestSuite) TestFindMyStudent() {
+	student, err := s.service.FindMyStudent(ctx, s.user.ID.String())
+	s.NoError(err)
+	s.Equal(uint(7), student.Roll)
+
+	_, err = s.service.FindMyStudent(ctx, "9a176ca5-f3e0-4994-869c-fac0e8c9d5dc")
+	s.ErrorIs(err, apperror.ErrUnauthorized)
+}
+
+func (s *StudentUseCaseTestSuite) TestFindStudentByRoll() {
+	student, err := s.service.FindStudentByRoll(ctx, 8)
+	s.NoError(err)
+	s.Equal("Former", student.Name)
+
+	_, err = s.service.FindStudentByRoll(ctx, 0)
+	s.ErrorIs(err, apperror.ErrInvalidID)
+	_, err = s.service.FindStudentByRoll(ctx, 99)
+	s.ErrorIs(err, apperror.ErrRecordNotFound)
+}
+
+func (s *StudentUseCaseTestSuite) TestFindActiveStudents() {
+	students, err := s.service.FindActiveStudents(ctx)
+	s.NoError(err)
+	s.Len(students, 1)
+	s.Equal(uint(7), students[0].Roll)
+}
+
+func (s *StudentUseCaseTestSuite) TestRestoreStudent() {
+	s.Require().NoError(s.db.Delete(&entities.Student{}, 8).Error)
+	admin := entities.AuditActor{UserID: "9a176ca5-f3e0-4994-869c-fac0e8c9d5dc"}
+
+	deleted, err := s.service.FindDeletedStudents(ctx, pagination.Query{})
+	s.NoError(err)
+	s.Require().Len(deleted.Items, 1)
+	s.Equal(uint(8), deleted.Items[0].Roll)
+
+	restored, err := s.service.RestoreStudent(ctx, admin, 8)
+	s.NoError(err)
+	s.False(restored.DeletedAt.Valid)
+
+	var audit entities.AuditLog
+	s.NoError(s.db.Where("action = ?", entities.AuditStudentRestored).First(&audit).Error)
+	s.Equal("8", audit.EntityID)
+	s.Contains(audit.Changes, "deleted_at")
+}
+
+func (s *StudentUseCaseTestSuite) TestRestoreStudent_AfterRetention() {
+	s.Require().NoError(s.db.Unscoped().Model(&entities.Student{}).Where("roll = ?", 8).
+		Update("deleted_at", time.Now().Add(-retention-time.Hour)).Error)
+
+	deleted, err := s.service.FindDeletedStudents(ctx, pagination.Query{})
+	s.NoError(err)
+	s.Empty(deleted.Items)
+	_, err = s.service.RestoreStudent(ctx, entities.SystemActor, 8)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
@@ -1,22 +1,28 @@
 package dto
 
-import "github.com/ePSA-eJya/Mess_Management/internal/entities"
+import (
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+)
 
 // From entity.User to UserResponse
 func ToUserResponse(user *entities.User) *UserResponse {
-	return &UserResponse{
+	response := &UserResponse{
 		ID:    user.ID,
 		Email: user.Email,
 		Name:  user.Name,
+
+		EmailVerified: user.EmailVerifiedAt != nil,
+		Version:       user.Version,
+	}
+	if user.DeletedAt.Valid {
+		response.DeletedAt = &user.DeletedAt.Time
 	}
+	return response
 }
 
-func ToUserResponseList(users []*entities.User) []*UserResponse {
-	responses := make([]*UserResponse, len(users))
-	for i, u := range users {
-		responses[i] = ToUserResponse(u)
-	}
-	return responses
+func ToUserPageResponse(page *pagination.Page[*entities.User]) *UserPageResponse {
+	return pagination.Map(page, ToUserResponse)
 }
 
 // From RegisterRequest to entity.User (optional, if want to use in usecase)
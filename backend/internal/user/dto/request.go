@@ -6,11 +6,6 @@ type RegisterRequest struct {
 	Name     string `json:"name" validate:"required"`
 }
 
-type LoginRequest struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required"`
-}
-
 type PatchUserRequest struct {
 	Name string `json:"name" validate:"required"`
 }
@@ -1,9 +1,20 @@
 package dto
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"github.com/google/uuid"
+)
 
 type UserResponse struct {
 	ID    uuid.UUID `json:"id"`
 	Email string    `json:"email"`
 	Name  string    `json:"name"`
+
+	EmailVerified bool       `json:"email_verified"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+	Version       uint       `json:"version"` // also sent as the ETag header
 }
+
+type UserPageResponse = pagination.Page[*UserResponse]
@@ -0,0 +1,153 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/internal/user/dto"
+	"github.com/ePSA-eJya/Mess_Management/internal/user/usecase"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"github.com/ePSA-eJya/Mess_Management/pkg/validation"
+	userpb "github.com/ePSA-eJya/Mess_Management/proto/user"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GrpcUserHandler struct {
+	userUseCase usecase.UserUseCase
+	userpb.UnimplementedUserServiceServer
+}
+
+func NewGrpcUserHandler(uc usecase.UserUseCase) *GrpcUserHandler {
+	return &GrpcUserHandler{userUseCase: uc}
+}
+
+func (h *GrpcUserHandler) GetMe(ctx context.Context, req *userpb.GetMeRequest) (*userpb.GetMeResponse, error) {
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := h.userUseCase.FindUserByID(ctx, actor, actor.UserID)
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	return &userpb.GetMeResponse{User: toProtoUser(user)}, nil
+}
+
+func (h *GrpcUserHandler) FindUserByID(ctx context.Context, req *userpb.FindUserByIDRequest) (*userpb.FindUserByIDResponse, error) {
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := h.userUseCase.FindUserByID(ctx, actor, req.Id)
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	return &userpb.FindUserByIDResponse{User: toProtoUser(user)}, nil
+}
+
+func (h *GrpcUserHandler) FindAllUsers(ctx context.Context, req *userpb.FindAllUsersRequest) (*userpb.FindAllUsersResponse, error) {
+	if err := middleware.RequireGRPCRole(ctx, string(entities.RoleOfficeAdmin), string(entities.RoleMessAdmin)); err != nil {
+		return nil, err
+	}
+	query := pagination.Query{Cursor: req.Cursor, Limit: int(req.Limit), Sort: req.Sort, Filter: req.Filter}
+	page, err := h.userUseCase.FindAllUsers(ctx, query)
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+
+	return toProtoUserPage(page), nil
+}
+
+func (h *GrpcUserHandler) PatchUser(ctx context.Context, req *userpb.PatchUserRequest) (*userpb.PatchUserResponse, error) {
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.Struct(&dto.PatchUserRequest{Name: req.Name}); err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	user, err := h.userUseCase.PatchUser(ctx, actor, req.Id, &entities.User{Name: req.Name}, uint(req.Version))
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	return &userpb.PatchUserResponse{User: toProtoUser(user)}, nil
+}
+
+func (h *GrpcUserHandler) DeleteUser(ctx context.Context, req *userpb.DeleteUserRequest) (*userpb.DeleteUserResponse, error) {
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.userUseCase.DeleteUser(ctx, actor, req.Id); err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	return &userpb.DeleteUserResponse{Message: "user deleted"}, nil
+}
+
+func (h *GrpcUserHandler) FindDeletedUsers(ctx context.Context, req *userpb.FindAllUsersRequest) (*userpb.FindAllUsersResponse, error) {
+	if err := middleware.RequireGRPCRole(ctx, string(entities.RoleOfficeAdmin)); err != nil {
+		return nil, err
+	}
+	query := pagination.Query{Cursor: req.Cursor, Limit: int(req.Limit), Sort: req.Sort, Filter: req.Filter}
+	page, err := h.userUseCase.FindDeletedUsers(ctx, query)
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	return toProtoUserPage(page), nil
+}
+
+func (h *GrpcUserHandler) RestoreUser(ctx context.Context, req *userpb.RestoreUserRequest) (*userpb.RestoreUserResponse, error) {
+	if err := middleware.RequireGRPCRole(ctx, string(entities.RoleOfficeAdmin)); err != nil {
+		return nil, err
+	}
+	actor, err := actorFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := h.userUseCase.RestoreUser(ctx, actor, req.Id)
+	if err != nil {
+		return nil, apperror.GRPCError(err)
+	}
+	return &userpb.RestoreUserResponse{User: toProtoUser(user)}, nil
+}
+
+// actorFromContext reads the caller authenticated by middleware.GRPCAuthInterceptor
+func actorFromContext(ctx context.Context) (usecase.Actor, error) {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		return usecase.Actor{}, status.Error(codes.Unauthenticated, "missing token")
+	}
+	return usecase.Actor{
+		UserID: claims.UserID,
+		Role:   entities.Role(claims.Role),
+		IP:     middleware.ClientIPFromContext(ctx),
+	}, nil
+}
+
+// helper function convert entities.User to userpb.User
+func toProtoUser(u *entities.User) *userpb.User {
+	protoUser := &userpb.User{
+		Id:            u.ID.String(),
+		Email:         u.Email,
+		Name:          u.Name,
+		Role:          string(u.Role),
+		EmailVerified: u.EmailVerifiedAt != nil,
+		Version:       uint32(u.Version),
+	}
+	if u.DeletedAt.Valid {
+		protoUser.DeletedAt = u.DeletedAt.Time.Format(time.RFC3339)
+	}
+	return protoUser
+}
+
+func toProtoUserPage(page *pagination.Page[*entities.User]) *userpb.FindAllUsersResponse {
+	protoUsers := make([]*userpb.User, len(page.Items))
+	for i, u := range page.Items {
+		protoUsers[i] = toProtoUser(u)
+	}
+	return &userpb.FindAllUsersResponse{Items: protoUsers, NextCursor: page.NextCursor, Total: int32(page.Total)}
+}
@@ -7,7 +7,10 @@ import (
 	"github.com/ePSA-eJya/Mess_Management/internal/user/dto"
 	"github.com/ePSA-eJya/Mess_Management/internal/user/usecase"
 	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/etag"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
 	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/ePSA-eJya/Mess_Management/pkg/validation"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -19,53 +22,6 @@ func NewHttpUserHandler(useCase usecase.UserUseCase) *HttpUserHandler {
 	return &HttpUserHandler{userUseCase: useCase}
 }
 
-// Register godoc
-// @Summary Register a new user
-// @Tags users
-// @Accept json
-// @Produce json
-// @Param user body entities.User true "User registration payload"
-// @Success 201 {object} entities.User
-// @Router /auth/signup [post]
-func (h *HttpUserHandler) Register(c *fiber.Ctx) error {
-	req := new(dto.RegisterRequest)
-	if err := c.BodyParser(req); err != nil {
-		return responses.Error(c, apperror.ErrInvalidData)
-	}
-
-	userEntity := dto.ToUserEntity(req)
-	if err := h.userUseCase.Register(userEntity); err != nil {
-		return responses.Error(c, err)
-	}
-
-	return c.Status(fiber.StatusCreated).JSON(dto.ToUserResponse(userEntity))
-}
-
-// Login godoc
-// @Summary Authenticate user and return token
-// @Tags users
-// @Accept json
-// @Produce json
-// @Param credentials body map[string]string true "Login credentials (email & password)"
-// @Success 200 {object} map[string]interface{} "Authenticated user and JWT token"
-// @Router /auth/signin [post]
-func (h *HttpUserHandler) Login(c *fiber.Ctx) error {
-	loginReq := new(dto.LoginRequest)
-	if err := c.BodyParser(loginReq); err != nil {
-		return responses.Error(c, apperror.ErrInvalidData)
-	}
-
-	token, userEntity, err := h.userUseCase.Login(loginReq.Email, loginReq.Password)
-	if err != nil {
-		return responses.ErrorWithMessage(c, apperror.ErrUnauthorized, "invalid email or password")
-	}
-
-	return c.JSON(fiber.Map{
-		"user":  dto.ToUserResponse(userEntity),
-		"token": token,
-	})
-}
-
 // GetUser godoc
 // @Summary Get currently authenticated user
 // @Tags users
@@ -73,16 +29,17 @@ func (h *HttpUserHandler) Login(c *fiber.Ctx) error {
 // @Success 200 {object} entities.User
 // @Router /users/me [get]
 func (h *HttpUserHandler) GetUser(c *fiber.Ctx) error {
-	userID := c.Locals("user_id")
-	if userID == nil {
-		return responses.Error(c, apperror.ErrInvalidData)
+	actor, err := actorFromCtx(c)
+	if err != nil {
+		return responses.Error(c, err)
 	}
 
-	userEntity, err := h.userUseCase.FindUserByID(fmt.Sprint(userID))
+	userEntity, err := h.userUseCase.FindUserByID(c.UserContext(), actor, actor.UserID)
 	if err != nil {
 		return responses.Error(c, err)
 	}
 
+	c.Set(fiber.HeaderETag, etag.Format(userEntity.Version))
 	return c.JSON(dto.ToUserResponse(userEntity))
 }
 
@@ -94,32 +51,48 @@ func (h *HttpUserHandler) GetUser(c *fiber.Ctx) error {
 // @Success 200 {object} entities.User
 // @Router /users/{id} [get]
 func (h *HttpUserHandler) FindUserByID(c *fiber.Ctx) error {
+	actor, err := actorFromCtx(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
 	id := c.Params("id")
 	if id == "" {
 		return responses.ErrorWithMessage(c, apperror.ErrInvalidData, "id is required")
 	}
 
-	userEntity, err := h.userUseCase.FindUserByID(id)
+	userEntity, err := h.userUseCase.FindUserByID(c.UserContext(), actor, id)
 	if err != nil {
 		return responses.Error(c, err)
 	}
 
+	c.Set(fiber.HeaderETag, etag.Format(userEntity.Version))
 	return c.JSON(dto.ToUserResponse(userEntity))
 }
 
 // FindAllUsers godoc
-// @Summary Get all users
+// @Summary Get a page of users
 // @Tags users
 // @Produce json
-// @Success 200 {array} entities.User
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Page size, at most 100"
+// @Param sort query string false "email or name, prefix with - for descending"
+// @Param filter[role] query string false "Only users with this role"
+// @Param filter[email] query string false "Only the user with this email"
+// @Success 200 {object} dto.UserPageResponse
 // @Router /users [get]
 func (h *HttpUserHandler) FindAllUsers(c *fiber.Ctx) error {
-	users, err := h.userUseCase.FindAllUsers()
+	query, err := pagination.Parse(c.Queries())
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	page, err := h.userUseCase.FindAllUsers(c.UserContext(), query)
 	if err != nil {
 		return responses.Error(c, err)
 	}
 
-	return c.JSON(dto.ToUserResponseList(users))
+	return c.JSON(dto.ToUserPageResponse(page))
 }
 
 // PatchUser godoc
@@ -128,29 +101,33 @@ func (h *HttpUserHandler) FindAllUsers(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param id path int true "User ID"
+// @Param If-Match header string true "ETag of the user the update is based on"
 // @Param user body entities.User true "User update payload"
 // @Success 200 {object} entities.User
 // @Router /users/{id} [patch]
 func (h *HttpUserHandler) PatchUser(c *fiber.Ctx) error {
+	actor, err := actorFromCtx(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
 	id := c.Params("id")
 
-	var req dto.PatchUserRequest
-	if err := c.BodyParser(&req); err != nil {
-		return responses.ErrorWithMessage(c, err, "invalid request")
+	version, err := etag.Parse(c.Get(fiber.HeaderIfMatch))
+	if err != nil {
+		return responses.Error(c, err)
 	}
 
-	user := &entities.User{Name: req.Name}
-
-	msg, err := validatePatchUser(user)
-	if err != nil {
-		return responses.ErrorWithMessage(c, err, msg)
+	var req dto.PatchUserRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return responses.Error(c, err)
 	}
 
-	updatedUser, err := h.userUseCase.PatchUser(id, user)
+	updatedUser, err := h.userUseCase.PatchUser(c.UserContext(), actor, id, &entities.User{Name: req.Name}, version)
 	if err != nil {
 		return responses.Error(c, err)
 	}
 
+	c.Set(fiber.HeaderETag, etag.Format(updatedUser.Version))
 	return c.JSON(dto.ToUserResponse(updatedUser))
 }
 
@@ -162,20 +139,70 @@ func (h *HttpUserHandler) PatchUser(c *fiber.Ctx) error {
 // @Success 200 {object} response.MessageResponse
 // @Router /users/{id} [delete]
 func (h *HttpUserHandler) DeleteUser(c *fiber.Ctx) error {
+	actor, err := actorFromCtx(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
 	id := c.Params("id")
 
-	if err := h.userUseCase.DeleteUser(id); err != nil {
+	if err := h.userUseCase.DeleteUser(c.UserContext(), actor, id); err != nil {
 		return responses.Error(c, err)
 	}
 
 	return responses.Message(c, fiber.StatusOK, "user deleted")
 }
 
-func validatePatchUser(user *entities.User) (string, error) {
+// FindDeletedUsers godoc
+// @Summary Get a page of users deleted within the retention window
+// @Tags users
+// @Produce json
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Page size, at most 100"
+// @Param sort query string false "email or name, prefix with - for descending"
+// @Param filter[role] query string false "Only users with this role"
+// @Param filter[email] query string false "Only the user with this email"
+// @Success 200 {object} dto.UserPageResponse
+// @Router /users/deleted [get]
+func (h *HttpUserHandler) FindDeletedUsers(c *fiber.Ctx) error {
+	query, err := pagination.Parse(c.Queries())
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	page, err := h.userUseCase.FindDeletedUsers(c.UserContext(), query)
+	if err != nil {
+		return responses.Error(c, err)
+	}
+
+	return c.JSON(dto.ToUserPageResponse(page))
+}
+
+// RestoreUser godoc
+// @Summary Restore a user deleted within the retention window
+// @Tags users
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.UserResponse
+// @Router /users/{id}/restore [post]
+func (h *HttpUserHandler) RestoreUser(c *fiber.Ctx) error {
+	actor, err := actorFromCtx(c)
+	if err != nil {
+		return responses.Error(c, err)
+	}
 
-	if user.Name == "" {
-		return "username is invalid", apperror.ErrInvalidData
+	user, err := h.userUseCase.RestoreUser(c.UserContext(), actor, c.Params("id"))
+	if err != nil {
+		return responses.Error(c, err)
 	}
 
-	return "", nil
+	return c.JSON(dto.ToUserResponse(user))
+}
+
+func actorFromCtx(c *fiber.Ctx) (usecase.Actor, error) {
+	userID := c.Locals("user_id")
+	if userID == nil {
+		return usecase.Actor{}, apperror.ErrUnauthorized
+	}
+	role, _ := c.Locals("role").(string)
+	return usecase.Actor{UserID: fmt.Sprint(userID), Role: entities.Role(role), IP: c.IP()}, nil
 }
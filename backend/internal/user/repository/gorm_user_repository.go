@@ -1,9 +1,17 @@
 package repository
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"time"
 
+	auditRepository "github.com/ePSA-eJya/Mess_Management/internal/audit/repository"
+	authRepository "github.com/ePSA-eJya/Mess_Management/internal/auth/repository"
 	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	studentRepository "github.com/ePSA-eJya/Mess_Management/internal/student/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
 	"gorm.io/gorm"
 )
 
@@ -15,13 +23,18 @@ func NewGormUserRepository(db *gorm.DB) UserRepository {
 	return &GormUserRepository{db: db}
 }
 
-func (r *GormUserRepository) Save(user *entities.User) error {
-	return r.db.Create(user).Error
+func (r *GormUserRepository) Save(ctx context.Context, user *entities.User, audit *entities.AuditLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return auditRepository.Append(tx, audit)
+	})
 }
 
-func (r *GormUserRepository) FindByEmail(email string) (*entities.User, error) {
+func (r *GormUserRepository) FindByEmail(ctx context.Context, email string) (*entities.User, error) {
 	var user entities.User
-	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, gorm.ErrRecordNotFound
 		}
@@ -30,17 +43,21 @@ func (r *GormUserRepository) FindByEmail(email string) (*entities.User, error) {
 	return &user, nil
 }
 
-func (r *GormUserRepository) FindByID(id string) (*entities.User, error) {
+func (r *GormUserRepository) FindByID(ctx context.Context, id string) (*entities.User, error) {
 	var user entities.User
-	if err := r.db.First(&user, "id = ?", id).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&user, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *GormUserRepository) FindAll() ([]*entities.User, error) {
+func (r *GormUserRepository) FindAll(ctx context.Context, query pagination.Query) (*pagination.Page[*entities.User], error) {
+	return pagination.Find[entities.User](r.db.WithContext(ctx), query, UserFields)
+}
+
+func (r *GormUserRepository) FindByRole(ctx context.Context, role entities.Role) ([]*entities.User, error) {
 	var userValues []entities.User
-	if err := r.db.Find(&userValues).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("role = ?", role).Find(&userValues).Error; err != nil {
 		return nil, err
 	}
 	users := make([]*entities.User, len(userValues))
@@ -50,24 +67,92 @@ func (r *GormUserRepository) FindAll() ([]*entities.User, error) {
 	return users, nil
 }
 
-func (r *GormUserRepository) Patch(id string, user *entities.User) error {
-	result := r.db.Model(&entities.User{}).Where("id = ?", id).Updates(user)
-	if result.Error != nil {
-		return result.Error
-	}
-	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
-	}
-	return nil
+// Patch updates the user, bumps its version and appends audit in the same
+// transaction, audit may be nil
+func (r *GormUserRepository) Patch(ctx context.Context, id string, user *entities.User, version uint, audit *entities.AuditLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&entities.User{}).Where("id = ?", id)
+		if version != 0 {
+			query = query.Where("version = ?", version)
+		}
+		result := query.Updates(user)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			var count int64
+			if err := tx.Model(&entities.User{}).Where("id = ?", id).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return gorm.ErrRecordNotFound
+			}
+			return fmt.Errorf("%w: user %s is no longer at version %d", apperror.ErrStaleVersion, id, version)
+		}
+		// the row is locked by the update above, so no one slips in between
+		if err := tx.Model(&entities.User{}).Where("id = ?", id).UpdateColumn("version", gorm.Expr("version + 1")).Error; err != nil {
+			return err
+		}
+		return auditRepository.Append(tx, audit)
+	})
 }
 
-func (r *GormUserRepository) Delete(id string) error {
-	result := r.db.Delete(&entities.User{}, "id = ?", id)
-	if result.Error != nil {
-		return result.Error
-	}
-	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+// Delete removes the user, revokes their sessions and appends audit in the same
+// transaction, audit may be nil
+func (r *GormUserRepository) Delete(ctx context.Context, id string, audit *entities.AuditLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&entities.User{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if _, err := authRepository.RevokeUserSessions(tx, id, RevokedUserDeleted, time.Now()); err != nil {
+			return err
+		}
+		return auditRepository.Append(tx, audit)
+	})
+}
+
+func (r *GormUserRepository) FindDeleted(ctx context.Context, query pagination.Query, since time.Time) (*pagination.Page[*entities.User], error) {
+	return pagination.Find[entities.User](r.db.WithContext(ctx).Unscoped().Where("deleted_at > ?", since), query, UserFields)
+}
+
+func (r *GormUserRepository) FindDeletedByID(ctx context.Context, id string, since time.Time) (*entities.User, error) {
+	var user entities.User
+	if err := r.db.WithContext(ctx).Unscoped().Where("deleted_at > ?", since).First(&user, "id = ?", id).Error; err != nil {
+		return nil, err
 	}
-	return nil
+	return &user, nil
+}
+
+// Restore clears deleted_at and appends audit in the same transaction, audit may be nil
+func (r *GormUserRepository) Restore(ctx context.Context, id string, since time.Time, audit *entities.AuditLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&entities.User{}).
+			Where("id = ? AND deleted_at > ?", id, since).
+			Update("deleted_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return auditRepository.Append(tx, audit)
+	})
+}
+
+func (r *GormUserRepository) Purge(before time.Time) (int64, error) {
+	// a student keeps their account, the student record may be deleted as well
+	billedStudent := r.db.Unscoped().Model(&entities.Student{}).Select("1").
+		Where("students.email = users.email").
+		Where("(?)", studentRepository.HasBillingHistory(r.db, "students.roll"))
+	recordedPayment := r.db.Model(&entities.Payment{}).Select("1").Where("payments.recorded_by = users.id")
+
+	result := r.db.Unscoped().
+		Where("deleted_at < ?", before).
+		Where("NOT EXISTS (?) AND NOT EXISTS (?)", billedStudent, recordedPayment).
+		Delete(&entities.User{})
+	return result.RowsAffected, result.Error
 }
@@ -1,16 +1,21 @@
 package repository_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/ePSA-eJya/Mess_Management/internal/database"
 	"github.com/ePSA-eJya/Mess_Management/internal/entities"
 	"github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
 )
 
+var ctx = context.Background()
+
 type UserRepositoryTestSuite struct {
 	suite.Suite
 	db      *gorm.DB
@@ -40,7 +45,7 @@ func (s *UserRepositoryTestSuite) TestSave() {
 		Name:     "Test User",
 	}
 
-	err := s.repo.Save(user)
+	err := s.repo.Save(ctx, user, nil)
 	s.NoError(err)
 	s.NotEmpty(user.ID)
 }
@@ -52,11 +57,11 @@ func (s *UserRepositoryTestSuite) TestFindByEmail() {
 		Password: "password123",
 		Name:     "Find By Email User",
 	}
-	err := s.repo.Save(user)
+	err := s.repo.Save(ctx, user, nil)
 	s.NoError(err)
 
 	// Find by email
-	found, err := s.repo.FindByEmail("findbyemail@example.com")
+	found, err := s.repo.FindByEmail(ctx, "findbyemail@example.com")
 	s.NoError(err)
 	s.NotNil(found)
 	s.Equal(user.Email, found.Email)
@@ -64,7 +69,7 @@ func (s *UserRepositoryTestSuite) TestFindByEmail() {
 }
 
 func (s *UserRepositoryTestSuite) TestFindByEmail_NotFound() {
-	found, err := s.repo.FindByEmail("notfound@example.com")
+	found, err := s.repo.FindByEmail(ctx, "notfound@example.com")
 	s.Error(err)
 	s.Nil(found)
 	s.Equal(gorm.ErrRecordNotFound, err)
@@ -77,11 +82,11 @@ func (s *UserRepositoryTestSuite) TestFindByID() {
 		Password: "password123",
 		Name:     "Find By ID User",
 	}
-	err := s.repo.Save(user)
+	err := s.repo.Save(ctx, user, nil)
 	s.NoError(err)
 
 	// Find by ID
-	found, err := s.repo.FindByID(user.ID.String())
+	found, err := s.repo.FindByID(ctx, user.ID.String())
 	s.NoError(err)
 	s.NotNil(found)
 	s.Equal(user.ID, found.ID)
@@ -90,7 +95,7 @@ func (s *UserRepositoryTestSuite) TestFindByID() {
 
 func (s *UserRepositoryTestSuite) TestFindByID_NotFound() {
 	nonExistentID := uuid.New().String()
-	found, err := s.repo.FindByID(nonExistentID)
+	found, err := s.repo.FindByID(ctx, nonExistentID)
 	s.Error(err)
 	s.Nil(found)
 }
@@ -104,20 +109,49 @@ func (s *UserRepositoryTestSuite) TestFindAll() {
 	}
 
 	for _, user := range users {
-		err := s.repo.Save(user)
+		err := s.repo.Save(ctx, user, nil)
 		s.NoError(err)
 	}
 
 	// Find all
-	allUsers, err := s.repo.FindAll()
+	allUsers, err := s.repo.FindAll(ctx, pagination.Query{})
+	s.NoError(err)
+	s.Len(allUsers.Items, 3)
+}
+
+func (s *UserRepositoryTestSuite) TestFindAll_FilterAndSort() {
+	for _, user := range []*entities.User{
+		{Email: "c@example.com", Name: "C", Role: entities.RoleStudent},
+		{Email: "a@example.com", Name: "A", Role: entities.RoleStudent},
+		{Email: "b@example.com", Name: "B", Role: entities.RoleMessAdmin},
+	} {
+		s.Require().NoError(s.repo.Save(ctx, user, nil))
+	}
+
+	page, err := s.repo.FindAll(ctx, pagination.Query{Limit: 1, Filter: map[string]string{"role": "STUDENT"}})
+	s.NoError(err)
+	s.Equal(int64(2), page.Total)
+	s.Require().Len(page.Items, 1)
+	s.Equal("a@example.com", page.Items[0].Email)
+	s.NotEmpty(page.NextCursor)
+
+	page, err = s.repo.FindAll(ctx, pagination.Query{Limit: 1, Cursor: page.NextCursor, Filter: map[string]string{"role": "STUDENT"}})
 	s.NoError(err)
-	s.Len(allUsers, 3)
+	s.Require().Len(page.Items, 1)
+	s.Equal("c@example.com", page.Items[0].Email)
+	s.Empty(page.NextCursor)
+
+	// a cursor only continues the sort order it was issued for
+	first, err := s.repo.FindAll(ctx, pagination.Query{Limit: 1})
+	s.NoError(err)
+	_, err = s.repo.FindAll(ctx, pagination.Query{Sort: "-name", Cursor: first.NextCursor})
+	s.Error(err)
 }
 
 func (s *UserRepositoryTestSuite) TestFindAll_Empty() {
-	allUsers, err := s.repo.FindAll()
+	allUsers, err := s.repo.FindAll(ctx, pagination.Query{})
 	s.NoError(err)
-	s.Empty(allUsers)
+	s.Empty(allUsers.Items)
 }
 
 func (s *UserRepositoryTestSuite) TestPatch() {
@@ -127,18 +161,18 @@ func (s *UserRepositoryTestSuite) TestPatch() {
 		Password: "password123",
 		Name:     "Original Name",
 	}
-	err := s.repo.Save(user)
+	err := s.repo.Save(ctx, user, nil)
 	s.NoError(err)
 
 	// Update user
 	updateData := &entities.User{
 		Name: "Updated Name",
 	}
-	err = s.repo.Patch(user.ID.String(), updateData)
+	err = s.repo.Patch(ctx, user.ID.String(), updateData, 0, nil)
 	s.NoError(err)
 
 	// Verify update
-	updated, err := s.repo.FindByID(user.ID.String())
+	updated, err := s.repo.FindByID(ctx, user.ID.String())
 	s.NoError(err)
 	s.Equal("Updated Name", updated.Name)
 	s.Equal(user.Email, updated.Email) // Email should remain unchanged
@@ -149,7 +183,7 @@ func (s *UserRepositoryTestSuite) TestPatch_NotFound() {
 	updateData := &entities.User{
 		Name: "Updated Name",
 	}
-	err := s.repo.Patch(nonExistentID, updateData)
+	err := s.repo.Patch(ctx, nonExistentID, updateData, 0, nil)
 	s.Error(err)
 	s.Equal(gorm.ErrRecordNotFound, err)
 }
@@ -161,33 +195,52 @@ func (s *UserRepositoryTestSuite) TestDelete() {
 		Password: "password123",
 		Name:     "Delete User",
 	}
-	err := s.repo.Save(user)
+	err := s.repo.Save(ctx, user, nil)
 	s.NoError(err)
 
 	// Delete user
-	err = s.repo.Delete(user.ID.String())
+	err = s.repo.Delete(ctx, user.ID.String(), nil)
 	s.NoError(err)
 
 	// Verify deletion
-	found, err := s.repo.FindByID(user.ID.String())
+	found, err := s.repo.FindByID(ctx, user.ID.String())
 	s.Error(err)
 	s.Nil(found)
 }
 
 func (s *UserRepositoryTestSuite) TestDelete_NotFound() {
 	nonExistentID := uuid.New().String()
-	err := s.repo.Delete(nonExistentID)
+	err := s.repo.Delete(ctx, nonExistentID, nil)
 	s.Error(err)
 	s.Equal(gorm.ErrRecordNotFound, err)
 }
 
+func (s *UserRepositoryTestSuite) TestPurge_KeepsBilledStudents() {
+	billed := &entities.User{Email: "billed@example.com", Password: "password123", Name: "Billed"}
+	unbilled := &entities.User{Email: "unbilled@example.com", Password: "password123", Name: "Unbilled"}
+	s.Require().NoError(s.repo.Save(ctx, billed, nil))
+	s.Require().NoError(s.repo.Save(ctx, unbilled, nil))
+	s.Require().NoError(s.db.Create(&entities.Student{Roll: 7, Name: "Billed", Hostel: "H1", RoomNo: 1, MessNo: 1, Email: "billed@example.com"}).Error)
+	s.Require().NoError(s.db.Create(&entities.MonthlyBill{Roll: 7, Month: "2026-01", TotalBill: 100}).Error)
+	s.Require().NoError(s.repo.Delete(ctx, billed.ID.String(), nil))
+	s.Require().NoError(s.repo.Delete(ctx, unbilled.ID.String(), nil))
+
+	purged, err := s.repo.Purge(time.Now().Add(time.Minute))
+	s.NoError(err)
+	s.Equal(int64(1), purged)
+
+	var count int64
+	s.NoError(s.db.Unscoped().Model(&entities.User{}).Where("id = ?", billed.ID).Count(&count).Error)
+	s.Equal(int64(1), count)
+}
+
 func (s *UserRepositoryTestSuite) TestSave_DuplicateEmail() {
 	user1 := &entities.User{
 		Email:    "duplicate@example.com",
 		Password: "password123",
 		Name:     "User 1",
 	}
-	err := s.repo.Save(user1)
+	err := s.repo.Save(ctx, user1, nil)
 	s.NoError(err)
 
 	// Try to save another user with same email
@@ -196,6 +249,6 @@ func (s *UserRepositoryTestSuite) TestSave_DuplicateEmail() {
 		Password: "password456",
 		Name:     "User 2",
 	}
-	err = s.repo.Save(user2)
+	err = s.repo.Save(ctx, user2, nil)
 	s.Error(err) // Should fail due to unique constraint
 }
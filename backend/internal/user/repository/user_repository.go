@@ -1,12 +1,43 @@
 package repository
 
-import "github.com/ePSA-eJya/Mess_Management/internal/entities"
+import (
+	"context"
+	"time"
 
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+)
+
+// RevokedUserDeleted is recorded on the sessions revoked by deleting their user
+const RevokedUserDeleted = "USER_DELETED"
+
+// UserFields are the fields users can be listed by
+var UserFields = pagination.Fields{
+	Filter:      map[string]string{"role": "role", "email": "email"},
+	Sort:        map[string]string{"email": "email", "name": "name"},
+	DefaultSort: "email",
+}
+
+// UserRepository queries take the request context so they show up in its
+// trace, Purge is the exception as only the cleanup job calls it.
 type UserRepository interface {
-	Save(user *entities.User) error
-	FindByEmail(email string) (*entities.User, error)
-	FindByID(id string) (*entities.User, error)
-	FindAll() ([]*entities.User, error)
-	Patch(id string, user *entities.User) error
-	Delete(id string) error
+	// Save creates the user and appends audit in the same transaction, audit may be nil
+	Save(ctx context.Context, user *entities.User, audit *entities.AuditLog) error
+	FindByEmail(ctx context.Context, email string) (*entities.User, error)
+	FindByID(ctx context.Context, id string) (*entities.User, error)
+	FindAll(ctx context.Context, query pagination.Query) (*pagination.Page[*entities.User], error)
+	FindByRole(ctx context.Context, role entities.Role) ([]*entities.User, error)
+	// Patch fails with apperror.ErrStaleVersion unless the user is still at
+	// version, 0 skips the check
+	Patch(ctx context.Context, id string, user *entities.User, version uint, audit *entities.AuditLog) error
+	// Delete also revokes every session of the user
+	Delete(ctx context.Context, id string, audit *entities.AuditLog) error
+
+	// deleted users, only those deleted after since can be found and restored
+	FindDeleted(ctx context.Context, query pagination.Query, since time.Time) (*pagination.Page[*entities.User], error)
+	FindDeletedByID(ctx context.Context, id string, since time.Time) (*entities.User, error)
+	Restore(ctx context.Context, id string, since time.Time, audit *entities.AuditLog) error
+	// Purge removes users deleted before before for good, except students with
+	// bills or payments and the admins who recorded payments
+	Purge(before time.Time) (int64, error)
 }
@@ -1,12 +1,48 @@
 package usecase
 
-import "github.com/ePSA-eJya/Mess_Management/internal/entities"
+import (
+	"context"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+)
+
+// Actor identifies the authenticated user performing a user action and the
+// address they acted from
+type Actor struct {
+	UserID string
+	Role   entities.Role
+	IP     string
+}
+
+// CanView reports whether the actor may read the user with id, which is their
+// own account unless they hold an admin role
+func (a Actor) CanView(id string) bool {
+	return a.UserID == id || a.Role.IsAdmin()
+}
+
+// CanManage reports whether the actor may change or delete user. Admins manage
+// every other account, except that only an office admin manages other admins.
+func (a Actor) CanManage(user *entities.User) bool {
+	switch {
+	case a.UserID == user.ID.String(), a.Role == entities.RoleOfficeAdmin:
+		return true
+	default:
+		return a.Role.IsAdmin() && !user.Role.IsAdmin()
+	}
+}
+
+func (a Actor) audit() entities.AuditActor {
+	return entities.AuditActor{UserID: a.UserID, IP: a.IP}
+}
 
 type UserUseCase interface {
-	Register(user *entities.User) error
-	Login(email, password string) (string, *entities.User, error)
-	FindUserByID(id string) (*entities.User, error)
-	FindAllUsers() ([]*entities.User, error)
-	PatchUser(id string, user *entities.User) (*entities.User, error)
-	DeleteUser(id string) error
+	Register(ctx context.Context, user *entities.User) error
+	FindUserByID(ctx context.Context, actor Actor, id string) (*entities.User, error)
+	FindAllUsers(ctx context.Context, query pagination.Query) (*pagination.Page[*entities.User], error)
+	// PatchUser applies on top of version, the one the caller read, which is required
+	PatchUser(ctx context.Context, actor Actor, id string, user *entities.User, version uint) (*entities.User, error)
+	DeleteUser(ctx context.Context, actor Actor, id string) error
+	FindDeletedUsers(ctx context.Context, query pagination.Query) (*pagination.Page[*entities.User], error)
+	RestoreUser(ctx context.Context, actor Actor, id string) (*entities.User, error)
 }
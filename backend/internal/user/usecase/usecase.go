@@ -1,29 +1,38 @@
 package usecase
 
 import (
-	"os"
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/ePSA-eJya/Mess_Management/internal/entities"
 	"github.com/ePSA-eJya/Mess_Management/internal/user/repository"
 	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"github.com/ePSA-eJya/Mess_Management/pkg/tracing"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 // UserService struct
 type UserService struct {
 	repo repository.UserRepository
+	// retention is how long a deleted user can be restored
+	retention time.Duration
 }
 
 // Init UserService
-func NewUserService(repo repository.UserRepository) UserUseCase {
-	return &UserService{repo: repo}
+func NewUserService(repo repository.UserRepository, retention time.Duration) UserUseCase {
+	return &UserService{repo: repo, retention: retention}
 }
 
 // UserService Methods - 1 Register user (hash password)
-func (s *UserService) Register(user *entities.User) error {
-	existingUser, _ := s.repo.FindByEmail(user.Email)
+func (s *UserService) Register(ctx context.Context, user *entities.User) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.Register")
+	defer func() { tracing.End(span, err) }()
+
+	existingUser, _ := s.repo.FindByEmail(ctx, user.Email)
 	if existingUser != nil {
 		return apperror.ErrAlreadyExists
 	}
@@ -34,74 +43,161 @@ func (s *UserService) Register(user *entities.User) error {
 	}
 
 	user.Password = string(hashedPwd)
+	user.ID = uuid.New()
 
-	return s.repo.Save(user)
+	// a new account registers itself
+	audit, err := entities.NewAuditLog(entities.AuditActor{UserID: user.ID.String()}, entities.AuditUserCreated, "user", user.ID.String(), nil, user)
+	if err != nil {
+		return err
+	}
+	return s.repo.Save(ctx, user, audit)
 }
 
-// UserService Methods - 2 Login user (check email + password)
-func (s *UserService) Login(email string, password string) (string, *entities.User, error) {
-	user, err := s.repo.FindByEmail(email)
-	if err != nil || user == nil {
-		return "", nil, err
-	}
+// UserService Methods - 2 Get user by id
+func (s *UserService) FindUserByID(ctx context.Context, actor Actor, id string) (_ *entities.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.FindUserByID")
+	defer func() { tracing.End(span, err) }()
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return "", nil, err
+	if err := authorize(actor, id); err != nil {
+		return nil, err
 	}
+	return s.repo.FindByID(ctx, id)
+}
 
-	// Generate JWT token
-	claims := jwt.MapClaims{
-		"user_id": user.ID,
-		"exp":     time.Now().Add(time.Hour * 72).Unix(), // 3 days
-	}
+// UserService Methods - 3 Get a page of users
+func (s *UserService) FindAllUsers(ctx context.Context, query pagination.Query) (_ *pagination.Page[*entities.User], err error) {
+	ctx, span := tracing.Start(ctx, "UserService.FindAllUsers")
+	defer func() { tracing.End(span, err) }()
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	secret := os.Getenv("JWT_SECRET")
-	tokenString, err := token.SignedString([]byte(secret))
+	page, err := s.repo.FindAll(ctx, query)
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
-
-	return tokenString, user, nil
+	return page, nil
 }
 
-// UserService Methods - 3 Get user by id
-func (s *UserService) FindUserByID(id string) (*entities.User, error) {
-	return s.repo.FindByID(id)
-}
+// UserService Methods - 4 Get user by email
+func (s *UserService) GetUserByEmail(ctx context.Context, email string) (_ *entities.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserByEmail")
+	defer func() { tracing.End(span, err) }()
 
-// UserService Methods - 4 Get all users
-func (s *UserService) FindAllUsers() ([]*entities.User, error) {
-	users, err := s.repo.FindAll()
+	user, err := s.repo.FindByEmail(ctx, email)
 	if err != nil {
 		return nil, err
 	}
-	return users, nil
+	return user, nil
 }
 
-// UserService Methods - 5 Get user by email
-func (s *UserService) GetUserByEmail(email string) (*entities.User, error) {
-	user, err := s.repo.FindByEmail(email)
+// UserService Methods - 5 Patch, only the name can be changed here
+func (s *UserService) PatchUser(ctx context.Context, actor Actor, id string, user *entities.User, version uint) (_ *entities.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.PatchUser")
+	defer func() { tracing.End(span, err) }()
+
+	if version == 0 {
+		return nil, fmt.Errorf("%w: send the version of the user the update is based on, If-Match over REST", apperror.ErrPreconditionRequired)
+	}
+	before, err := s.findManaged(ctx, actor, id)
 	if err != nil {
 		return nil, err
 	}
-	return user, nil
-}
 
-// UserService Methods - 6 Patch
-func (s *UserService) PatchUser(id string, user *entities.User) (*entities.User, error) {
-	if err := s.repo.Patch(id, user); err != nil {
+	after := *before
+	after.Name = user.Name
+	audit, err := entities.NewAuditLog(actor.audit(), entities.AuditUserUpdated, "user", id, before, &after)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Patch(ctx, id, &entities.User{Name: user.Name}, version, audit); err != nil {
 		return nil, err
 	}
-	updatedUser, _ := s.repo.FindByID(id)
+	updatedUser, _ := s.repo.FindByID(ctx, id)
 
 	return updatedUser, nil
 }
 
-// UserService Methods - 7 Delete
-func (s *UserService) DeleteUser(id string) error {
-	if err := s.repo.Delete(id); err != nil {
+// UserService Methods - 6 Delete
+func (s *UserService) DeleteUser(ctx context.Context, actor Actor, id string) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.DeleteUser")
+	defer func() { tracing.End(span, err) }()
+
+	before, err := s.findManaged(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+
+	audit, err := entities.NewAuditLog(actor.audit(), entities.AuditUserDeleted, "user", id, before, nil)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id, audit); err != nil {
 		return err
 	}
 	return nil
 }
+
+// UserService Methods - 7 Get a page of users deleted within the retention window
+func (s *UserService) FindDeletedUsers(ctx context.Context, query pagination.Query) (_ *pagination.Page[*entities.User], err error) {
+	ctx, span := tracing.Start(ctx, "UserService.FindDeletedUsers")
+	defer func() { tracing.End(span, err) }()
+
+	return s.repo.FindDeleted(ctx, query, time.Now().Add(-s.retention))
+}
+
+// UserService Methods - 8 Restore a user deleted within the retention window,
+// unless their email has been taken by a new account since
+func (s *UserService) RestoreUser(ctx context.Context, actor Actor, id string) (_ *entities.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.RestoreUser")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, apperror.ErrInvalidID
+	}
+	since := time.Now().Add(-s.retention)
+	before, err := s.repo.FindDeletedByID(ctx, id, since)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.CanManage(before) {
+		return nil, apperror.ErrForbidden
+	}
+	if existing, _ := s.repo.FindByEmail(ctx, before.Email); existing != nil {
+		return nil, apperror.ErrAlreadyExists
+	}
+
+	after := *before
+	after.DeletedAt = gorm.DeletedAt{}
+	audit, err := entities.NewAuditLog(actor.audit(), entities.AuditUserRestored, "user", id, before, &after)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Restore(ctx, id, since, audit); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByID(ctx, id)
+}
+
+// authorize checks the id is well formed and belongs to an account the actor can view
+func authorize(actor Actor, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return apperror.ErrInvalidID
+	}
+	if !actor.CanView(id) {
+		return apperror.ErrForbidden
+	}
+	return nil
+}
+
+// findManaged returns the user with id if the actor may change it
+func (s *UserService) findManaged(ctx context.Context, actor Actor, id string) (*entities.User, error) {
+	if err := authorize(actor, id); err != nil {
+		return nil, err
+	}
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.CanManage(user) {
+		return nil, apperror.ErrForbidden
+	}
+	return user, nil
+}
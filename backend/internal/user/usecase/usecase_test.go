@@ -1,19 +1,23 @@
 package usecase_test
 
 import (
-	"os"
+	"context"
 	"testing"
+	"time"
 
 	"github.com/ePSA-eJya/Mess_Management/internal/database"
 	"github.com/ePSA-eJya/Mess_Management/internal/entities"
 	"github.com/ePSA-eJya/Mess_Management/internal/user/repository"
 	"github.com/ePSA-eJya/Mess_Management/internal/user/usecase"
 	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
 	"github.com/stretchr/testify/suite"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
+var ctx = context.Background()
+
 type UserUseCaseTestSuite struct {
 	suite.Suite
 	db      *gorm.DB
@@ -25,23 +29,24 @@ type UserUseCaseTestSuite struct {
 func (s *UserUseCaseTestSuite) SetupTest() {
 	s.db, s.cleanup = database.SetupTestDB(s.T())
 	s.repo = repository.NewGormUserRepository(s.db)
-	s.service = usecase.NewUserService(s.repo)
-
-	// Set JWT_SECRET for testing
-	os.Setenv("JWT_SECRET", "test-secret-key-for-jwt-token-generation")
+	s.service = usecase.NewUserService(s.repo, 30*24*time.Hour)
 }
 
 func (s *UserUseCaseTestSuite) TearDownTest() {
 	if s.cleanup != nil {
 		s.cleanup()
 	}
-	os.Unsetenv("JWT_SECRET")
 }
 
 func TestUserUseCaseTestSuite(t *testing.T) {
 	suite.Run(t, new(UserUseCaseTestSuite))
 }
 
+// self acts as the user on their own account
+func self(user *entities.User) usecase.Actor {
+	return usecase.Actor{UserID: user.ID.String(), Role: entities.RoleStudent}
+}
+
 func (s *UserUseCaseTestSuite) TestRegister() {
 	user := &entities.User{
 		Email:    "register@example.com",
@@ -49,7 +54,7 @@ func (s *UserUseCaseTestSuite) TestRegister() {
 		Name:     "Register User",
 	}
 
-	err := s.service.Register(user)
+	err := s.service.Register(ctx, user)
 	s.NoError(err)
 	s.NotEmpty(user.ID)
 
@@ -65,7 +70,7 @@ func (s *UserUseCaseTestSuite) TestRegister_DuplicateEmail() {
 		Password: "password123",
 		Name:     "User 1",
 	}
-	err := s.service.Register(user1)
+	err := s.service.Register(ctx, user1)
 	s.NoError(err)
 
 	// Try to register with same email
@@ -74,53 +79,11 @@ func (s *UserUseCaseTestSuite) TestRegister_DuplicateEmail() {
 		Password: "password456",
 		Name:     "User 2",
 	}
-	err = s.service.Register(user2)
+	err = s.service.Register(ctx, user2)
 	s.Error(err)
 	s.Equal(apperror.ErrAlreadyExists, err)
 }
 
-func (s *UserUseCaseTestSuite) TestLogin() {
-	// Register a user first
-	user := &entities.User{
-		Email:    "login@example.com",
-		Password: "password123",
-		Name:     "Login User",
-	}
-	err := s.service.Register(user)
-	s.NoError(err)
-
-	// Login with correct credentials
-	token, loggedInUser, err := s.service.Login("login@example.com", "password123")
-	s.NoError(err)
-	s.NotEmpty(token)
-	s.NotNil(loggedInUser)
-	s.Equal(user.Email, loggedInUser.Email)
-}
-
-func (s *UserUseCaseTestSuite) TestLogin_WrongPassword() {
-	// Register a user first
-	user := &entities.User{
-		Email:    "wrongpass@example.com",
-		Password: "password123",
-		Name:     "Wrong Pass User",
-	}
-	err := s.service.Register(user)
-	s.NoError(err)
-
-	// Login with wrong password
-	token, loggedInUser, err := s.service.Login("wrongpass@example.com", "wrongpassword")
-	s.Error(err)
-	s.Empty(token)
-	s.Nil(loggedInUser)
-}
-
-func (s *UserUseCaseTestSuite) TestLogin_UserNotFound() {
-	token, loggedInUser, err := s.service.Login("notfound@example.com", "password123")
-	s.Error(err)
-	s.Empty(token)
-	s.Nil(loggedInUser)
-}
-
 func (s *UserUseCaseTestSuite) TestFindUserByID() {
 	// Register a user first
 	user := &entities.User{
@@ -128,11 +91,11 @@ func (s *UserUseCaseTestSuite) TestFindUserByID() {
 		Password: "password123",
 		Name:     "Find By ID User",
 	}
-	err := s.service.Register(user)
+	err := s.service.Register(ctx, user)
 	s.NoError(err)
 
 	// Find by ID
-	found, err := s.service.FindUserByID(user.ID.String())
+	found, err := s.service.FindUserByID(ctx, self(user), user.ID.String())
 	s.NoError(err)
 	s.NotNil(found)
 	s.Equal(user.ID, found.ID)
@@ -148,14 +111,14 @@ func (s *UserUseCaseTestSuite) TestFindAllUsers() {
 	}
 
 	for _, user := range users {
-		err := s.service.Register(user)
+		err := s.service.Register(ctx, user)
 		s.NoError(err)
 	}
 
 	// Find all
-	allUsers, err := s.service.FindAllUsers()
+	allUsers, err := s.service.FindAllUsers(ctx, pagination.Query{})
 	s.NoError(err)
-	s.Len(allUsers, 3)
+	s.Len(allUsers.Items, 3)
 }
 
 func (s *UserUseCaseTestSuite) TestPatchUser() {
@@ -165,20 +128,53 @@ func (s *UserUseCaseTestSuite) TestPatchUser() {
 		Password: "password123",
 		Name:     "Original Name",
 	}
-	err := s.service.Register(user)
+	err := s.service.Register(ctx, user)
 	s.NoError(err)
 
 	// Update user
 	updateData := &entities.User{
 		Name: "Updated Name",
 	}
-	updated, err := s.service.PatchUser(user.ID.String(), updateData)
+	updated, err := s.service.PatchUser(ctx, self(user), user.ID.String(), updateData, 1)
 	s.NoError(err)
 	s.NotNil(updated)
 	s.Equal("Updated Name", updated.Name)
 	s.Equal(user.Email, updated.Email)
 }
 
+func (s *UserUseCaseTestSuite) TestPatchUser_StaleVersion() {
+	user := &entities.User{Email: "stale@example.com", Password: "password123", Name: "Original"}
+	s.Require().NoError(s.service.Register(ctx, user))
+	admin := usecase.Actor{UserID: "9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", Role: entities.RoleOfficeAdmin}
+
+	first, err := s.service.PatchUser(ctx, admin, user.ID.String(), &entities.User{Name: "First"}, 1)
+	s.Require().NoError(err)
+	s.Equal(uint(2), first.Version)
+
+	// the second admin read version 1 as well
+	_, err = s.service.PatchUser(ctx, admin, user.ID.String(), &entities.User{Name: "Second"}, 1)
+	s.ErrorIs(err, apperror.ErrStaleVersion)
+
+	found, err := s.service.FindUserByID(ctx, admin, user.ID.String())
+	s.Require().NoError(err)
+	s.Equal("First", found.Name)
+	var audits int64
+	s.Require().NoError(s.db.Model(&entities.AuditLog{}).Where("action = ?", entities.AuditUserUpdated).Count(&audits).Error)
+	s.Equal(int64(1), audits)
+}
+
+func (s *UserUseCaseTestSuite) TestPatchUser_VersionRequired() {
+	user := &entities.User{Email: "blind@example.com", Password: "password123", Name: "Original"}
+	s.Require().NoError(s.service.Register(ctx, user))
+
+	_, err := s.service.PatchUser(ctx, self(user), user.ID.String(), &entities.User{Name: "Blind"}, 0)
+	s.ErrorIs(err, apperror.ErrPreconditionRequired)
+
+	found, err := s.service.FindUserByID(ctx, self(user), user.ID.String())
+	s.Require().NoError(err)
+	s.Equal("Original", found.Name)
+}
+
 func (s *UserUseCaseTestSuite) TestDeleteUser() {
 	// Register a user first
 	user := &entities.User{
@@ -186,15 +182,122 @@ func (s *UserUseCaseTestSuite) TestDeleteUser() {
 		Password: "password123",
 		Name:     "Delete User",
 	}
-	err := s.service.Register(user)
+	err := s.service.Register(ctx, user)
 	s.NoError(err)
 
 	// Delete user
-	err = s.service.DeleteUser(user.ID.String())
+	err = s.service.DeleteUser(ctx, self(user), user.ID.String())
 	s.NoError(err)
 
 	// Verify deletion
-	found, err := s.service.FindUserByID(user.ID.String())
+	found, err := s.service.FindUserByID(ctx, self(user), user.ID.String())
 	s.Error(err)
 	s.Nil(found)
 }
+
+func (s *UserUseCaseTestSuite) TestPatchAndDeleteUser_OnlySelfOrAdmin() {
+	user := &entities.User{Email: "owner@example.com", Password: "password123", Name: "Owner"}
+	other := &entities.User{Email: "other@example.com", Password: "password123", Name: "Other"}
+	s.Require().NoError(s.service.Register(ctx, user))
+	s.Require().NoError(s.service.Register(ctx, other))
+
+	_, err := s.service.FindUserByID(ctx, self(other), user.ID.String())
+	s.ErrorIs(err, apperror.ErrForbidden)
+	_, err = s.service.PatchUser(ctx, self(other), user.ID.String(), &entities.User{Name: "Hijacked"}, 1)
+	s.ErrorIs(err, apperror.ErrForbidden)
+	s.ErrorIs(s.service.DeleteUser(ctx, self(other), user.ID.String()), apperror.ErrForbidden)
+
+	admin := usecase.Actor{UserID: other.ID.String(), Role: entities.RoleOfficeAdmin}
+	updated, err := s.service.PatchUser(ctx, admin, user.ID.String(), &entities.User{Name: "Renamed"}, 1)
+	s.NoError(err)
+	s.Equal("Renamed", updated.Name)
+	s.NoError(s.service.DeleteUser(ctx, admin, user.ID.String()))
+}
+
+func (s *UserUseCaseTestSuite) TestPatchAndDeleteUser_OnlyOfficeAdminManagesAdmins() {
+	officeAdmin := &entities.User{Email: "office@example.com", Password: "password123", Name: "Office", Role: entities.RoleOfficeAdmin}
+	student := &entities.User{Email: "student@example.com", Password: "password123", Name: "Student"}
+	s.Require().NoError(s.service.Register(ctx, officeAdmin))
+	s.Require().NoError(s.service.Register(ctx, student))
+	messAdmin := usecase.Actor{UserID: "9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", Role: entities.RoleMessAdmin}
+
+	// a mess admin still sees every account
+	_, err := s.service.FindUserByID(ctx, messAdmin, officeAdmin.ID.String())
+	s.NoError(err)
+	_, err = s.service.PatchUser(ctx, messAdmin, officeAdmin.ID.String(), &entities.User{Name: "Demoted"}, 1)
+	s.ErrorIs(err, apperror.ErrForbidden)
+	s.ErrorIs(s.service.DeleteUser(ctx, messAdmin, officeAdmin.ID.String()), apperror.ErrForbidden)
+
+	_, err = s.service.PatchUser(ctx, messAdmin, student.ID.String(), &entities.User{Name: "Renamed"}, 1)
+	s.NoError(err)
+	s.NoError(s.service.DeleteUser(ctx, self(officeAdmin), officeAdmin.ID.String()))
+}
+
+func (s *UserUseCaseTestSuite) TestRegisterPatchAndDeleteUser_Audited() {
+	user := &entities.User{Email: "audited@example.com", Password: "password123", Name: "Before"}
+	s.Require().NoError(s.service.Register(ctx, user))
+	admin := usecase.Actor{UserID: "9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", Role: entities.RoleOfficeAdmin, IP: "203.0.113.7"}
+
+	_, err := s.service.PatchUser(ctx, admin, user.ID.String(), &entities.User{Name: "After"}, 1)
+	s.Require().NoError(err)
+	s.Require().NoError(s.service.DeleteUser(ctx, admin, user.ID.String()))
+
+	var logs []entities.AuditLog
+	s.Require().NoError(s.db.Order("id").Find(&logs).Error)
+	s.Require().Len(logs, 3)
+	s.Equal(entities.AuditUserCreated, logs[0].Action)
+	s.Equal(user.ID.String(), logs[0].ActorID)
+	s.Equal(user.ID.String(), logs[0].EntityID)
+	s.Contains(logs[0].Changes, "audited@example.com")
+	s.NotContains(logs[0].Changes, "password")
+	s.Equal(entities.AuditUserUpdated, logs[1].Action)
+	s.Equal(admin.UserID, logs[1].ActorID)
+	s.Equal(admin.IP, logs[1].IP)
+	s.JSONEq(`{"name":{"before":"Before","after":"After"}}`, logs[1].Changes)
+	s.Equal(entities.AuditUserDeleted, logs[2].Action)
+	s.NotContains(logs[2].Changes, "password")
+	s.Equal(logs[0].Hash, logs[1].PrevHash)
+	s.Equal(logs[1].Hash, logs[2].PrevHash)
+}
+
+func (s *UserUseCaseTestSuite) TestRestoreUser() {
+	user := &entities.User{Email: "restore@example.com", Password: "password123", Name: "Restore"}
+	s.Require().NoError(s.service.Register(ctx, user))
+	admin := usecase.Actor{UserID: "9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", Role: entities.RoleOfficeAdmin}
+	s.Require().NoError(s.service.DeleteUser(ctx, admin, user.ID.String()))
+
+	deleted, err := s.service.FindDeletedUsers(ctx, pagination.Query{})
+	s.NoError(err)
+	s.Require().Len(deleted.Items, 1)
+	s.Equal(user.ID, deleted.Items[0].ID)
+
+	restored, err := s.service.RestoreUser(ctx, admin, user.ID.String())
+	s.NoError(err)
+	s.False(restored.DeletedAt.Valid)
+
+	var audit entities.AuditLog
+	s.NoError(s.db.Where("action = ?", entities.AuditUserRestored).First(&audit).Error)
+	s.Contains(audit.Changes, "deleted_at")
+}
+
+func (s *UserUseCaseTestSuite) TestRestoreUser_EmailTaken() {
+	user := &entities.User{Email: "reused@example.com", Password: "password123", Name: "First"}
+	s.Require().NoError(s.service.Register(ctx, user))
+	s.Require().NoError(s.service.DeleteUser(ctx, self(user), user.ID.String()))
+
+	// the email is free again once its owner is deleted
+	newcomer := &entities.User{Email: "reused@example.com", Password: "password123", Name: "Second"}
+	s.Require().NoError(s.service.Register(ctx, newcomer))
+
+	admin := usecase.Actor{UserID: newcomer.ID.String(), Role: entities.RoleOfficeAdmin}
+	_, err := s.service.RestoreUser(ctx, admin, user.ID.String())
+	s.ErrorIs(err, apperror.ErrAlreadyExists)
+}
+
+func (s *UserUseCaseTestSuite) TestFindUserByID_InvalidID() {
+	user := &entities.User{Email: "invalid@example.com", Password: "password123", Name: "Invalid"}
+	s.Require().NoError(s.service.Register(ctx, user))
+
+	_, err := s.service.FindUserByID(ctx, self(user), "not-a-uuid")
+	s.ErrorIs(err, apperror.ErrInvalidID)
+}
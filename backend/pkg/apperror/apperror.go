@@ -8,17 +8,24 @@ import (
 	"gorm.io/gorm/logger"
 )
 
+// AppError is the error envelope REST and gRPC clients receive, see From
 type AppError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Err     error  `json:"-"`
+	Code      string       `json:"code" example:"INVALID_DATA"`
+	Message   string       `json:"message" example:"invalid data: name is required"`
+	Fields    []FieldError `json:"fields,omitempty"`
+	RequestID string       `json:"request_id,omitempty" example:"0f8b7c2e-5a41-4d0b-9a53-3c2f8e7d6b1a"`
+	Err       error        `json:"-"`
 }
 
 func (e *AppError) Error() string {
 	return e.Message
 }
 
-func NewAppError(code int, msg string, err error) *AppError {
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+func NewAppError(code, msg string, err error) *AppError {
 	return &AppError{
 		Code:    code,
 		Message: msg,
@@ -26,6 +33,18 @@ func NewAppError(code int, msg string, err error) *AppError {
 	}
 }
 
+// FieldError is one rule a request field failed, named by its json key
+type FieldError struct {
+	Field   string `json:"field" example:"name"`
+	Rule    string `json:"rule" example:"required"`
+	Message string `json:"message" example:"name is required"`
+}
+
+// FieldErrors is implemented by errors that carry field details, like validation errors
+type FieldErrors interface {
+	FieldErrors() []FieldError
+}
+
 var (
 	// ------------------------
 	// Generic errors
@@ -79,6 +98,9 @@ var (
 	ErrNotAvailable    = errors.New("not available")    // 409
 	ErrLimitExceeded   = errors.New("limit exceeded")   // 429
 	ErrOperationDenied = errors.New("operation denied") // 403
+	ErrStaleVersion    = errors.New("stale version")    // 409, the row changed since it was read
+
+	ErrPreconditionRequired = errors.New("precondition required") // 428, a write without the version it applies on top of
 
 	// ------------------------
 	// Other errors
@@ -106,7 +128,8 @@ func StatusCode(err error) int {
 	// Database / GORM errors
 	case errors.Is(err, ErrRecordNotFound):
 		return fiber.StatusNotFound
-	case errors.Is(err, ErrDuplicatedKey), errors.Is(err, ErrConflict), errors.Is(err, ErrAlreadyExists), errors.Is(err, ErrNotAvailable):
+	case errors.Is(err, ErrDuplicatedKey), errors.Is(err, ErrConflict), errors.Is(err, ErrAlreadyExists), errors.Is(err, ErrNotAvailable),
+		errors.Is(err, ErrStaleVersion):
 		return fiber.StatusConflict
 	case errors.Is(err, ErrDependencyFail):
 		return fiber.StatusBadGateway
@@ -126,6 +149,8 @@ func StatusCode(err error) int {
 		return fiber.StatusBadRequest
 	case errors.Is(err, ErrUnprocessable):
 		return fiber.StatusUnprocessableEntity
+	case errors.Is(err, ErrPreconditionRequired):
+		return fiber.StatusPreconditionRequired
 	case errors.Is(err, ErrLimitExceeded):
 		return fiber.StatusTooManyRequests
 
@@ -153,6 +178,8 @@ func GRPCCode(err error) codes.Code {
 	// Database / GORM errors
 	case errors.Is(err, ErrRecordNotFound):
 		return codes.NotFound
+	case errors.Is(err, ErrStaleVersion):
+		return codes.Aborted
 	case errors.Is(err, ErrDuplicatedKey), errors.Is(err, ErrConflict), errors.Is(err, ErrAlreadyExists), errors.Is(err, ErrNotAvailable):
 		return codes.AlreadyExists
 	case errors.Is(err, ErrDependencyFail):
@@ -170,7 +197,7 @@ func GRPCCode(err error) codes.Code {
 		return codes.InvalidArgument
 
 	// Validation / business logic
-	case errors.Is(err, ErrUnprocessable):
+	case errors.Is(err, ErrUnprocessable), errors.Is(err, ErrPreconditionRequired):
 		return codes.FailedPrecondition
 	case errors.Is(err, ErrLimitExceeded):
 		return codes.ResourceExhausted
@@ -180,3 +207,86 @@ func GRPCCode(err error) codes.Code {
 		return codes.Unknown
 	}
 }
+
+// Machine-readable error codes clients can switch on, the message may change
+const (
+	CodeInternal             = "INTERNAL"
+	CodeTimeout              = "TIMEOUT"
+	CodeUnauthorized         = "UNAUTHORIZED"
+	CodeForbidden            = "FORBIDDEN"
+	CodeNotImplemented       = "NOT_IMPLEMENTED"
+	CodeNotFound             = "NOT_FOUND"
+	CodeConflict             = "CONFLICT"
+	CodeStaleVersion         = "STALE_VERSION"
+	CodeDependencyFailed     = "DEPENDENCY_FAILED"
+	CodeBadRequest           = "BAD_REQUEST"
+	CodeInvalidData          = "INVALID_DATA"
+	CodeInvalidID            = "INVALID_ID"
+	CodeRequiredField        = "REQUIRED_FIELD"
+	CodeInvalidFormat        = "INVALID_FORMAT"
+	CodeOutOfRange           = "OUT_OF_RANGE"
+	CodeUnprocessable        = "UNPROCESSABLE"
+	CodePreconditionRequired = "PRECONDITION_REQUIRED"
+	CodeLimitExceeded        = "LIMIT_EXCEEDED"
+)
+
+// Code maps errors to the machine-readable code of the error envelope
+func Code(err error) string {
+	var appErr *AppError
+	if errors.As(err, &appErr) && appErr.Code != "" {
+		return appErr.Code
+	}
+
+	switch {
+	// Generic
+	case errors.Is(err, ErrTimeout):
+		return CodeTimeout
+	case errors.Is(err, ErrUnauthorized):
+		return CodeUnauthorized
+	case errors.Is(err, ErrForbidden), errors.Is(err, ErrOperationDenied):
+		return CodeForbidden
+	case errors.Is(err, ErrNotImplemented):
+		return CodeNotImplemented
+
+	// Database / GORM errors
+	case errors.Is(err, ErrRecordNotFound):
+		return CodeNotFound
+	case errors.Is(err, ErrStaleVersion):
+		return CodeStaleVersion
+	case errors.Is(err, ErrDuplicatedKey), errors.Is(err, ErrConflict), errors.Is(err, ErrAlreadyExists), errors.Is(err, ErrNotAvailable):
+		return CodeConflict
+	case errors.Is(err, ErrDependencyFail):
+		return CodeDependencyFailed
+	case errors.Is(err, ErrInvalidTransaction), errors.Is(err, ErrMissingWhereClause),
+		errors.Is(err, ErrUnsupportedRelation), errors.Is(err, ErrPrimaryKeyRequired),
+		errors.Is(err, ErrModelValueRequired), errors.Is(err, ErrModelAccessibleFieldsRequired),
+		errors.Is(err, ErrSubQueryRequired), errors.Is(err, ErrUnsupportData),
+		errors.Is(err, ErrUnsupportedDriver), errors.Is(err, ErrEmptySlice),
+		errors.Is(err, ErrDryRunModeUnsupported), errors.Is(err, ErrPreloadNotAllowed),
+		errors.Is(err, ErrForeignKeyViolated), errors.Is(err, ErrCheckConstraintViolated):
+		return CodeBadRequest
+
+	// Validation / business logic
+	case errors.Is(err, ErrInvalidID):
+		return CodeInvalidID
+	case errors.Is(err, ErrRequiredField):
+		return CodeRequiredField
+	case errors.Is(err, ErrInvalidFormat):
+		return CodeInvalidFormat
+	case errors.Is(err, ErrOutOfRange):
+		return CodeOutOfRange
+	case errors.Is(err, ErrInvalidData), errors.Is(err, ErrInvalidValue),
+		errors.Is(err, ErrInvalidValueOfLength), errors.Is(err, ErrInvalidField):
+		return CodeInvalidData
+	case errors.Is(err, ErrUnprocessable):
+		return CodeUnprocessable
+	case errors.Is(err, ErrPreconditionRequired):
+		return CodePreconditionRequired
+	case errors.Is(err, ErrLimitExceeded):
+		return CodeLimitExceeded
+
+	// Default
+	default:
+		return CodeInternal
+	}
+}
@@ -0,0 +1,90 @@
+package apperror_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fieldsError struct{}
+
+func (fieldsError) Error() string { return "invalid data: name is required" }
+func (fieldsError) Unwrap() error { return apperror.ErrInvalidData }
+func (fieldsError) FieldErrors() []apperror.FieldError {
+	return []apperror.FieldError{{Field: "name", Rule: "required", Message: "name is required"}}
+}
+
+func TestCode(t *testing.T) {
+	assert.Equal(t, apperror.CodeNotFound, apperror.Code(apperror.ErrRecordNotFound))
+	assert.Equal(t, apperror.CodeInvalidData, apperror.Code(fmt.Errorf("%w: bad total", apperror.ErrInvalidData)))
+	assert.Equal(t, apperror.CodeLimitExceeded, apperror.Code(apperror.ErrLimitExceeded))
+	assert.Equal(t, apperror.CodeStaleVersion, apperror.Code(apperror.ErrStaleVersion))
+	assert.Equal(t, apperror.CodeInternal, apperror.Code(errors.New("pq: connection reset")))
+	assert.Equal(t, "CUSTOM", apperror.Code(apperror.NewAppError("CUSTOM", "custom", apperror.ErrConflict)))
+}
+
+func TestStaleVersion(t *testing.T) {
+	err := fmt.Errorf("%w: order 3 is at version 4", apperror.ErrStaleVersion)
+
+	assert.Equal(t, fiber.StatusConflict, apperror.StatusCode(err))
+	assert.Equal(t, codes.Aborted, apperror.GRPCCode(err))
+	assert.Equal(t, apperror.CodeStaleVersion, apperror.FromStatus(status.Convert(apperror.GRPCError(err))).Code)
+}
+
+func TestPreconditionRequired(t *testing.T) {
+	err := fmt.Errorf("%w: send If-Match", apperror.ErrPreconditionRequired)
+
+	assert.Equal(t, fiber.StatusPreconditionRequired, apperror.StatusCode(err))
+	assert.Equal(t, codes.FailedPrecondition, apperror.GRPCCode(err))
+	assert.Equal(t, apperror.CodePreconditionRequired, apperror.FromStatus(status.Convert(apperror.GRPCError(err))).Code)
+}
+
+func TestFrom(t *testing.T) {
+	envelope := apperror.From(fieldsError{})
+
+	assert.Equal(t, apperror.CodeInvalidData, envelope.Code)
+	assert.Equal(t, "invalid data: name is required", envelope.Message)
+	assert.Equal(t, fieldsError{}.FieldErrors(), envelope.Fields)
+}
+
+func TestFrom_MasksInternalErrors(t *testing.T) {
+	apperror.SetMaskInternal(true)
+	t.Cleanup(func() { apperror.SetMaskInternal(false) })
+
+	assert.Equal(t, "internal server error", apperror.From(errors.New("pq: password authentication failed")).Message)
+	assert.Equal(t, "forbidden", apperror.From(apperror.ErrForbidden).Message)
+}
+
+func TestGRPCError_RoundTrip(t *testing.T) {
+	err := apperror.WithRequestID(apperror.GRPCError(fieldsError{}), "req-1")
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 3)
+	info := st.Details()[0].(*errdetails.ErrorInfo)
+	assert.Equal(t, apperror.CodeInvalidData, info.Reason)
+
+	envelope := apperror.FromStatus(st)
+	assert.Equal(t, apperror.CodeInvalidData, envelope.Code)
+	assert.Equal(t, "invalid data: name is required", envelope.Message)
+	assert.Equal(t, fieldsError{}.FieldErrors(), envelope.Fields)
+	assert.Equal(t, "req-1", envelope.RequestID)
+}
+
+func TestWithRequestID_PlainStatus(t *testing.T) {
+	err := apperror.WithRequestID(status.Error(codes.Unauthenticated, "missing token"), "req-2")
+
+	envelope := apperror.FromStatus(status.Convert(err))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, apperror.CodeUnauthorized, envelope.Code)
+	assert.Equal(t, "missing token", envelope.Message)
+	assert.Equal(t, "req-2", envelope.RequestID)
+}
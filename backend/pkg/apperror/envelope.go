@@ -0,0 +1,31 @@
+package apperror
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maskInternal hides the message of internal errors from clients, see SetMaskInternal
+var maskInternal atomic.Bool
+
+// SetMaskInternal replaces the message of internal errors with a generic one.
+// It is on in production so raw database or library errors never reach clients.
+func SetMaskInternal(mask bool) {
+	maskInternal.Store(mask)
+}
+
+// From builds the envelope sent to clients for err
+func From(err error) *AppError {
+	appErr := &AppError{Code: Code(err), Message: err.Error(), Err: err}
+
+	var fields FieldErrors
+	if errors.As(err, &fields) {
+		appErr.Fields = fields.FieldErrors()
+	}
+	if maskInternal.Load() && StatusCode(err) == fiber.StatusInternalServerError {
+		appErr.Message = ErrInternalServer.Error()
+	}
+	return appErr
+}
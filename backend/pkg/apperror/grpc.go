@@ -0,0 +1,103 @@
+package apperror
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain names this service in the ErrorInfo of gRPC errors
+const errorDomain = "mess-management"
+
+// GRPCError converts err to a gRPC status with the GRPCCode of err. The
+// envelope travels in the details: an ErrorInfo whose reason is the Code and
+// a BadRequest listing the failed fields, if any.
+func GRPCError(err error) error {
+	return From(err).grpcStatus(GRPCCode(err)).Err()
+}
+
+// WithRequestID attaches requestID to a gRPC error as a RequestInfo detail,
+// converting errors that are not a status yet with GRPCError
+func WithRequestID(err error, requestID string) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		st = status.Convert(GRPCError(err))
+	}
+	appErr := FromStatus(st)
+	appErr.RequestID = requestID
+	return appErr.grpcStatus(st.Code()).Err()
+}
+
+// FromStatus restores the envelope carried by st. Statuses created without
+// details get the Code closest to their gRPC code.
+func FromStatus(st *status.Status) *AppError {
+	appErr := &AppError{Code: codeFromGRPC(st.Code()), Message: st.Message(), Err: st.Err()}
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			appErr.Code = detail.Reason
+		case *errdetails.BadRequest:
+			for _, violation := range detail.FieldViolations {
+				appErr.Fields = append(appErr.Fields, FieldError{
+					Field:   violation.Field,
+					Rule:    violation.Reason,
+					Message: violation.Description,
+				})
+			}
+		case *errdetails.RequestInfo:
+			appErr.RequestID = detail.RequestId
+		}
+	}
+	return appErr
+}
+
+func (e *AppError) grpcStatus(code codes.Code) *status.Status {
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: e.Code, Domain: errorDomain}}
+	if len(e.Fields) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, len(e.Fields))
+		for i, field := range e.Fields {
+			violations[i] = &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+				Reason:      field.Rule,
+			}
+		}
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	if e.RequestID != "" {
+		details = append(details, &errdetails.RequestInfo{RequestId: e.RequestID})
+	}
+
+	st := status.New(code, e.Message)
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+func codeFromGRPC(code codes.Code) string {
+	switch code {
+	case codes.Unauthenticated:
+		return CodeUnauthorized
+	case codes.PermissionDenied:
+		return CodeForbidden
+	case codes.NotFound:
+		return CodeNotFound
+	case codes.AlreadyExists:
+		return CodeConflict
+	case codes.Aborted:
+		return CodeStaleVersion
+	case codes.InvalidArgument:
+		return CodeInvalidData
+	case codes.FailedPrecondition:
+		return CodeUnprocessable
+	case codes.ResourceExhausted:
+		return CodeLimitExceeded
+	case codes.DeadlineExceeded:
+		return CodeTimeout
+	case codes.Unimplemented:
+		return CodeNotImplemented
+	}
+	return CodeInternal
+}
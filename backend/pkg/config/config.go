@@ -1,8 +1,9 @@
 package config
 
 import (
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"strconv"
 
@@ -21,7 +22,89 @@ type Config struct {
 	DatabaseDSN string
 
 	JWTSecret     string
-	JWTExpiration int // in seconds
+	JWTExpiration int // access token lifetime in seconds
+
+	RefreshTokenTTLHours int // how long a session lasts without signing in again
+
+	AppBaseURL                string // frontend address the emailed links point at
+	EmailVerificationTTLHours int
+	PasswordResetTTLMinutes   int
+
+	LoginMaxAccountFailures   int
+	LoginMaxIPFailures        int
+	LoginLockoutBaseSeconds   int
+	LoginLockoutMaxMinutes    int
+	LoginFailureWindowMinutes int
+
+	OIDCIssuerURL    string // campus sign-in is off when empty
+	OIDCClientID     string
+	OIDCClientSecret string
+	OIDCRedirectURL  string
+
+	FileStoreDir string
+
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+
+	ComplaintAckSLAHours     int
+	ComplaintResolveSLAHours int
+
+	MealRateBreakfast      float64
+	MealRateLunch          float64
+	MealRateDinner         float64
+	CancellationCutoffHour int // cancellations for a day close at this hour on the day before
+
+	// dashboards flag a meal once this many cancellations arrive within the window
+	CancellationSpikeThreshold     int
+	CancellationSpikeWindowMinutes int
+
+	JobBillingSpec        string
+	JobEscalationSpec     string
+	JobCutoffReminderSpec string
+	JobOutboxSpec         string
+	JobSessionCleanupSpec string
+	JobPurgeSpec          string
+	JobRunCleanupSpec     string
+
+	// deleted users, students and orders can be restored for this long, then they are purged
+	SoftDeleteRetentionDays int
+	// finished job runs are kept for this long, the outbox job alone records thousands a day
+	JobRunRetentionDays int
+	// responses to requests sent with an Idempotency-Key are replayed for this long
+	IdempotencyKeyTTLHours int
+
+	// memory counts requests per replica, postgres shares the buckets between replicas
+	RateLimitStore          string
+	RateLimitAuthPerMinute  int
+	RateLimitReadPerMinute  int
+	RateLimitWritePerMinute int
+
+	// spans are exported over OTLP/gRPC to OTelEndpoint when tracing is enabled
+	OTelEnabled     bool
+	OTelEndpoint    string
+	OTelInsecure    bool
+	OTelServiceName string
+	OTelSampleRatio float64
+
+	// LogFormat defaults to json in production and text elsewhere, LogLevels
+	// overrides LogLevel per logger such as "gorm=warn,billing=debug"
+	LogLevel  string
+	LogFormat string
+	LogLevels string
+
+	HealthCheckTimeoutSeconds int
+	// on shutdown /readyz fails for this long before the servers stop, so load
+	// balancers move traffic away first
+	ShutdownDrainSeconds int
+
+	OutboxBatchSize       int
+	OutboxMaxAttempts     int
+	WebhookTimeoutSeconds int
+	// a claimed outbox batch is left to other replicas once this runs out
+	OutboxClaimLeaseSeconds int
 }
 
 func LoadConfig(env string) *Config {
@@ -32,10 +115,10 @@ func LoadConfig(env string) *Config {
 	}
 
 	if err := godotenv.Load(envFile); err != nil {
-		log.Println("No .env file found, using system env", err)
+		slog.Info("No .env file found, using system env", "file", envFile, "error", err)
 	}
 
-	jwtExp := getEnvAsInt("JWT_EXPIRATION", 3600)
+	jwtExp := getEnvAsInt("JWT_EXPIRATION", 900)
 
 	cfg := &Config{
 		AppPort:       getEnv("APP_PORT", "8000"),
@@ -48,6 +131,77 @@ func LoadConfig(env string) *Config {
 		DBName:        getEnv("DB_NAME", "test"),
 		JWTSecret:     getEnv("JWT_SECRET", "changeme"),
 		JWTExpiration: jwtExp,
+
+		RefreshTokenTTLHours: getEnvAsInt("REFRESH_TOKEN_TTL_HOURS", 720),
+
+		AppBaseURL:                getEnv("APP_BASE_URL", "http://localhost:3000"),
+		EmailVerificationTTLHours: getEnvAsInt("EMAIL_VERIFICATION_TTL_HOURS", 48),
+		PasswordResetTTLMinutes:   getEnvAsInt("PASSWORD_RESET_TTL_MINUTES", 60),
+
+		LoginMaxAccountFailures:   getEnvAsInt("LOGIN_MAX_ACCOUNT_FAILURES", 5),
+		LoginMaxIPFailures:        getEnvAsInt("LOGIN_MAX_IP_FAILURES", 20),
+		LoginLockoutBaseSeconds:   getEnvAsInt("LOGIN_LOCKOUT_BASE_SECONDS", 30),
+		LoginLockoutMaxMinutes:    getEnvAsInt("LOGIN_LOCKOUT_MAX_MINUTES", 60),
+		LoginFailureWindowMinutes: getEnvAsInt("LOGIN_FAILURE_WINDOW_MINUTES", 15),
+
+		OIDCIssuerURL:    getEnv("OIDC_ISSUER_URL", ""),
+		OIDCClientID:     getEnv("OIDC_CLIENT_ID", ""),
+		OIDCClientSecret: getEnv("OIDC_CLIENT_SECRET", ""),
+		OIDCRedirectURL:  getEnv("OIDC_REDIRECT_URL", "http://localhost:8000/api/v1/auth/oidc/callback"),
+
+		FileStoreDir: getEnv("FILE_STORE_DIR", "./uploads"),
+
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     getEnv("SMTP_PORT", "25"),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:     getEnv("SMTP_FROM", "mess-office@localhost"),
+
+		ComplaintAckSLAHours:     getEnvAsInt("COMPLAINT_ACK_SLA_HOURS", 24),
+		ComplaintResolveSLAHours: getEnvAsInt("COMPLAINT_RESOLVE_SLA_HOURS", 72),
+
+		MealRateBreakfast:      getEnvAsFloat("MEAL_RATE_BREAKFAST", 30),
+		MealRateLunch:          getEnvAsFloat("MEAL_RATE_LUNCH", 55),
+		MealRateDinner:         getEnvAsFloat("MEAL_RATE_DINNER", 55),
+		CancellationCutoffHour: getEnvAsInt("CANCELLATION_CUTOFF_HOUR", 22),
+
+		CancellationSpikeThreshold:     getEnvAsInt("CANCELLATION_SPIKE_THRESHOLD", 10),
+		CancellationSpikeWindowMinutes: getEnvAsInt("CANCELLATION_SPIKE_WINDOW_MINUTES", 30),
+
+		JobBillingSpec:        getEnv("JOB_BILLING_SPEC", "0 2 1 * *"),
+		JobEscalationSpec:     getEnv("JOB_ESCALATION_SPEC", "*/5 * * * *"),
+		JobCutoffReminderSpec: getEnv("JOB_CUTOFF_REMINDER_SPEC", "0 20 * * *"),
+		JobOutboxSpec:         getEnv("JOB_OUTBOX_SPEC", "*/15 * * * * *"),
+		JobSessionCleanupSpec: getEnv("JOB_SESSION_CLEANUP_SPEC", "30 3 * * *"),
+		JobPurgeSpec:          getEnv("JOB_PURGE_SPEC", "0 4 * * *"),
+		JobRunCleanupSpec:     getEnv("JOB_RUN_CLEANUP_SPEC", "15 4 * * *"),
+
+		SoftDeleteRetentionDays: getEnvAsInt("SOFT_DELETE_RETENTION_DAYS", 30),
+		JobRunRetentionDays:     getEnvAsInt("JOB_RUN_RETENTION_DAYS", 7),
+		IdempotencyKeyTTLHours:  getEnvAsInt("IDEMPOTENCY_KEY_TTL_HOURS", 24),
+
+		RateLimitStore:          getEnv("RATE_LIMIT_STORE", "memory"),
+		RateLimitAuthPerMinute:  getEnvAsInt("RATE_LIMIT_AUTH_PER_MINUTE", 30),
+		RateLimitReadPerMinute:  getEnvAsInt("RATE_LIMIT_READ_PER_MINUTE", 600),
+		RateLimitWritePerMinute: getEnvAsInt("RATE_LIMIT_WRITE_PER_MINUTE", 120),
+
+		OTelEnabled:     getEnvAsBool("OTEL_ENABLED", false),
+		OTelEndpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
+		OTelInsecure:    getEnvAsBool("OTEL_EXPORTER_OTLP_INSECURE", true),
+		OTelServiceName: getEnv("OTEL_SERVICE_NAME", "mess-management"),
+		OTelSampleRatio: getEnvAsFloat("OTEL_SAMPLE_RATIO", 1),
+
+		LogLevel:  getEnv("LOG_LEVEL", "info"),
+		LogFormat: getEnv("LOG_FORMAT", ""),
+		LogLevels: getEnv("LOG_LEVELS", "gorm=warn"),
+
+		HealthCheckTimeoutSeconds: getEnvAsInt("HEALTH_CHECK_TIMEOUT_SECONDS", 2),
+		ShutdownDrainSeconds:      getEnvAsInt("SHUTDOWN_DRAIN_SECONDS", 5),
+
+		OutboxBatchSize:         getEnvAsInt("OUTBOX_BATCH_SIZE", 100),
+		OutboxMaxAttempts:       getEnvAsInt("OUTBOX_MAX_ATTEMPTS", 10),
+		WebhookTimeoutSeconds:   getEnvAsInt("WEBHOOK_TIMEOUT_SECONDS", 10),
+		OutboxClaimLeaseSeconds: getEnvAsInt("OUTBOX_CLAIM_LEASE_SECONDS", 300),
 	}
 
 	cfg.DatabaseDSN = fmt.Sprintf(
@@ -55,9 +209,25 @@ func LoadConfig(env string) *Config {
 		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName,
 	)
 
+	if cfg.LogFormat == "" {
+		cfg.LogFormat = "text"
+		if cfg.AppEnv == "production" {
+			cfg.LogFormat = "json"
+		}
+	}
+
 	return cfg
 }
 
+// Validate rejects settings the server must not start with
+func (c *Config) Validate() error {
+	// without a relay no verification or reset email would ever arrive
+	if c.AppEnv == "production" && c.SMTPHost == "" {
+		return errors.New("SMTP_HOST must be set when APP_ENV=production")
+	}
+	return nil
+}
+
 func getEnv(key, fallback string) string {
 	if val := os.Getenv(key); val != "" {
 		return val
@@ -73,3 +243,21 @@ func getEnvAsInt(key string, fallback int) int {
 	}
 	return fallback
 }
+
+func getEnvAsFloat(key string, fallback float64) float64 {
+	if val := os.Getenv(key); val != "" {
+		if parsed, err := strconv.ParseFloat(val, 64); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
+
+func getEnvAsBool(key string, fallback bool) bool {
+	if val := os.Getenv(key); val != "" {
+		if parsed, err := strconv.ParseBool(val); err == nil {
+			return parsed
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,59 @@
+// Package etag turns row versions into ETag headers and reads them back from
+// If-Match, so PATCH requests can only apply on top of the version they read.
+package etag
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// HeaderMetadata carries the ETag of a gRPC response, the gateway
+	// writes it back as the ETag header
+	HeaderMetadata = "etag"
+	// IfMatchMetadata carries the If-Match header forwarded by the gateway
+	IfMatchMetadata = "if-match"
+)
+
+// Format returns the strong ETag of a version
+func Format(version uint) string {
+	return strconv.Quote(strconv.FormatUint(uint64(version), 10))
+}
+
+// Parse reads the version out of an If-Match header. An empty header or "*"
+// returns 0, which names no version, so PATCH refuses it.
+func Parse(header string) (uint, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	// weak validators compare equal for our purposes
+	header = strings.TrimPrefix(header, "W/")
+	version, err := strconv.ParseUint(strings.Trim(header, `"`), 10, 32)
+	if err != nil || version == 0 {
+		return 0, fmt.Errorf("%w: If-Match must be an ETag returned by the server", apperror.ErrInvalidFormat)
+	}
+	return uint(version), nil
+}
+
+// FromIncomingContext reads the version out of the If-Match header the
+// gateway forwarded, 0 when there is none
+func FromIncomingContext(ctx context.Context) (uint, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(IfMatchMetadata)
+	if len(values) == 0 {
+		return 0, nil
+	}
+	return Parse(values[0])
+}
+
+// SetHeader sends the ETag of version with the gRPC response headers
+func SetHeader(ctx context.Context, version uint) {
+	_ = grpc.SetHeader(ctx, metadata.Pairs(HeaderMetadata, Format(version)))
+}
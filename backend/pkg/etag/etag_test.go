@@ -0,0 +1,45 @@
+package etag_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/etag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestFormatAndParse(t *testing.T) {
+	assert.Equal(t, `"7"`, etag.Format(7))
+
+	for header, want := range map[string]uint{
+		"":       0,
+		"*":      0,
+		`"7"`:    7,
+		`W/"7"`:  7,
+		" 12 ":   12,
+		`"4242"`: 4242,
+	} {
+		version, err := etag.Parse(header)
+		require.NoError(t, err, header)
+		assert.Equal(t, want, version, header)
+	}
+
+	for _, header := range []string{`"abc"`, `"0"`, `"-1"`, `"1", "2"`} {
+		_, err := etag.Parse(header)
+		assert.ErrorIs(t, err, apperror.ErrInvalidFormat, header)
+	}
+}
+
+func TestFromIncomingContext(t *testing.T) {
+	version, err := etag.FromIncomingContext(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, version)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(etag.IfMatchMetadata, `"3"`))
+	version, err = etag.FromIncomingContext(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), version)
+}
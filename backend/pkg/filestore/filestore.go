@@ -0,0 +1,19 @@
+package filestore
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	ErrNotFound   = errors.New("file not found")
+	ErrInvalidKey = errors.New("invalid file key")
+)
+
+// FileStore persists uploaded files under opaque keys so the storage backend
+// (local disk, object storage, ...) can be swapped without touching usecases
+type FileStore interface {
+	Save(key string, r io.Reader) (int64, error)
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
@@ -0,0 +1,59 @@
+package filestore_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/filestore"
+	"github.com/stretchr/testify/suite"
+)
+
+type LocalFileStoreTestSuite struct {
+	suite.Suite
+	store filestore.FileStore
+}
+
+func (s *LocalFileStoreTestSuite) SetupTest() {
+	s.store = filestore.NewLocalFileStore(s.T().TempDir())
+}
+
+func TestLocalFileStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(LocalFileStoreTestSuite))
+}
+
+func (s *LocalFileStoreTestSuite) TestSaveAndOpen() {
+	n, err := s.store.Save("complaints/1/photo.jpg", strings.NewReader("image-bytes"))
+	s.NoError(err)
+	s.Equal(int64(len("image-bytes")), n)
+
+	rc, err := s.store.Open("complaints/1/photo.jpg")
+	s.NoError(err)
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	s.NoError(err)
+	s.Equal("image-bytes", string(content))
+}
+
+func (s *LocalFileStoreTestSuite) TestOpen_NotFound() {
+	rc, err := s.store.Open("complaints/1/missing.jpg")
+	s.Nil(rc)
+	s.ErrorIs(err, filestore.ErrNotFound)
+}
+
+func (s *LocalFileStoreTestSuite) TestDelete() {
+	_, err := s.store.Save("complaints/2/report.pdf", strings.NewReader("pdf"))
+	s.NoError(err)
+
+	s.NoError(s.store.Delete("complaints/2/report.pdf"))
+	s.ErrorIs(s.store.Delete("complaints/2/report.pdf"), filestore.ErrNotFound)
+}
+
+func (s *LocalFileStoreTestSuite) TestRejectsKeysOutsideBaseDir() {
+	_, err := s.store.Save("../escape.txt", strings.NewReader("x"))
+	s.ErrorIs(err, filestore.ErrInvalidKey)
+
+	_, err = s.store.Save("/etc/passwd", strings.NewReader("x"))
+	s.ErrorIs(err, filestore.ErrInvalidKey)
+}
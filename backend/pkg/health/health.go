@@ -0,0 +1,167 @@
+// Package health tells orchestrators whether the service can take traffic.
+// Checks probe its dependencies, the REST probes and the gRPC health service
+// report their result, and Drain takes the service out of rotation before it
+// shuts down.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	grpchealth "google.golang.org/grpc/health"
+)
+
+type Status string
+
+const (
+	StatusUp       Status = "up"
+	StatusDown     Status = "down"
+	StatusDraining Status = "draining"
+)
+
+// A Check probes one dependency. Its details, such as a schema version, are
+// reported next to its status, an error marks it down.
+type Check func(ctx context.Context) (map[string]any, error)
+
+type CheckResult struct {
+	Status  Status         `json:"status"`
+	Error   string         `json:"error,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+type Report struct {
+	Status Status                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Up reports whether the service can take traffic
+func (r Report) Up() bool {
+	return r.Status == StatusUp
+}
+
+type Checker struct {
+	timeout time.Duration // per run of every check
+
+	mu       sync.RWMutex
+	checks   map[string]Check
+	mirror   *grpchealth.Server
+	draining bool
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout, checks: make(map[string]Check)}
+}
+
+// Add registers check under name, replacing any check of that name
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = check
+}
+
+// Mirror keeps every service of server SERVING while the service is ready and
+// NOT_SERVING otherwise. The status is updated whenever readiness is checked.
+func (c *Checker) Mirror(server *grpchealth.Server) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mirror = server
+	if c.draining {
+		server.Shutdown()
+	}
+}
+
+// Check runs every check at once and reports the service up when all of them pass
+func (c *Checker) Check(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	c.mu.RLock()
+	checks := make(map[string]Check, len(c.checks))
+	for name, check := range c.checks {
+		checks[name] = check
+	}
+	c.mu.RUnlock()
+
+	report := Report{Status: StatusUp, Checks: make(map[string]CheckResult, len(checks))}
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := CheckResult{Status: StatusUp}
+			details, err := check(ctx)
+			result.Details = details
+			if err != nil {
+				result.Status, result.Error = StatusDown, err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if err != nil {
+				report.Status = StatusDown
+			}
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+// Ready is Check, except that a draining service is never ready. The result
+// is copied to the mirrored gRPC health server.
+func (c *Checker) Ready(ctx context.Context) Report {
+	report := c.Check(ctx)
+
+	// held until the mirror is updated, a check that started before Drain
+	// must not mark the server SERVING again
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.draining {
+		report.Status = StatusDraining
+	}
+	if c.mirror != nil {
+		if report.Up() {
+			c.mirror.Resume()
+		} else {
+			c.mirror.Shutdown()
+		}
+	}
+	return report
+}
+
+// Watch checks readiness every interval until ctx is done, so the gRPC health
+// service follows dependencies nobody asked /readyz about
+func (c *Checker) Watch(ctx context.Context, every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Ready(ctx)
+		}
+	}
+}
+
+// Drain reports the service not ready from now on, load balancers stop
+// sending it new requests while the ones in flight finish
+func (c *Checker) Drain() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.draining = true
+	if c.mirror != nil {
+		c.mirror.Shutdown()
+	}
+}
+
+// Draining reports whether Drain has been called
+func (c *Checker) Draining() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.draining
+}
@@ -0,0 +1,99 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func up(ctx context.Context) (map[string]any, error) {
+	return map[string]any{"schema_version": 1}, nil
+}
+
+func down(ctx context.Context) (map[string]any, error) {
+	return nil, errors.New("connection refused")
+}
+
+func servingStatus(t *testing.T, server *grpchealth.Server, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+	return resp.Status
+}
+
+func TestChecker_Check(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Add("database", up)
+
+	report := checker.Check(context.Background())
+	assert.True(t, report.Up())
+	assert.Equal(t, health.StatusUp, report.Checks["database"].Status)
+	assert.Equal(t, 1, report.Checks["database"].Details["schema_version"])
+
+	checker.Add("mailer", down)
+	report = checker.Check(context.Background())
+	assert.Equal(t, health.StatusDown, report.Status)
+	assert.Equal(t, health.StatusUp, report.Checks["database"].Status)
+	assert.Equal(t, health.CheckResult{Status: health.StatusDown, Error: "connection refused"}, report.Checks["mailer"])
+}
+
+func TestChecker_Timeout(t *testing.T) {
+	checker := health.NewChecker(10 * time.Millisecond)
+	checker.Add("database", func(ctx context.Context) (map[string]any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	report := checker.Check(context.Background())
+	assert.Equal(t, health.StatusDown, report.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["database"].Error)
+}
+
+func TestChecker_Drain(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Add("database", up)
+	server := grpchealth.NewServer()
+	server.SetServingStatus("order.OrderService", healthpb.HealthCheckResponse_SERVING)
+	checker.Mirror(server)
+
+	assert.True(t, checker.Ready(context.Background()).Up())
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus(t, server, "order.OrderService"))
+
+	checker.Drain()
+	assert.True(t, checker.Draining())
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, server, "order.OrderService"))
+
+	// the dependencies are still fine, the server is on its way out
+	assert.True(t, checker.Check(context.Background()).Up())
+	report := checker.Ready(context.Background())
+	assert.Equal(t, health.StatusDraining, report.Status)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, server, ""))
+}
+
+func TestChecker_MirrorFollowsChecks(t *testing.T) {
+	failing := false
+	checker := health.NewChecker(time.Second)
+	checker.Add("database", func(ctx context.Context) (map[string]any, error) {
+		if failing {
+			return down(ctx)
+		}
+		return up(ctx)
+	})
+	server := grpchealth.NewServer()
+	checker.Mirror(server)
+
+	failing = true
+	assert.False(t, checker.Ready(context.Background()).Up())
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, server, ""))
+
+	failing = false
+	assert.True(t, checker.Ready(context.Background()).Up())
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus(t, server, ""))
+}
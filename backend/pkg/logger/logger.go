@@ -0,0 +1,153 @@
+// Package logger configures log/slog for the service. Loggers are named after
+// the package they log for, each name can have its own level, and records
+// logged with a context carry its request ID and trace.
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Config struct {
+	Level  string // debug, info, warn or error
+	Format string // text or json
+	// Levels overrides Level per logger name, such as "billing=debug,gorm=info"
+	Levels string
+}
+
+type requestIDKey struct{}
+
+var (
+	mu           sync.RWMutex
+	output       slog.Handler = slog.NewTextHandler(os.Stderr, nil)
+	defaultLevel              = slog.LevelInfo
+	levels                    = map[string]slog.Level{}
+)
+
+// Setup installs the configuration for every logger, including those created
+// before it, and routes slog.Default and the standard log package through it
+func Setup(cfg Config, w io.Writer) error {
+	level, err := parseLevel(cfg.Level)
+	if err != nil {
+		return err
+	}
+	overrides := map[string]slog.Level{}
+	for _, entry := range strings.Split(cfg.Levels, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return fmt.Errorf("log level %q is not name=level", entry)
+		}
+		if overrides[strings.TrimSpace(name)], err = parseLevel(value); err != nil {
+			return err
+		}
+	}
+
+	// the handlers log everything, levels are checked per logger
+	options := &slog.HandlerOptions{Level: slog.LevelDebug - 4}
+	var handler slog.Handler
+	switch strings.ToLower(cfg.Format) {
+	case "json":
+		handler = slog.NewJSONHandler(w, options)
+	case "text", "":
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+
+	mu.Lock()
+	output, defaultLevel, levels = handler, level, overrides
+	mu.Unlock()
+	slog.SetDefault(For(""))
+	return nil
+}
+
+// For returns the logger of name, its records carry it as "logger"
+func For(name string) *slog.Logger {
+	return slog.New(&namedHandler{name: name})
+}
+
+// WithRequestID returns a context whose log records carry requestID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the request ID stored by WithRequestID, "" when there is none
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// namedHandler writes to the configured output at the level of its name. It
+// looks the configuration up on every record, so loggers kept in package
+// variables follow Setup.
+type namedHandler struct {
+	name string
+	// with replays WithAttrs and WithGroup calls on the output
+	with []func(slog.Handler) slog.Handler
+}
+
+func (h *namedHandler) Enabled(_ context.Context, level slog.Level) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	minimum, ok := levels[h.name]
+	if !ok {
+		minimum = defaultLevel
+	}
+	return level >= minimum
+}
+
+func (h *namedHandler) Handle(ctx context.Context, record slog.Record) error {
+	mu.RLock()
+	handler := output
+	mu.RUnlock()
+
+	if h.name != "" {
+		record.AddAttrs(slog.String("logger", h.name))
+	}
+	if requestID := RequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		record.AddAttrs(slog.String("trace_id", span.TraceID().String()), slog.String("span_id", span.SpanID().String()))
+	}
+	for _, with := range h.with {
+		handler = with(handler)
+	}
+	return handler.Handle(ctx, record)
+}
+
+func (h *namedHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.chain(func(handler slog.Handler) slog.Handler { return handler.WithAttrs(attrs) })
+}
+
+func (h *namedHandler) WithGroup(name string) slog.Handler {
+	return h.chain(func(handler slog.Handler) slog.Handler { return handler.WithGroup(name) })
+}
+
+func (h *namedHandler) chain(with func(slog.Handler) slog.Handler) slog.Handler {
+	chained := make([]func(slog.Handler) slog.Handler, len(h.with), len(h.with)+1)
+	copy(chained, h.with)
+	return &namedHandler{name: h.name, with: append(chained, with)}
+}
+
+// parseLevel reads a level name, "" is info
+func parseLevel(value string) (slog.Level, error) {
+	var level slog.Level
+	if strings.TrimSpace(value) == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(value))); err != nil {
+		return 0, fmt.Errorf("unknown log level %q", value)
+	}
+	return level, nil
+}
@@ -0,0 +1,97 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setup(t *testing.T, cfg logger.Config) *bytes.Buffer {
+	t.Helper()
+	var out bytes.Buffer
+	require.NoError(t, logger.Setup(cfg, &out))
+	t.Cleanup(func() { _ = logger.Setup(logger.Config{}, os.Stderr) })
+	return &out
+}
+
+func records(t *testing.T, out *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		lines = append(lines, record)
+	}
+	return lines
+}
+
+func TestSetup_JSON(t *testing.T) {
+	out := setup(t, logger.Config{Format: "json"})
+
+	logger.For("billing").With("month", "2026-01").Info("Generated monthly bills", "count", 3)
+
+	lines := records(t, out)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "INFO", lines[0]["level"])
+	assert.Equal(t, "Generated monthly bills", lines[0]["msg"])
+	assert.Equal(t, "billing", lines[0]["logger"])
+	assert.Equal(t, "2026-01", lines[0]["month"])
+	assert.Equal(t, float64(3), lines[0]["count"])
+}
+
+func TestSetup_Levels(t *testing.T) {
+	// loggers created before Setup follow it
+	gorm, billing := logger.For("gorm"), logger.For("billing")
+	out := setup(t, logger.Config{Level: "info", Format: "json", Levels: "gorm=warn, billing=debug"})
+
+	gorm.Info("SQL executed")
+	gorm.Warn("SQL executed slowly")
+	billing.Debug("Pricing meals")
+	logger.For("auth").Debug("Checking token")
+
+	lines := records(t, out)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "SQL executed slowly", lines[0]["msg"])
+	assert.Equal(t, "Pricing meals", lines[1]["msg"])
+}
+
+func TestSetup_Invalid(t *testing.T) {
+	var out bytes.Buffer
+	assert.Error(t, logger.Setup(logger.Config{Level: "loud"}, &out))
+	assert.Error(t, logger.Setup(logger.Config{Format: "xml"}, &out))
+	assert.Error(t, logger.Setup(logger.Config{Levels: "gorm"}, &out))
+	assert.Error(t, logger.Setup(logger.Config{Levels: "gorm=loud"}, &out))
+}
+
+func TestContext(t *testing.T) {
+	out := setup(t, logger.Config{Format: "json"})
+	span := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(logger.WithRequestID(context.Background(), "req-1"), span)
+	assert.Equal(t, "req-1", logger.RequestID(ctx))
+	assert.Empty(t, logger.RequestID(context.Background()))
+
+	logger.For("orders").InfoContext(ctx, "Order placed")
+	logger.For("orders").Info("Order placed")
+
+	lines := records(t, out)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "req-1", lines[0]["request_id"])
+	assert.Equal(t, span.TraceID().String(), lines[0]["trace_id"])
+	assert.Equal(t, span.SpanID().String(), lines[0]["span_id"])
+	assert.NotContains(t, lines[1], "request_id")
+}
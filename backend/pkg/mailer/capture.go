@@ -0,0 +1,67 @@
+package mailer
+
+import (
+	"sync"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/logger"
+)
+
+var log = logger.For("mailer")
+
+// maxCapturedMessages bounds the memory a long running CaptureSender holds,
+// older messages are dropped first
+const maxCapturedMessages = 100
+
+// CaptureSender keeps the latest messages in memory instead of delivering
+// them. It backs tests and local development without an SMTP relay and must
+// not be used in production, where emails carry live sign-in links.
+type CaptureSender struct {
+	mu          sync.Mutex
+	messages    []Message
+	logMessages bool
+}
+
+// NewCaptureSender also logs the recipients and subject of every message when
+// logMessages is set. Bodies are never logged, they hold tokens.
+func NewCaptureSender(logMessages bool) *CaptureSender {
+	return &CaptureSender{logMessages: logMessages}
+}
+
+func (s *CaptureSender) Send(msg Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+
+	s.mu.Lock()
+	if len(s.messages) == maxCapturedMessages {
+		s.messages = append(s.messages[:0], s.messages[1:]...)
+	}
+	s.messages = append(s.messages, msg)
+	s.mu.Unlock()
+
+	if s.logMessages {
+		log.Info("Captured email", "to", msg.To, "subject", msg.Subject)
+	}
+	return nil
+}
+
+// Messages returns the captured messages, oldest first
+func (s *CaptureSender) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// Last returns the most recent message sent to the address
+func (s *CaptureSender) Last(to string) (Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.messages) - 1; i >= 0; i-- {
+		for _, addr := range s.messages[i].To {
+			if addr == to {
+				return s.messages[i], true
+			}
+		}
+	}
+	return Message{}, false
+}
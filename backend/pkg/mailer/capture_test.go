@@ -0,0 +1,38 @@
+package mailer_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/mailer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureSender(t *testing.T) {
+	sender := mailer.NewCaptureSender(false)
+
+	require.NoError(t, sender.Send(mailer.Message{To: []string{"a@example.com"}, Subject: "first"}))
+	require.NoError(t, sender.Send(mailer.Message{To: []string{"b@example.com"}, Subject: "second"}))
+	require.NoError(t, sender.Send(mailer.Message{To: []string{"a@example.com"}, Subject: "third"}))
+	assert.ErrorIs(t, sender.Send(mailer.Message{Subject: "nobody"}), mailer.ErrNoRecipients)
+
+	assert.Len(t, sender.Messages(), 3)
+	last, ok := sender.Last("a@example.com")
+	assert.True(t, ok)
+	assert.Equal(t, "third", last.Subject)
+	_, ok = sender.Last("c@example.com")
+	assert.False(t, ok)
+}
+
+func TestCaptureSender_KeepsLatestMessages(t *testing.T) {
+	sender := mailer.NewCaptureSender(false)
+	for i := 0; i < 150; i++ {
+		require.NoError(t, sender.Send(mailer.Message{To: []string{"a@example.com"}, Subject: fmt.Sprint(i)}))
+	}
+
+	messages := sender.Messages()
+	assert.Len(t, messages, 100)
+	assert.Equal(t, "50", messages[0].Subject)
+	assert.Equal(t, "149", messages[99].Subject)
+}
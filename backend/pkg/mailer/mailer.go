@@ -0,0 +1,13 @@
+package mailer
+
+// Message is a plain-text email
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Sender delivers email messages through some transport
+type Sender interface {
+	Send(msg Message) error
+}
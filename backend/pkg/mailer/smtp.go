@@ -0,0 +1,46 @@
+package mailer
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+var ErrNoRecipients = errors.New("mailer: no recipients")
+
+// SMTPSender sends mail through an SMTP relay
+type SMTPSender struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewSMTPSender uses PLAIN auth when a username is configured
+func NewSMTPSender(host, port, username, password, from string) Sender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPSender{addr: net.JoinHostPort(host, port), auth: auth, from: from}
+}
+
+func (s *SMTPSender) Send(msg Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+	return smtp.SendMail(s.addr, s.auth, s.from, msg.To, s.build(msg))
+}
+
+func (s *SMTPSender) build(msg Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", s.from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
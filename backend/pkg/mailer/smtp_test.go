@@ -0,0 +1,134 @@
+package mailer_test
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/mailer"
+	"github.com/stretchr/testify/suite"
+)
+
+// smtpStub is a minimal SMTP server accepting a single message per connection
+type smtpStub struct {
+	listener net.Listener
+	received chan stubMessage
+}
+
+type stubMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+func newSMTPStub() (*smtpStub, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	stub := &smtpStub{listener: l, received: make(chan stubMessage, 1)}
+	go stub.serve()
+	return stub, nil
+}
+
+func (s *smtpStub) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *smtpStub) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+	var msg stubMessage
+	reply("220 stub ready")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.TrimSpace(line)
+		upper := strings.ToUpper(cmd)
+		switch {
+		case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+			reply("250 stub")
+		case strings.HasPrefix(upper, "MAIL FROM:"):
+			msg.from = strings.Trim(cmd[len("MAIL FROM:"):], "<> ")
+			reply("250 OK")
+		case strings.HasPrefix(upper, "RCPT TO:"):
+			msg.to = append(msg.to, strings.Trim(cmd[len("RCPT TO:"):], "<> "))
+			reply("250 OK")
+		case upper == "DATA":
+			reply("354 end with <CRLF>.<CRLF>")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			msg.data = data.String()
+			s.received <- msg
+			reply("250 queued")
+		case upper == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+type SMTPSenderTestSuite struct {
+	suite.Suite
+	stub   *smtpStub
+	sender mailer.Sender
+}
+
+func (s *SMTPSenderTestSuite) SetupTest() {
+	stub, err := newSMTPStub()
+	s.Require().NoError(err)
+	s.stub = stub
+
+	host, port, _ := net.SplitHostPort(stub.listener.Addr().String())
+	s.sender = mailer.NewSMTPSender(host, port, "", "", "mess@example.com")
+}
+
+func (s *SMTPSenderTestSuite) TearDownTest() {
+	s.stub.listener.Close()
+}
+
+func TestSMTPSenderTestSuite(t *testing.T) {
+	suite.Run(t, new(SMTPSenderTestSuite))
+}
+
+func (s *SMTPSenderTestSuite) TestSend() {
+	err := s.sender.Send(mailer.Message{
+		To:      []string{"student@example.com"},
+		Subject: "Bill generated",
+		Body:    "Your bill is ready.\nAmount: 1200",
+	})
+	s.NoError(err)
+
+	msg := <-s.stub.received
+	s.Equal("mess@example.com", msg.from)
+	s.Equal([]string{"student@example.com"}, msg.to)
+	s.Contains(msg.data, "Subject: Bill generated\r\n")
+	s.Contains(msg.data, "Your bill is ready.\r\nAmount: 1200")
+}
+
+func (s *SMTPSenderTestSuite) TestSend_NoRecipients() {
+	err := s.sender.Send(mailer.Message{Subject: "nobody"})
+	s.ErrorIs(err, mailer.ErrNoRecipients)
+}
@@ -1,20 +1,33 @@
 package middleware
 
 import (
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/google/uuid"
 )
 
 // LoadCommon sets common global middleware for the app
 func FiberMiddleware(app *fiber.App) {
 	app.Use(
 
-		logger.New(), // Logs all requests
+		// Tags every request with the caller's X-Request-ID or a new one,
+		// error responses repeat it
+		requestid.New(requestid.Config{
+			Generator:  uuid.NewString,
+			ContextKey: responses.RequestIDKey,
+		}),
+
+		RequestContext(),
+		Tracing(),
+		Metrics(),
+		RequestLogger(),
 
 		cors.New(cors.Config{
-			AllowOrigins: "*", // need to be changed in production
-			AllowHeaders: "Origin, Content-Type, Accept, Authorization",
+			AllowOrigins:  "*", // need to be changed in production
+			AllowHeaders:  "Origin, Content-Type, Accept, Authorization, X-Request-ID, If-Match, Idempotency-Key, traceparent, tracestate",
+			ExposeHeaders: "X-Request-ID, ETag, Idempotent-Replayed, Retry-After, X-RateLimit-Limit, X-RateLimit-Remaining",
 		}),
 	)
 }
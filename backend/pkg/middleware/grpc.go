@@ -0,0 +1,281 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/logger"
+	"github.com/ePSA-eJya/Mess_Management/pkg/token"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type claimsKey struct{}
+
+// RequestIDMetadata carries the request ID in both directions, the REST
+// gateway forwards X-Request-ID under it
+const RequestIDMetadata = "x-request-id"
+
+// publicServices are served without a token so load balancers and tooling can
+// probe the server
+var publicServices = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+// GRPCServerOptions chains the interceptors every gRPC service runs behind,
+// logging and metrics wrap recovery so recovered panics are logged and counted
+// with their final code. Every call is traced, as a child of the traceparent
+// metadata when the caller sent one.
+func GRPCServerOptions(tokens *token.Manager, revocations RevocationChecker, idempotency IdempotencyStore, limits RateLimits) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			GRPCRequestIDInterceptor(),
+			GRPCLoggingInterceptor(),
+			GRPCMetricsInterceptor(),
+			GRPCRecoveryInterceptor(),
+			GRPCAuthInterceptor(tokens, revocations),
+			GRPCRateLimitInterceptor(limits),
+			GRPCIdempotencyInterceptor(idempotency),
+		),
+		grpc.ChainStreamInterceptor(
+			GRPCStreamRequestIDInterceptor(),
+			GRPCStreamLoggingInterceptor(),
+			GRPCStreamMetricsInterceptor(),
+			GRPCStreamRecoveryInterceptor(),
+			GRPCStreamAuthInterceptor(tokens, revocations),
+			GRPCStreamRateLimitInterceptor(limits),
+		),
+	}
+}
+
+// GRPCAuthInterceptor is the gRPC counterpart of JWTMiddleware, it expects the
+// same access token as "authorization: Bearer <token>" metadata
+func GRPCAuthInterceptor(tokens *token.Manager, revocations RevocationChecker) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		claims, err := authenticate(ctx, tokens, revocations)
+		if err != nil {
+			return nil, err
+		}
+		return handler(context.WithValue(ctx, claimsKey{}, claims), req)
+	}
+}
+
+// GRPCStreamAuthInterceptor authenticates streaming calls the same way as GRPCAuthInterceptor
+func GRPCStreamAuthInterceptor(tokens *token.Manager, revocations RevocationChecker) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isPublic(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		claims, err := authenticate(ss.Context(), tokens, revocations)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: context.WithValue(ss.Context(), claimsKey{}, claims)})
+	}
+}
+
+// GRPCRequestIDInterceptor tags every unary call with the caller's
+// x-request-id or a new one. The ID is echoed in the response header and
+// attached to returned errors together with their error code.
+func GRPCRequestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, requestID := withRequestID(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadata, requestID))
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, apperror.WithRequestID(err, requestID)
+		}
+		return resp, nil
+	}
+}
+
+// GRPCStreamRequestIDInterceptor is the streaming counterpart of GRPCRequestIDInterceptor
+func GRPCStreamRequestIDInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, requestID := withRequestID(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs(RequestIDMetadata, requestID))
+
+		if err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx}); err != nil {
+			return apperror.WithRequestID(err, requestID)
+		}
+		return nil
+	}
+}
+
+// GRPCLoggingInterceptor logs every unary call with its status code and latency
+func GRPCLoggingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// GRPCStreamLoggingInterceptor logs every streaming call once it has finished
+func GRPCStreamLoggingInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logCall(ss.Context(), info.FullMethod, start, err)
+		return err
+	}
+}
+
+// GRPCRecoveryInterceptor turns a panicking handler into a codes.Internal error
+func GRPCRecoveryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// GRPCStreamRecoveryInterceptor turns a panicking stream handler into a codes.Internal error
+func GRPCStreamRecoveryInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ss.Context(), info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// ClaimsFromContext returns the claims of the caller authenticated by the interceptor
+func ClaimsFromContext(ctx context.Context) (*token.Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*token.Claims)
+	return claims, ok
+}
+
+// RequestIDFromContext returns the ID GRPCRequestIDInterceptor tagged the call with
+func RequestIDFromContext(ctx context.Context) string {
+	return logger.RequestID(ctx)
+}
+
+// ClientIPFromContext returns the address of the caller. Calls relayed by the
+// REST gateway arrive from loopback, for those it is the address the gateway
+// appended to x-forwarded-for.
+func ClientIPFromContext(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return host
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("x-forwarded-for"); len(values) > 0 {
+		forwarded := strings.Split(values[len(values)-1], ",")
+		if client := strings.TrimSpace(forwarded[len(forwarded)-1]); client != "" {
+			return client
+		}
+	}
+	return host
+}
+
+// RequireGRPCRole is the gRPC counterpart of RequireRole, it returns nil when
+// the caller authenticated by the interceptor holds one of roles
+func RequireGRPCRole(ctx context.Context, roles ...string) error {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing token")
+	}
+	for _, r := range roles {
+		if claims.Role == r {
+			return nil
+		}
+	}
+	return status.Error(codes.PermissionDenied, "forbidden")
+}
+
+// contextStream carries the authenticated context into stream handlers
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func authenticate(ctx context.Context, tokens *token.Manager, revocations RevocationChecker) (*token.Claims, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing token")
+	}
+	tokenStr, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	claims, err := tokens.Parse(tokenStr)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	revoked, err := revocations.IsRevoked(ctx, claims)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	if revoked {
+		return nil, status.Error(codes.Unauthenticated, "token revoked")
+	}
+	return claims, nil
+}
+
+func withRequestID(ctx context.Context) (context.Context, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	requestID := uuid.NewString()
+	if values := md.Get(RequestIDMetadata); len(values) > 0 && values[0] != "" {
+		requestID = values[0]
+	}
+	return logger.WithRequestID(ctx, requestID), requestID
+}
+
+func isPublic(method string) bool {
+	for _, prefix := range publicServices {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func logCall(ctx context.Context, method string, start time.Time, err error) {
+	attrs := []any{"method", method, "code", status.Code(err).String(), "duration", time.Since(start)}
+	if err != nil {
+		attrs = append(attrs, "error", status.Convert(err).Message())
+	}
+	grpcLog.InfoContext(ctx, "gRPC call", attrs...)
+}
+
+func recovered(ctx context.Context, method string, r interface{}) error {
+	grpcLog.ErrorContext(ctx, "gRPC handler panicked", "method", method, "panic", r, "stack", string(debug.Stack()))
+	return status.Error(codes.Internal, "internal server error")
+}
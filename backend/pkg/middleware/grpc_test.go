@@ -0,0 +1,183 @@
+package middleware_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+	"github.com/ePSA-eJya/Mess_Management/pkg/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type revokedSessions map[string]bool
+
+func (r revokedSessions) IsRevoked(_ context.Context, claims *token.Claims) (bool, error) {
+	return r[claims.SessionID], nil
+}
+
+var unaryInfo = &grpc.UnaryServerInfo{FullMethod: "/order.OrderService/GetOrder"}
+
+func withBearer(value string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", value))
+}
+
+func TestGRPCAuthInterceptor(t *testing.T) {
+	tokens := token.NewManager("secret", time.Minute)
+	valid, _, err := tokens.Issue("user-1", "STUDENT", "session-1")
+	require.NoError(t, err)
+	revoked, _, err := tokens.Issue("user-1", "STUDENT", "session-2")
+	require.NoError(t, err)
+
+	interceptor := middleware.GRPCAuthInterceptor(tokens, revokedSessions{"session-2": true})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		claims, ok := middleware.ClaimsFromContext(ctx)
+		require.True(t, ok)
+		return claims.UserID + "/" + claims.Role, nil
+	}
+
+	resp, err := interceptor(withBearer("Bearer "+valid), nil, unaryInfo, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1/STUDENT", resp)
+
+	for name, ctx := range map[string]context.Context{
+		"missing": context.Background(),
+		"scheme":  withBearer(valid),
+		"garbage": withBearer("Bearer not-a-token"),
+		"revoked": withBearer("Bearer " + revoked),
+	} {
+		_, err := interceptor(ctx, nil, unaryInfo, handler)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), name)
+	}
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeStream) Context() context.Context { return s.ctx }
+
+func TestGRPCStreamAuthInterceptor(t *testing.T) {
+	tokens := token.NewManager("secret", time.Minute)
+	valid, _, err := tokens.Issue("user-1", "SUPER_ADMIN", "session-1")
+	require.NoError(t, err)
+
+	interceptor := middleware.GRPCStreamAuthInterceptor(tokens, revokedSessions{})
+	info := &grpc.StreamServerInfo{FullMethod: "/attendance.AttendanceService/Watch"}
+
+	var role string
+	err = interceptor(nil, &fakeStream{ctx: withBearer("Bearer " + valid)}, info, func(srv interface{}, ss grpc.ServerStream) error {
+		claims, ok := middleware.ClaimsFromContext(ss.Context())
+		require.True(t, ok)
+		role = claims.Role
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "SUPER_ADMIN", role)
+
+	err = interceptor(nil, &fakeStream{ctx: context.Background()}, info, func(srv interface{}, ss grpc.ServerStream) error {
+		t.Fatal("handler must not run without a token")
+		return nil
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestGRPCRecoveryInterceptor(t *testing.T) {
+	_, err := middleware.GRPCRecoveryInterceptor()(context.Background(), nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	err = middleware.GRPCStreamRecoveryInterceptor()(nil, &fakeStream{ctx: context.Background()}, &grpc.StreamServerInfo{}, func(srv interface{}, ss grpc.ServerStream) error {
+		panic("boom")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestGRPCRequestIDInterceptor(t *testing.T) {
+	interceptor := middleware.GRPCRequestIDInterceptor()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(middleware.RequestIDMetadata, "req-1"))
+
+	resp, err := interceptor(ctx, nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return middleware.RequestIDFromContext(ctx), nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "req-1", resp)
+
+	_, err = interceptor(ctx, nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, apperror.ErrRecordNotFound
+	})
+	envelope := apperror.FromStatus(status.Convert(err))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, apperror.CodeNotFound, envelope.Code)
+	assert.Equal(t, "req-1", envelope.RequestID)
+
+	// callers without an ID get a new one
+	resp, err = interceptor(context.Background(), nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return middleware.RequestIDFromContext(ctx), nil
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp)
+}
+
+func TestGRPCLoggingInterceptor_PassesThrough(t *testing.T) {
+	want := status.Error(codes.NotFound, "order not found")
+	_, err := middleware.GRPCLoggingInterceptor()(context.Background(), nil, unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, want
+	})
+	assert.Equal(t, want, err)
+}
+
+func TestGRPCAuthInterceptor_PublicServices(t *testing.T) {
+	interceptor := middleware.GRPCAuthInterceptor(token.NewManager("secret", time.Minute), revokedSessions{})
+	info := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+
+	resp, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "SERVING", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "SERVING", resp)
+}
+
+func TestRequireGRPCRole(t *testing.T) {
+	tokens := token.NewManager("secret", time.Minute)
+	signed, _, err := tokens.Issue("user-1", "MESS_ADMIN", "session-1")
+	require.NoError(t, err)
+
+	var ctx context.Context
+	_, err = middleware.GRPCAuthInterceptor(tokens, revokedSessions{})(withBearer("Bearer "+signed), nil, unaryInfo, func(c context.Context, req interface{}) (interface{}, error) {
+		ctx = c
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, middleware.RequireGRPCRole(ctx, "OFFICE_ADMIN", "MESS_ADMIN"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(middleware.RequireGRPCRole(ctx, "OFFICE_ADMIN")))
+	assert.Equal(t, codes.Unauthenticated, status.Code(middleware.RequireGRPCRole(context.Background(), "OFFICE_ADMIN")))
+}
+
+func TestClientIPFromContext(t *testing.T) {
+	withPeer := func(addr string, md metadata.MD) context.Context {
+		tcp, err := net.ResolveTCPAddr("tcp", addr)
+		require.NoError(t, err)
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: tcp})
+		return metadata.NewIncomingContext(ctx, md)
+	}
+
+	assert.Equal(t, "203.0.113.7", middleware.ClientIPFromContext(withPeer("203.0.113.7:5000", nil)))
+	// relayed by the gateway, which appends the address it was called from
+	relayed := metadata.Pairs("x-forwarded-for", "10.0.0.1, 198.51.100.2")
+	assert.Equal(t, "198.51.100.2", middleware.ClientIPFromContext(withPeer("127.0.0.1:5000", relayed)))
+	// a remote caller cannot pick its own address
+	assert.Equal(t, "203.0.113.7", middleware.ClientIPFromContext(withPeer("203.0.113.7:5000", relayed)))
+	assert.Equal(t, "", middleware.ClientIPFromContext(context.Background()))
+}
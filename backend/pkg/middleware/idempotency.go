@@ -0,0 +1,176 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// IdempotencyKeyHeader marks a request as safe to retry, the gateway
+	// forwards it as IdempotencyKeyMetadata
+	IdempotencyKeyHeader   = "Idempotency-Key"
+	IdempotencyKeyMetadata = "idempotency-key"
+	// IdempotentReplayedHeader is set on responses replayed for a retry
+	IdempotentReplayedHeader   = "Idempotent-Replayed"
+	IdempotentReplayedMetadata = "idempotent-replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotentResponse is what an IdempotencyStore keeps for a key
+type IdempotentResponse struct {
+	RequestHash string // fingerprint of the request first sent with the key
+	Completed   bool   // false while that request is still being handled
+	StatusCode  int
+	Body        []byte
+}
+
+// IdempotencyStore keeps the first successful response per user and key
+type IdempotencyStore interface {
+	// Reserve claims key for userID. When the key is already taken it claims
+	// nothing and returns what is stored for it instead.
+	Reserve(userID, key, requestHash string) (*IdempotentResponse, error)
+	// Complete stores the response of the request that reserved key
+	Complete(userID, key string, statusCode int, body []byte) error
+	// Release frees a key whose request failed, so a retry runs it again
+	Release(userID, key string) error
+}
+
+// Idempotency replays the stored response when a POST is retried with the
+// same Idempotency-Key, it must run after JWTMiddleware. Only 2xx responses
+// are stored, a failed request can be retried with its key.
+func Idempotency(store IdempotencyStore) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(IdempotencyKeyHeader)
+		if key == "" || c.Method() != fiber.MethodPost {
+			return c.Next()
+		}
+		userID := fmt.Sprint(c.Locals("user_id"))
+
+		prior, err := reserveIdempotencyKey(store, userID, key, requestHash(c.Method()+" "+c.OriginalURL(), c.Body()))
+		if err != nil {
+			return responses.Error(c, err)
+		}
+		if prior != nil {
+			c.Set(IdempotentReplayedHeader, "true")
+			c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			return c.Status(prior.StatusCode).Send(prior.Body)
+		}
+
+		if err := c.Next(); err != nil {
+			releaseIdempotencyKey(c.UserContext(), store, userID, key)
+			return err
+		}
+		if status := c.Response().StatusCode(); status >= 200 && status < 300 {
+			if err := store.Complete(userID, key, status, c.Response().Body()); err != nil {
+				log.ErrorContext(c.UserContext(), "Storing idempotent response failed", "key", key, "error", err)
+			}
+		} else {
+			releaseIdempotencyKey(c.UserContext(), store, userID, key)
+		}
+		return nil
+	}
+}
+
+// GRPCIdempotencyInterceptor is the gRPC counterpart of Idempotency, it
+// expects the key as "idempotency-key" metadata and must run after
+// GRPCAuthInterceptor. Only successful responses are stored.
+func GRPCIdempotencyInterceptor(store IdempotencyStore) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		keys := md.Get(IdempotencyKeyMetadata)
+		claims, authenticated := ClaimsFromContext(ctx)
+		message, isProto := req.(proto.Message)
+		if len(keys) == 0 || !authenticated || !isProto {
+			return handler(ctx, req)
+		}
+		key := keys[0]
+
+		body, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+		if err != nil {
+			return nil, apperror.GRPCError(err)
+		}
+		prior, err := reserveIdempotencyKey(store, claims.UserID, key, requestHash(info.FullMethod, body))
+		if err != nil {
+			return nil, apperror.GRPCError(err)
+		}
+		if prior != nil {
+			var stored anypb.Any
+			if err := proto.Unmarshal(prior.Body, &stored); err != nil {
+				return nil, apperror.GRPCError(err)
+			}
+			_ = grpc.SetHeader(ctx, metadata.Pairs(IdempotentReplayedMetadata, "true"))
+			return stored.UnmarshalNew()
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			releaseIdempotencyKey(ctx, store, claims.UserID, key)
+			return nil, err
+		}
+		if err := completeGRPC(store, claims.UserID, key, resp); err != nil {
+			log.ErrorContext(ctx, "Storing idempotent response failed", "key", key, "error", err)
+		}
+		return resp, nil
+	}
+}
+
+func completeGRPC(store IdempotencyStore, userID, key string, resp interface{}) error {
+	message, ok := resp.(proto.Message)
+	if !ok {
+		return fmt.Errorf("response %T is not a proto message", resp)
+	}
+	stored, err := anypb.New(message)
+	if err != nil {
+		return err
+	}
+	body, err := proto.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	return store.Complete(userID, key, int(codes.OK), body)
+}
+
+// reserveIdempotencyKey claims key, or returns the stored response to replay
+func reserveIdempotencyKey(store IdempotencyStore, userID, key, hash string) (*IdempotentResponse, error) {
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, fmt.Errorf("%w: %s must be at most %d characters", apperror.ErrInvalidFormat, IdempotencyKeyHeader, maxIdempotencyKeyLength)
+	}
+	prior, err := store.Reserve(userID, key, hash)
+	if err != nil || prior == nil {
+		return nil, err
+	}
+	if prior.RequestHash != hash {
+		return nil, fmt.Errorf("%w: %s was already used for a different request", apperror.ErrInvalidData, IdempotencyKeyHeader)
+	}
+	if !prior.Completed {
+		return nil, fmt.Errorf("%w: a request with this %s is still in progress", apperror.ErrConflict, IdempotencyKeyHeader)
+	}
+	return prior, nil
+}
+
+func releaseIdempotencyKey(ctx context.Context, store IdempotencyStore, userID, key string) {
+	if err := store.Release(userID, key); err != nil {
+		log.ErrorContext(ctx, "Releasing idempotency key failed", "key", key, "error", err)
+	}
+}
+
+// requestHash fingerprints a request so a key cannot be reused for another one
+func requestHash(route string, body []byte) string {
+	sum := sha256.New()
+	sum.Write([]byte(route))
+	sum.Write([]byte{0})
+	sum.Write(body)
+	return hex.EncodeToString(sum.Sum(nil))
+}
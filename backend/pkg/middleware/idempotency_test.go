@@ -0,0 +1,222 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+	"github.com/ePSA-eJya/Mess_Management/pkg/token"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// memoryIdempotency keeps keys in a map, they never expire
+type memoryIdempotency struct {
+	mu      sync.Mutex
+	entries map[string]*middleware.IdempotentResponse
+}
+
+func newMemoryIdempotency() *memoryIdempotency {
+	return &memoryIdempotency{entries: map[string]*middleware.IdempotentResponse{}}
+}
+
+func (m *memoryIdempotency) Reserve(userID, key, requestHash string) (*middleware.IdempotentResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if entry, ok := m.entries[userID+"/"+key]; ok {
+		prior := *entry
+		return &prior, nil
+	}
+	m.entries[userID+"/"+key] = &middleware.IdempotentResponse{RequestHash: requestHash}
+	return nil, nil
+}
+
+func (m *memoryIdempotency) Complete(userID, key string, statusCode int, body []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry := m.entries[userID+"/"+key]
+	entry.Completed = true
+	entry.StatusCode = statusCode
+	entry.Body = append([]byte(nil), body...)
+	return nil
+}
+
+func (m *memoryIdempotency) Release(userID, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if entry, ok := m.entries[userID+"/"+key]; ok && !entry.Completed {
+		delete(m.entries, userID+"/"+key)
+	}
+	return nil
+}
+
+func TestIdempotency(t *testing.T) {
+	var payments int
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", c.Get("X-User"))
+		return c.Next()
+	}, middleware.Idempotency(newMemoryIdempotency()))
+	app.Post("/payments", func(c *fiber.Ctx) error {
+		if strings.Contains(string(c.Body()), "fail") {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "rejected"})
+		}
+		payments++
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"payment": payments})
+	})
+	post := func(user, key, body string) (*http.Response, string) {
+		req := httptest.NewRequest("POST", "/payments", strings.NewReader(body))
+		req.Header.Set("X-User", user)
+		if key != "" {
+			req.Header.Set(middleware.IdempotencyKeyHeader, key)
+		}
+		resp, err := app.Test(req, -1)
+		require.NoError(t, err)
+		raw, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(raw)
+	}
+
+	resp, body := post("user-1", "key-1", `{"amount":100}`)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	assert.JSONEq(t, `{"payment":1}`, body)
+
+	// the retry gets the first response and the handler does not run again
+	resp, body = post("user-1", "key-1", `{"amount":100}`)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	assert.JSONEq(t, `{"payment":1}`, body)
+	assert.Equal(t, "true", resp.Header.Get(middleware.IdempotentReplayedHeader))
+	assert.Equal(t, 1, payments)
+
+	resp, body = post("user-1", "key-1", `{"amount":200}`)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	assert.Contains(t, body, "already used for a different request")
+
+	// keys are per user, and requests without one are never deduplicated
+	resp, _ = post("user-2", "key-1", `{"amount":100}`)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	post("user-1", "", `{"amount":100}`)
+	post("user-1", "", `{"amount":100}`)
+	assert.Equal(t, 4, payments)
+
+	// failed requests are not stored, so the key can be retried
+	resp, _ = post("user-1", "key-2", `{"fail":true}`)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	resp, _ = post("user-1", "key-2", `{"amount":100}`)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	resp, _ = post("user-1", strings.Repeat("k", 256), `{"amount":100}`)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	started, finish := make(chan struct{}), make(chan struct{})
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-1")
+		return c.Next()
+	}, middleware.Idempotency(newMemoryIdempotency()))
+	app.Post("/payments", func(c *fiber.Ctx) error {
+		close(started)
+		<-finish
+		return c.SendStatus(fiber.StatusCreated)
+	})
+	post := func() int {
+		req := httptest.NewRequest("POST", "/payments", strings.NewReader(`{"amount":100}`))
+		req.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+		resp, err := app.Test(req, -1)
+		require.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	first := make(chan int)
+	go func() { first <- post() }()
+	<-started
+
+	// a retry while the first request is still running must not run it twice
+	assert.Equal(t, fiber.StatusConflict, post())
+	close(finish)
+	assert.Equal(t, fiber.StatusCreated, <-first)
+}
+
+func TestGRPCIdempotencyInterceptor(t *testing.T) {
+	tokens := token.NewManager("secret", time.Minute)
+	accessToken, _, err := tokens.Issue("user-1", "STUDENT", "session-1")
+	require.NoError(t, err)
+	auth := middleware.GRPCAuthInterceptor(tokens, revokedSessions{})
+	idempotency := middleware.GRPCIdempotencyInterceptor(newMemoryIdempotency())
+
+	var calls int
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		if req.(*wrapperspb.DoubleValue).Value <= 0 {
+			return nil, apperror.GRPCError(apperror.ErrInvalidData)
+		}
+		calls++
+		return wrapperspb.Int64(int64(calls)), nil
+	}
+	call := func(key string, total float64) (proto.Message, metadata.MD, error) {
+		md := metadata.Pairs("authorization", "Bearer "+accessToken)
+		if key != "" {
+			md.Set(middleware.IdempotencyKeyMetadata, key)
+		}
+		stream := &headerStream{}
+		ctx := grpc.NewContextWithServerTransportStream(metadata.NewIncomingContext(context.Background(), md), stream)
+		resp, err := auth(ctx, wrapperspb.Double(total), unaryInfo, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return idempotency(ctx, req, unaryInfo, handler)
+		})
+		if err != nil {
+			return nil, stream.header, err
+		}
+		return resp.(proto.Message), stream.header, nil
+	}
+
+	resp, _, err := call("key-1", 300)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(wrapperspb.Int64(1), resp))
+
+	resp, header, err := call("key-1", 300)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(wrapperspb.Int64(1), resp))
+	assert.Equal(t, []string{"true"}, header.Get(middleware.IdempotentReplayedMetadata))
+	assert.Equal(t, 1, calls)
+
+	_, _, err = call("key-1", 450)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, 1, calls)
+
+	_, _, err = call("key-2", 0)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	resp, _, err = call("key-2", 450)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(wrapperspb.Int64(2), resp))
+}
+
+// headerStream records the headers a handler sets with grpc.SetHeader
+type headerStream struct {
+	header metadata.MD
+}
+
+func (s *headerStream) Method() string { return unaryInfo.FullMethod }
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *headerStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *headerStream) SetTrailer(metadata.MD) error { return nil }
@@ -1,37 +1,66 @@
 package middleware
 
 import (
-	"os"
+	"context"
+	"strings"
 
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/ePSA-eJya/Mess_Management/pkg/token"
 	"github.com/gofiber/fiber/v2"
-	"github.com/golang-jwt/jwt/v5"
 )
 
-func JWTMiddleware() fiber.Handler {
+// RevocationChecker reports whether a verified access token was revoked before
+// it expired, the check runs within the request's context
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, claims *token.Claims) (bool, error)
+}
+
+func JWTMiddleware(tokens *token.Manager, revocations RevocationChecker) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		auth := c.Get("Authorization")
 		if auth == "" {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "missing token"})
+			return responses.ErrorWithMessage(c, apperror.ErrUnauthorized, "missing token")
+		}
+		tokenStr, ok := strings.CutPrefix(auth, "Bearer ")
+		if !ok {
+			return responses.ErrorWithMessage(c, apperror.ErrUnauthorized, "invalid token")
 		}
-		tokenStr := auth[len("Bearer "):]
-
-		// tokenStr := c.Cookies("token") // Assuming the token is stored in a cookie named "token"
-		// if tokenStr == "" {
-		// 	return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "missing token"})
-		// }
 
-		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			return []byte(os.Getenv("JWT_SECRET")), nil
-		})
+		claims, err := tokens.Parse(tokenStr)
+		if err != nil {
+			return responses.ErrorWithMessage(c, apperror.ErrUnauthorized, "invalid token")
+		}
 
-		if err != nil || !token.Valid {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid token"})
+		revoked, err := revocations.IsRevoked(c.UserContext(), claims)
+		if err != nil {
+			return responses.Error(c, err)
+		}
+		if revoked {
+			return responses.ErrorWithMessage(c, apperror.ErrUnauthorized, "token revoked")
 		}
 
-		claims := token.Claims.(jwt.MapClaims)
-		userID := claims["user_id"]
-		c.Locals("user_id", userID)
+		c.Locals("user_id", claims.UserID)
+		c.Locals("role", claims.Role)
+		c.Locals("session_id", claims.SessionID)
+		// long lived streams check the token again while they run
+		c.Locals("claims", claims)
 
 		return c.Next()
 	}
 }
+
+// TokenFromQuery copies an access token passed as the param query parameter
+// into the Authorization header for JWTMiddleware. It is meant for browser
+// EventSource and WebSocket clients which cannot set headers, a header that
+// is already present wins.
+func TokenFromQuery(param string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Get("Authorization") == "" {
+			if tokenStr := c.Query(param); tokenStr != "" {
+				c.Request().Header.Set("Authorization", "Bearer "+tokenStr)
+			}
+		}
+		return c.Next()
+	}
+}
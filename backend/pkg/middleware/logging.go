@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/logger"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	log     = logger.For("middleware")
+	httpLog = logger.For("http")
+	grpcLog = logger.For("grpc")
+)
+
+// RequestContext copies the request ID into c.UserContext(), so everything
+// logged with that context down to the repositories carries it. It must run
+// after the request ID middleware.
+func RequestContext() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if requestID := c.Locals(responses.RequestIDKey); requestID != nil {
+			c.SetUserContext(logger.WithRequestID(c.UserContext(), fmt.Sprint(requestID)))
+		}
+		return c.Next()
+	}
+}
+
+// RequestLogger writes one access log record per request to the "http" logger
+func RequestLogger() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		attrs := []any{
+			"method", c.Method(),
+			"path", c.Path(),
+			"route", c.Route().Path,
+			"status", responseStatus(c, err),
+			"duration", time.Since(start),
+			"ip", c.IP(),
+		}
+		if err != nil {
+			attrs = append(attrs, "error", err)
+		}
+		httpLog.InfoContext(c.UserContext(), "HTTP request", attrs...)
+		return err
+	}
+}
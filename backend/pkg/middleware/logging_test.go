@@ -0,0 +1,52 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/logger"
+	"github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLogger(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, logger.Setup(logger.Config{Format: "json"}, &out))
+	t.Cleanup(func() { _ = logger.Setup(logger.Config{}, os.Stderr) })
+
+	var seen string
+	app := fiber.New()
+	app.Use(
+		requestid.New(requestid.Config{ContextKey: responses.RequestIDKey}),
+		middleware.RequestContext(),
+		middleware.RequestLogger(),
+	)
+	app.Get("/orders/:id", func(c *fiber.Ctx) error {
+		seen = logger.RequestID(c.UserContext())
+		return fiber.ErrNotFound
+	})
+
+	req := httptest.NewRequest("GET", "/orders/7", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "req-42")
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	// handlers find the request ID in their context
+	assert.Equal(t, "req-42", seen)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &record))
+	assert.Equal(t, "http", record["logger"])
+	assert.Equal(t, "req-42", record["request_id"])
+	assert.Equal(t, "GET", record["method"])
+	assert.Equal(t, "/orders/:id", record["route"])
+	assert.Equal(t, float64(fiber.StatusNotFound), record["status"])
+}
@@ -0,0 +1,91 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/metrics"
+	"github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+	"github.com/ePSA-eJya/Mess_Management/pkg/tracing"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMetrics(t *testing.T) {
+	app := fiber.New()
+	app.Use(middleware.Metrics())
+	app.Get("/widgets/:id", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	app.Get("/broken", func(c *fiber.Ctx) error { return fiber.ErrTeapot })
+
+	ok := metrics.HTTPRequests.WithLabelValues("GET", "/widgets/:id", "200")
+	teapot := metrics.HTTPRequests.WithLabelValues("GET", "/broken", "418")
+	before, beforeTeapot := testutil.ToFloat64(ok), testutil.ToFloat64(teapot)
+
+	for _, target := range []string{"/widgets/1", "/widgets/2", "/broken"} {
+		_, err := app.Test(httptest.NewRequest("GET", target, nil), -1)
+		require.NoError(t, err)
+	}
+
+	// requests are counted by route pattern, not by path
+	assert.Equal(t, before+2, testutil.ToFloat64(ok))
+	assert.Equal(t, beforeTeapot+1, testutil.ToFloat64(teapot))
+}
+
+func TestGRPCMetricsInterceptor(t *testing.T) {
+	interceptor := middleware.GRPCMetricsInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/order.OrderService/FindOrderByID"}
+	notFound := metrics.GRPCRequests.WithLabelValues(info.FullMethod, codes.NotFound.String())
+	before := testutil.ToFloat64(notFound)
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "order not found")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, before+1, testutil.ToFloat64(notFound))
+}
+
+func TestTracing(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	var forwarded string
+	app := fiber.New()
+	app.Use(middleware.Tracing())
+	app.Get("/orders/:id", func(c *fiber.Ctx) error {
+		_, span := tracing.Start(c.UserContext(), "OrderService.FindOrderByID")
+		span.End()
+		forwarded = c.Get("traceparent")
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	req := httptest.NewRequest("GET", "/orders/7", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, err := app.Test(req, -1)
+	require.NoError(t, err)
+
+	ended := spans.Ended()
+	require.Len(t, ended, 2)
+	usecase, server := ended[0], ended[1]
+	assert.Equal(t, "GET /orders/:id", server.Name())
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+	assert.Equal(t, server.SpanContext().SpanID(), usecase.Parent().SpanID())
+
+	// the gateway passes the server span on to the gRPC call
+	assert.Contains(t, forwarded, server.SpanContext().SpanID().String())
+}
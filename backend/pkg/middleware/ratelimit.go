@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/ratelimit"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	// RetryAfterMetadata is sent with rejected gRPC calls, the gateway
+	// writes it back as the Retry-After header
+	RetryAfterMetadata = "retry-after"
+)
+
+// RateLimits holds the read and write policies of an API and the store of their buckets
+type RateLimits struct {
+	Store ratelimit.Store
+	Read  ratelimit.Policy // GET and HEAD requests, Find, Get and Watch RPCs
+	Write ratelimit.Policy // every other request
+}
+
+// RateLimit takes a token from the caller's bucket for every request and
+// answers 429 once it is empty. Callers are told apart by user ID after
+// JWTMiddleware and by IP address before it. When the store fails the
+// request is let through.
+func RateLimit(store ratelimit.Store, policy ratelimit.Policy) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if policy.Disabled() {
+			return c.Next()
+		}
+		caller := "ip:" + c.IP()
+		if userID := c.Locals("user_id"); userID != nil {
+			caller = "user:" + fmt.Sprint(userID)
+		}
+
+		result, err := store.Take(policy.Name+":"+caller, policy, time.Now())
+		if err != nil {
+			log.ErrorContext(c.UserContext(), "Taking a rate limit token failed, letting the request through", "policy", policy.Name, "error", err)
+			return c.Next()
+		}
+		c.Set(RateLimitLimitHeader, strconv.Itoa(result.Limit))
+		c.Set(RateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+		if !result.Allowed {
+			c.Set(fiber.HeaderRetryAfter, retryAfterSeconds(result.RetryAfter))
+			return responses.Error(c, limitExceeded(result))
+		}
+		return c.Next()
+	}
+}
+
+// RateLimitByMethod applies the read policy to GET and HEAD requests and the
+// write policy to the others
+func RateLimitByMethod(limits RateLimits) fiber.Handler {
+	read, write := RateLimit(limits.Store, limits.Read), RateLimit(limits.Store, limits.Write)
+	return func(c *fiber.Ctx) error {
+		if c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead {
+			return read(c)
+		}
+		return write(c)
+	}
+}
+
+// GRPCRateLimitInterceptor is the gRPC counterpart of RateLimitByMethod, Find,
+// Get and Watch methods are reads. It must run after GRPCAuthInterceptor.
+func GRPCRateLimitInterceptor(limits RateLimits) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := takeGRPC(ctx, limits, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// GRPCStreamRateLimitInterceptor takes one token when a stream is opened
+func GRPCStreamRateLimitInterceptor(limits RateLimits) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := takeGRPC(ss.Context(), limits, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func takeGRPC(ctx context.Context, limits RateLimits, method string) error {
+	if isPublic(method) {
+		return nil
+	}
+	policy := limits.Write
+	if isReadMethod(method) {
+		policy = limits.Read
+	}
+	if policy.Disabled() {
+		return nil
+	}
+	caller := "ip:" + ClientIPFromContext(ctx)
+	if claims, ok := ClaimsFromContext(ctx); ok {
+		caller = "user:" + claims.UserID
+	}
+
+	result, err := limits.Store.Take(policy.Name+":"+caller, policy, time.Now())
+	if err != nil {
+		log.ErrorContext(ctx, "Taking a rate limit token failed, letting the call through", "policy", policy.Name, "error", err)
+		return nil
+	}
+	if !result.Allowed {
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RetryAfterMetadata, retryAfterSeconds(result.RetryAfter)))
+		return apperror.GRPCError(limitExceeded(result))
+	}
+	return nil
+}
+
+// isReadMethod reports whether a full gRPC method name such as
+// "/order.v1.OrderService/FindAllOrders" only reads
+func isReadMethod(method string) bool {
+	name := method[strings.LastIndex(method, "/")+1:]
+	for _, prefix := range []string{"Find", "Get", "Watch"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func limitExceeded(result ratelimit.Result) error {
+	return fmt.Errorf("%w: too many requests, retry in %ss", apperror.ErrLimitExceeded, retryAfterSeconds(result.RetryAfter))
+}
+
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
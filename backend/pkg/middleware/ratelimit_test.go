@@ -0,0 +1,119 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+	"github.com/ePSA-eJya/Mess_Management/pkg/ratelimit"
+	"github.com/ePSA-eJya/Mess_Management/pkg/token"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// failingStore fails every take, as an unreachable database would
+type failingStore struct{}
+
+func (failingStore) Take(string, ratelimit.Policy, time.Time) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+func TestRateLimit(t *testing.T) {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		if user := c.Get("X-User"); user != "" {
+			c.Locals("user_id", user)
+		}
+		return c.Next()
+	}, middleware.RateLimitByMethod(middleware.RateLimits{
+		Store: ratelimit.NewMemoryStore(),
+		Read:  ratelimit.PerMinute("read", 2),
+		Write: ratelimit.PerMinute("write", 1),
+	}))
+	app.All("/orders", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	request := func(method, user string) (int, string, string) {
+		req := httptest.NewRequest(method, "/orders", nil)
+		if user != "" {
+			req.Header.Set("X-User", user)
+		}
+		resp, err := app.Test(req, -1)
+		require.NoError(t, err)
+		return resp.StatusCode, resp.Header.Get(middleware.RateLimitRemainingHeader), resp.Header.Get(fiber.HeaderRetryAfter)
+	}
+
+	code, remaining, _ := request("GET", "user-1")
+	assert.Equal(t, fiber.StatusOK, code)
+	assert.Equal(t, "1", remaining)
+	code, _, _ = request("GET", "user-1")
+	assert.Equal(t, fiber.StatusOK, code)
+	code, remaining, retryAfter := request("GET", "user-1")
+	assert.Equal(t, fiber.StatusTooManyRequests, code)
+	assert.Equal(t, "0", remaining)
+	assert.Equal(t, "30", retryAfter)
+
+	// writes, other users and anonymous callers have their own buckets
+	code, _, _ = request("POST", "user-1")
+	assert.Equal(t, fiber.StatusOK, code)
+	code, _, _ = request("POST", "user-1")
+	assert.Equal(t, fiber.StatusTooManyRequests, code)
+	code, _, _ = request("GET", "user-2")
+	assert.Equal(t, fiber.StatusOK, code)
+	code, _, _ = request("POST", "")
+	assert.Equal(t, fiber.StatusOK, code)
+}
+
+func TestRateLimit_FailsOpen(t *testing.T) {
+	app := fiber.New()
+	app.Use(middleware.RateLimit(failingStore{}, ratelimit.PerMinute("auth", 1)))
+	app.Post("/signin", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	for i := 0; i < 3; i++ {
+		resp, err := app.Test(httptest.NewRequest("POST", "/signin", nil), -1)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	}
+}
+
+func TestGRPCRateLimitInterceptor(t *testing.T) {
+	tokens := token.NewManager("secret", time.Minute)
+	accessToken, _, err := tokens.Issue("user-1", "STUDENT", "session-1")
+	require.NoError(t, err)
+	auth := middleware.GRPCAuthInterceptor(tokens, revokedSessions{})
+	limit := middleware.GRPCRateLimitInterceptor(middleware.RateLimits{
+		Store: ratelimit.NewMemoryStore(),
+		Read:  ratelimit.PerMinute("read", 1),
+		Write: ratelimit.PerMinute("write", 1),
+	})
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	call := func(method string) (metadata.MD, error) {
+		stream := &headerStream{}
+		md := metadata.Pairs("authorization", "Bearer "+accessToken)
+		ctx := grpc.NewContextWithServerTransportStream(metadata.NewIncomingContext(context.Background(), md), stream)
+		info := &grpc.UnaryServerInfo{FullMethod: method}
+		_, err := auth(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return limit(ctx, req, info, handler)
+		})
+		return stream.header, err
+	}
+
+	_, err = call("/order.OrderService/GetOrder")
+	require.NoError(t, err)
+	header, err := call("/order.OrderService/FindAllOrders")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, []string{"60"}, header.Get(middleware.RetryAfterMetadata))
+
+	// writes are counted apart from reads
+	_, err = call("/order.OrderService/CreateOrder")
+	require.NoError(t, err)
+	_, err = call("/order.OrderService/PatchOrder")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireRole only lets through users whose JWT role is one of roles,
+// it must run after JWTMiddleware
+func RequireRole(roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, _ := c.Locals("role").(string)
+		for _, r := range roles {
+			if role == r {
+				return c.Next()
+			}
+		}
+		return responses.Error(c, apperror.ErrForbidden)
+	}
+}
@@ -0,0 +1,88 @@
+package oidc_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/oidc"
+	"github.com/ePSA-eJya/Mess_Management/pkg/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const redirectURL = "http://localhost:8000/api/v1/auth/oidc/callback"
+
+func newClient(t *testing.T) (*oidc.Client, *oidctest.Server) {
+	idp := oidctest.NewServer("mess", "mess-secret")
+	t.Cleanup(idp.Close)
+	idp.SignInAs(oidctest.Identity{Subject: "s-1", Email: "student@campus.edu", EmailVerified: true, Name: "Campus Student"})
+
+	return oidc.NewClient(oidc.Config{
+		IssuerURL:    idp.URL,
+		ClientID:     "mess",
+		ClientSecret: "mess-secret",
+		RedirectURL:  redirectURL,
+		Scopes:       []string{"email", "profile"},
+	}, nil), idp
+}
+
+func authorize(t *testing.T, client *oidc.Client, idp *oidctest.Server, state, nonce, verifier string) *url.URL {
+	authURL, err := client.AuthCodeURL(context.Background(), state, nonce, verifier)
+	require.NoError(t, err)
+
+	callback, err := idp.Authorize(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, state, callback.Query().Get("state"))
+	return callback
+}
+
+func TestExchange(t *testing.T) {
+	client, idp := newClient(t)
+	verifier := oidc.NewCodeVerifier()
+	callback := authorize(t, client, idp, "state-1", "nonce-1", verifier)
+
+	identity, err := client.Exchange(context.Background(), callback.Query().Get("code"), verifier, "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, idp.URL, identity.Issuer)
+	assert.Equal(t, "s-1", identity.Subject)
+	assert.Equal(t, "student@campus.edu", identity.Email)
+	assert.True(t, identity.EmailVerified)
+	assert.Equal(t, "Campus Student", identity.Name)
+}
+
+func TestExchange_WrongVerifier(t *testing.T) {
+	client, idp := newClient(t)
+	callback := authorize(t, client, idp, "state-1", "nonce-1", oidc.NewCodeVerifier())
+
+	_, err := client.Exchange(context.Background(), callback.Query().Get("code"), oidc.NewCodeVerifier(), "nonce-1")
+	assert.ErrorIs(t, err, oidc.ErrExchangeFailed)
+}
+
+func TestExchange_NonceMismatch(t *testing.T) {
+	client, idp := newClient(t)
+	verifier := oidc.NewCodeVerifier()
+	callback := authorize(t, client, idp, "state-1", "nonce-1", verifier)
+
+	_, err := client.Exchange(context.Background(), callback.Query().Get("code"), verifier, "other-nonce")
+	assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+}
+
+func TestExchange_CodeIsSingleUse(t *testing.T) {
+	client, idp := newClient(t)
+	verifier := oidc.NewCodeVerifier()
+	callback := authorize(t, client, idp, "state-1", "nonce-1", verifier)
+	code := callback.Query().Get("code")
+
+	_, err := client.Exchange(context.Background(), code, verifier, "nonce-1")
+	require.NoError(t, err)
+	_, err = client.Exchange(context.Background(), code, verifier, "nonce-1")
+	assert.ErrorIs(t, err, oidc.ErrExchangeFailed)
+}
+
+func TestAuthCodeURL_DiscoveryFails(t *testing.T) {
+	client := oidc.NewClient(oidc.Config{IssuerURL: "http://127.0.0.1:1", ClientID: "mess"}, nil)
+
+	_, err := client.AuthCodeURL(context.Background(), "state", "nonce", oidc.NewCodeVerifier())
+	assert.Error(t, err)
+}
@@ -0,0 +1,153 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// cursor is the position after the last item of a page: its sort value and
+// primary key, which breaks ties between equal sort values
+type cursor struct {
+	Sort  string          `json:"s"`
+	Value json.RawMessage `json:"v"`
+	Key   json.RawMessage `json:"k"`
+}
+
+// Find loads the page of T selected by query from db, which may already carry
+// conditions the client cannot override, like the owner of the rows
+func Find[T any](db *gorm.DB, query Query, fields Fields) (*Page[*T], error) {
+	limit, err := query.limit()
+	if err != nil {
+		return nil, err
+	}
+
+	sort := query.Sort
+	if sort == "" {
+		sort = fields.DefaultSort
+	}
+	name, desc := strings.CutPrefix(sort, "-")
+	column, ok := fields.Sort[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot sort by %q", apperror.ErrInvalidData, name)
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return nil, err
+	}
+	sortField := stmt.Schema.LookUpField(column)
+	keyField := stmt.Schema.PrioritizedPrimaryField
+	if sortField == nil || keyField == nil {
+		return nil, fmt.Errorf("pagination: %s has no column %q or no primary key", stmt.Schema.Name, column)
+	}
+
+	base := db.Model(new(T))
+	for field, value := range query.Filter {
+		column, ok := fields.Filter[field]
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot filter by %q", apperror.ErrInvalidData, field)
+		}
+		base = base.Where(clause.Eq{Column: clause.Column{Name: column}, Value: value})
+	}
+	// both queries below start from the filtered statement
+	base = base.Session(&gorm.Session{})
+
+	var total int64
+	if err := base.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	find := base
+	if query.Cursor != "" {
+		value, key, err := decodeCursor(query.Cursor, sort, sortField, keyField)
+		if err != nil {
+			return nil, err
+		}
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		sortColumn := clause.Column{Name: sortField.DBName}
+		keyColumn := clause.Column{Name: keyField.DBName}
+		find = find.Where(clause.Expr{
+			SQL:  fmt.Sprintf("(? %[1]s ? OR (? = ? AND ? %[1]s ?))", op),
+			Vars: []any{sortColumn, value, sortColumn, value, keyColumn, key},
+		})
+	}
+
+	var values []T
+	err = find.Order(clause.OrderBy{Columns: []clause.OrderByColumn{
+		{Column: clause.Column{Name: sortField.DBName}, Desc: desc},
+		{Column: clause.Column{Name: keyField.DBName}, Desc: desc},
+	}}).Limit(limit + 1).Find(&values).Error
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page[*T]{Items: make([]*T, 0, limit), Total: total}
+	more := len(values) > limit
+	if more {
+		values = values[:limit]
+	}
+	for i := range values {
+		page.Items = append(page.Items, &values[i])
+	}
+	if more {
+		last := reflect.ValueOf(page.Items[len(page.Items)-1]).Elem()
+		page.NextCursor, err = encodeCursor(db, sort, sortField, keyField, last)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+func encodeCursor(db *gorm.DB, sort string, sortField, keyField *schema.Field, row reflect.Value) (string, error) {
+	value, _ := sortField.ValueOf(db.Statement.Context, row)
+	key, _ := keyField.ValueOf(db.Statement.Context, row)
+
+	var c cursor
+	var err error
+	c.Sort = sort
+	if c.Value, err = json.Marshal(value); err != nil {
+		return "", err
+	}
+	if c.Key, err = json.Marshal(key); err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor restores the sort value and key with the Go types of their
+// fields so they are bound to the query like any other value
+func decodeCursor(encoded, sort string, sortField, keyField *schema.Field) (any, any, error) {
+	invalid := fmt.Errorf("%w: invalid cursor", apperror.ErrInvalidData)
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, nil, invalid
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Sort != sort {
+		return nil, nil, invalid
+	}
+
+	value := reflect.New(sortField.FieldType)
+	key := reflect.New(keyField.FieldType)
+	if json.Unmarshal(c.Value, value.Interface()) != nil || json.Unmarshal(c.Key, key.Interface()) != nil {
+		return nil, nil, invalid
+	}
+	return value.Elem().Interface(), key.Elem().Interface(), nil
+}
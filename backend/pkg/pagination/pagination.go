@@ -0,0 +1,98 @@
+// Package pagination pages, filters and sorts list queries with opaque
+// cursors so every list endpoint answers with the same Page envelope.
+package pagination
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Query asks for one page of a list
+type Query struct {
+	Cursor string // NextCursor of the previous page, empty for the first page
+	Limit  int    // page size, DefaultLimit when zero and capped at MaxLimit
+	Sort   string // field to sort by, prefixed with "-" for descending order
+	// Filter keeps the items whose field equals the value
+	Filter map[string]string
+}
+
+// Page is the envelope lists are returned in, NextCursor is empty on the last page
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor"`
+	Total      int64  `json:"total"`
+}
+
+// Fields whitelists what clients may filter and sort a list by, mapping the
+// public field name to its column. Sort columns must not be nullable.
+type Fields struct {
+	Filter      map[string]string
+	Sort        map[string]string
+	DefaultSort string
+}
+
+var filterParam = regexp.MustCompile(`^filter\[(\w+)\]$`)
+
+// Parse reads a Query from URL query parameters: cursor, limit, sort and
+// filter[field]=value. Other parameters are ignored.
+func Parse(params map[string]string) (Query, error) {
+	query := Query{
+		Cursor: params["cursor"],
+		Sort:   params["sort"],
+		Filter: map[string]string{},
+	}
+	if limit, ok := params["limit"]; ok && limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return Query{}, fmt.Errorf("%w: limit must be a number", apperror.ErrInvalidData)
+		}
+		query.Limit = n
+	}
+	for key, value := range params {
+		if match := filterParam.FindStringSubmatch(key); match != nil {
+			query.Filter[match[1]] = value
+		}
+	}
+	return query, nil
+}
+
+// WithFilter returns a copy of the query that also filters field by value,
+// replacing any value the client asked for
+func (q Query) WithFilter(field, value string) Query {
+	filter := make(map[string]string, len(q.Filter)+1)
+	for k, v := range q.Filter {
+		filter[k] = v
+	}
+	filter[field] = value
+	q.Filter = filter
+	return q
+}
+
+// Map converts the items of a page, e.g. from entities to responses
+func Map[T, U any](page *Page[T], convert func(T) U) *Page[U] {
+	items := make([]U, len(page.Items))
+	for i, item := range page.Items {
+		items[i] = convert(item)
+	}
+	return &Page[U]{Items: items, NextCursor: page.NextCursor, Total: page.Total}
+}
+
+func (q Query) limit() (int, error) {
+	switch {
+	case q.Limit < 0:
+		return 0, fmt.Errorf("%w: limit must not be negative", apperror.ErrInvalidData)
+	case q.Limit == 0:
+		return DefaultLimit, nil
+	case q.Limit > MaxLimit:
+		return MaxLimit, nil
+	}
+	return q.Limit, nil
+}
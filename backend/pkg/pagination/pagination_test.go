@@ -0,0 +1,49 @@
+package pagination_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	query, err := pagination.Parse(map[string]string{
+		"cursor":       "abc",
+		"limit":        "5",
+		"sort":         "-total",
+		"filter[role]": "STUDENT",
+		"access_token": "ignored",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pagination.Query{
+		Cursor: "abc",
+		Limit:  5,
+		Sort:   "-total",
+		Filter: map[string]string{"role": "STUDENT"},
+	}, query)
+
+	_, err = pagination.Parse(map[string]string{"limit": "ten"})
+	assert.ErrorIs(t, err, apperror.ErrInvalidData)
+}
+
+func TestWithFilter_CopiesTheFilter(t *testing.T) {
+	query := pagination.Query{Filter: map[string]string{"user_id": "someone-else"}}
+
+	scoped := query.WithFilter("user_id", "me")
+
+	assert.Equal(t, "me", scoped.Filter["user_id"])
+	assert.Equal(t, "someone-else", query.Filter["user_id"])
+	assert.Equal(t, "me", pagination.Query{}.WithFilter("user_id", "me").Filter["user_id"])
+}
+
+func TestMap(t *testing.T) {
+	page := &pagination.Page[int]{Items: []int{1, 2}, NextCursor: "next", Total: 7}
+
+	mapped := pagination.Map(page, strconv.Itoa)
+
+	assert.Equal(t, &pagination.Page[string]{Items: []string{"1", "2"}, NextCursor: "next", Total: 7}, mapped)
+}
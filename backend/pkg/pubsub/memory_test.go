@@ -0,0 +1,85 @@
+package pubsub_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/pubsub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func receive(t *testing.T, ch <-chan []byte) string {
+	select {
+	case msg := <-ch:
+		return string(msg)
+	case <-time.After(time.Second):
+		t.Fatal("no message received")
+		return ""
+	}
+}
+
+func TestMemoryBroker_FanOut(t *testing.T) {
+	broker := pubsub.NewMemoryBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first, err := broker.Subscribe(ctx, "meals")
+	require.NoError(t, err)
+	second, err := broker.Subscribe(ctx, "meals")
+	require.NoError(t, err)
+	other, err := broker.Subscribe(ctx, "complaints")
+	require.NoError(t, err)
+
+	require.NoError(t, broker.Publish(ctx, "meals", []byte("42")))
+
+	assert.Equal(t, "42", receive(t, first))
+	assert.Equal(t, "42", receive(t, second))
+	select {
+	case msg := <-other:
+		t.Fatalf("unexpected message on another topic: %s", msg)
+	default:
+	}
+}
+
+func TestMemoryBroker_UnsubscribeOnCancel(t *testing.T) {
+	broker := pubsub.NewMemoryBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch, err := broker.Subscribe(ctx, "meals")
+	require.NoError(t, err)
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		assert.False(t, ok, "channel should be closed")
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+	assert.NoError(t, broker.Publish(context.Background(), "meals", []byte("ignored")))
+}
+
+func TestMemoryBroker_SlowSubscriberDoesNotBlock(t *testing.T) {
+	broker := pubsub.NewMemoryBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := broker.Subscribe(ctx, "meals")
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			_ = broker.Publish(ctx, "meals", []byte("tick"))
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publish blocked on a slow subscriber")
+	}
+	assert.Equal(t, "tick", receive(t, ch))
+}
@@ -0,0 +1,16 @@
+package pubsub
+
+import "context"
+
+// Broker fans messages out to every current subscriber of a topic. Payloads are
+// raw bytes so the in-process broker can be swapped for one backed by Postgres
+// LISTEN/NOTIFY or another transport without touching publishers.
+//
+// Delivery is best effort: a subscriber that falls behind misses messages, so
+// payloads should carry full state (a counter value) rather than deltas.
+type Broker interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	// Subscribe returns a channel of payloads published to topic from now on,
+	// it is closed once ctx is done
+	Subscribe(ctx context.Context, topic string) (<-chan []byte, error)
+}
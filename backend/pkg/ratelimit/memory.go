@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepEvery is how often MemoryStore drops buckets that are full again
+const sweepEvery = time.Minute
+
+type memoryBucket struct {
+	Bucket
+	fullAt time.Time
+}
+
+// MemoryStore keeps buckets within the current process only, every replica
+// counts its own requests
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+	sweptAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*memoryBucket)}
+}
+
+func (s *MemoryStore) Take(key string, policy Policy, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.sweptAt) >= sweepEvery {
+		s.sweep(now)
+	}
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &memoryBucket{Bucket: NewBucket(policy, now)}
+		s.buckets[key] = bucket
+	}
+	result := bucket.Take(policy, now)
+	bucket.fullAt = bucket.FullAt(policy)
+	return result, nil
+}
+
+// sweep drops full buckets, a missing bucket behaves the same
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, bucket := range s.buckets {
+		if !bucket.fullAt.After(now) {
+			delete(s.buckets, key)
+		}
+	}
+	s.sweptAt = now
+}
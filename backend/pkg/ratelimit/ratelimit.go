@@ -0,0 +1,75 @@
+// Package ratelimit implements token buckets: every caller may send Burst
+// requests at once and earns one more every Every, up to Burst again.
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// Policy is how many requests a caller may send and how fast they come back
+type Policy struct {
+	Name  string // prefixes bucket keys, so policies never share a bucket
+	Burst int
+	Every time.Duration
+}
+
+// PerMinute allows n requests a minute with bursts of up to n, n <= 0
+// disables the policy
+func PerMinute(name string, n int) Policy {
+	if n <= 0 {
+		return Policy{Name: name}
+	}
+	return Policy{Name: name, Burst: n, Every: time.Minute / time.Duration(n)}
+}
+
+// Disabled reports whether the policy lets every request through
+func (p Policy) Disabled() bool {
+	return p.Burst <= 0 || p.Every <= 0
+}
+
+// Result is the outcome of taking a token
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration // how long until a token is available, 0 when allowed
+}
+
+// Store keeps the buckets of every caller
+type Store interface {
+	// Take takes one token from the bucket under key, filling it first
+	// with what was earned since it was last used
+	Take(key string, policy Policy, now time.Time) (Result, error)
+}
+
+// Bucket is the state of one caller, a new bucket is full
+type Bucket struct {
+	Tokens     float64
+	RefilledAt time.Time
+}
+
+// NewBucket returns a full bucket
+func NewBucket(policy Policy, now time.Time) Bucket {
+	return Bucket{Tokens: float64(policy.Burst), RefilledAt: now}
+}
+
+// Take refills the bucket up to now and takes one token when there is one
+func (b *Bucket) Take(policy Policy, now time.Time) Result {
+	if elapsed := now.Sub(b.RefilledAt); elapsed > 0 {
+		b.Tokens = math.Min(float64(policy.Burst), b.Tokens+float64(elapsed)/float64(policy.Every))
+		b.RefilledAt = now
+	}
+	if b.Tokens < 1 {
+		missing := time.Duration((1 - b.Tokens) * float64(policy.Every))
+		return Result{Limit: policy.Burst, RetryAfter: missing}
+	}
+	b.Tokens--
+	return Result{Allowed: true, Limit: policy.Burst, Remaining: int(b.Tokens)}
+}
+
+// FullAt is when the bucket is full again, from then on it can be dropped
+func (b *Bucket) FullAt(policy Policy) time.Time {
+	missing := float64(policy.Burst) - b.Tokens
+	return b.RefilledAt.Add(time.Duration(missing * float64(policy.Every)))
+}
@@ -0,0 +1,74 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPerMinute(t *testing.T) {
+	policy := ratelimit.PerMinute("auth", 30)
+	assert.Equal(t, 30, policy.Burst)
+	assert.Equal(t, 2*time.Second, policy.Every)
+	assert.False(t, policy.Disabled())
+
+	assert.True(t, ratelimit.PerMinute("auth", 0).Disabled())
+	assert.True(t, ratelimit.Policy{}.Disabled())
+}
+
+func TestBucket(t *testing.T) {
+	policy := ratelimit.PerMinute("auth", 3)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	bucket := ratelimit.NewBucket(policy, now)
+
+	for remaining := 2; remaining >= 0; remaining-- {
+		result := bucket.Take(policy, now)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, remaining, result.Remaining)
+		assert.Equal(t, 3, result.Limit)
+	}
+
+	result := bucket.Take(policy, now.Add(5*time.Second))
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 15*time.Second, result.RetryAfter)
+
+	// one token is back after 20s, the rejected request did not cost one
+	result = bucket.Take(policy, now.Add(20*time.Second))
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+
+	// the bucket never holds more than the burst
+	later := now.Add(time.Hour)
+	result = bucket.Take(policy, later)
+	assert.Equal(t, 2, result.Remaining)
+	assert.Equal(t, later.Add(20*time.Second), bucket.FullAt(policy))
+}
+
+func TestMemoryStore(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	policy := ratelimit.PerMinute("auth", 2)
+	now := time.Now()
+
+	for i := 0; i < 2; i++ {
+		result, err := store.Take("auth:ip:10.0.0.1", policy, now)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	}
+	result, err := store.Take("auth:ip:10.0.0.1", policy, now)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+
+	// callers have their own buckets
+	result, err = store.Take("auth:ip:10.0.0.2", policy, now)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	// swept buckets start full again
+	result, err = store.Take("auth:ip:10.0.0.1", policy, now.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 1, result.Remaining)
+}
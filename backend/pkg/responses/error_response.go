@@ -1,19 +1,41 @@
 package responses
 
 import (
+	"fmt"
+
 	appError "github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/logger"
 	"github.com/gofiber/fiber/v2"
 )
 
+var log = logger.For("http")
+
+// RequestIDKey is the fiber local the request ID middleware stores the ID under
+const RequestIDKey = "request_id"
+
 // ErrorResponse represents the standard error response
 type ErrorResponse struct {
-	Error string `json:"error" example:"example error"`
+	Error *appError.AppError `json:"error"`
 }
 
 func Error(c *fiber.Ctx, err error) error {
-	return c.Status(appError.StatusCode(err)).JSON(ErrorResponse{Error: err.Error()})
+	return send(c, err, appError.From(err))
 }
 
 func ErrorWithMessage(c *fiber.Ctx, err error, message string) error {
-	return c.Status(appError.StatusCode(err)).JSON(ErrorResponse{Error: message})
+	envelope := appError.From(err)
+	envelope.Message = message
+	return send(c, err, envelope)
+}
+
+func send(c *fiber.Ctx, err error, envelope *appError.AppError) error {
+	status := appError.StatusCode(err)
+	if requestID := c.Locals(RequestIDKey); requestID != nil {
+		envelope.RequestID = fmt.Sprint(requestID)
+	}
+	// the client may only see a masked message, the log keeps the cause
+	if status >= fiber.StatusInternalServerError {
+		log.ErrorContext(c.UserContext(), "Request failed", "method", c.Method(), "path", c.Path(), "status", status, "error", err)
+	}
+	return c.Status(status).JSON(ErrorResponse{Error: envelope})
 }
@@ -0,0 +1,81 @@
+package routes
+
+import (
+	"net/http"
+	"time"
+
+	authRepository "github.com/ePSA-eJya/Mess_Management/internal/auth/repository"
+	authUseCase "github.com/ePSA-eJya/Mess_Management/internal/auth/usecase"
+	studentRepository "github.com/ePSA-eJya/Mess_Management/internal/student/repository"
+	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
+	userUseCase "github.com/ePSA-eJya/Mess_Management/internal/user/usecase"
+	"github.com/ePSA-eJya/Mess_Management/pkg/config"
+	"github.com/ePSA-eJya/Mess_Management/pkg/mailer"
+	"github.com/ePSA-eJya/Mess_Management/pkg/oidc"
+	"github.com/ePSA-eJya/Mess_Management/pkg/token"
+	"gorm.io/gorm"
+)
+
+// NewAuthService wires the session service shared by the sign-in routes and the
+// JWT checks of the REST and gRPC servers
+func NewAuthService(db *gorm.DB, cfg *config.Config) (authUseCase.AuthUseCase, *token.Manager) {
+	tokens := token.NewManager(cfg.JWTSecret, time.Duration(cfg.JWTExpiration)*time.Second)
+	authService := authUseCase.NewAuthService(
+		userRepository.NewGormUserRepository(db),
+		authRepository.NewGormSessionRepository(db),
+		authRepository.NewGormLoginThrottleRepository(db),
+		tokens,
+		time.Duration(cfg.RefreshTokenTTLHours)*time.Hour,
+		authUseCase.LoginPolicy{
+			MaxAccountFailures: cfg.LoginMaxAccountFailures,
+			MaxIPFailures:      cfg.LoginMaxIPFailures,
+			BaseLockout:        time.Duration(cfg.LoginLockoutBaseSeconds) * time.Second,
+			MaxLockout:         time.Duration(cfg.LoginLockoutMaxMinutes) * time.Minute,
+			FailureWindow:      time.Duration(cfg.LoginFailureWindowMinutes) * time.Minute,
+		},
+	)
+	return authService, tokens
+}
+
+// newAccountService wires the signup, verification and password reset flows
+func newAccountService(db *gorm.DB, cfg *config.Config, users userUseCase.UserUseCase) authUseCase.AccountUseCase {
+	return authUseCase.NewAccountService(
+		users,
+		userRepository.NewGormUserRepository(db),
+		authRepository.NewGormUserTokenRepository(db),
+		authRepository.NewGormSessionRepository(db),
+		newMailSender(cfg),
+		authUseCase.AccountConfig{
+			LinkBaseURL:     cfg.AppBaseURL,
+			VerificationTTL: time.Duration(cfg.EmailVerificationTTLHours) * time.Hour,
+			ResetTTL:        time.Duration(cfg.PasswordResetTTLMinutes) * time.Minute,
+		},
+	)
+}
+
+// newMailSender delivers over SMTP when it is configured. Outside production,
+// where Config.Validate requires SMTP, emails are captured in memory instead.
+func newMailSender(cfg *config.Config) mailer.Sender {
+	if cfg.SMTPHost != "" {
+		return mailer.NewSMTPSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom)
+	}
+	return mailer.NewCaptureSender(true)
+}
+
+// newSSOService wires campus sign-in through the configured identity provider
+func newSSOService(db *gorm.DB, cfg *config.Config, authService authUseCase.AuthUseCase) authUseCase.SSOUseCase {
+	client := oidc.NewClient(oidc.Config{
+		IssuerURL:    cfg.OIDCIssuerURL,
+		ClientID:     cfg.OIDCClientID,
+		ClientSecret: cfg.OIDCClientSecret,
+		RedirectURL:  cfg.OIDCRedirectURL,
+		Scopes:       []string{"email", "profile"},
+	}, &http.Client{Timeout: 10 * time.Second})
+	return authUseCase.NewSSOService(
+		client,
+		authRepository.NewGormSSORepository(db),
+		userRepository.NewGormUserRepository(db),
+		studentRepository.NewGormStudentRepository(db),
+		authService,
+	)
+}
@@ -0,0 +1,151 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/etag"
+	"github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	attendancepb "github.com/ePSA-eJya/Mess_Management/proto/attendance"
+	orderpb "github.com/ePSA-eJya/Mess_Management/proto/order"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// createdMethods answer 201 instead of 200 over REST
+var createdMethods = map[string]bool{
+	"/order.OrderService/CreateOrder":                true,
+	"/attendance.AttendanceService/RecordAttendance": true,
+}
+
+// RegisterGatewayRoutes serves the REST routes generated from the proto
+// definitions, see proto/gateway.yaml. Requests are forwarded to the
+// gRPC server over conn so they go through the same interceptors, validation
+// and handlers as native gRPC calls. It must be registered before
+// RegisterPrivateRoutes, the gRPC interceptors check the token themselves.
+func RegisterGatewayRoutes(app fiber.Router, conn grpc.ClientConnInterface) error {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		}),
+		runtime.WithIncomingHeaderMatcher(gatewayHeaders),
+		runtime.WithOutgoingHeaderMatcher(gatewayResponseHeaders),
+		runtime.WithErrorHandler(gatewayError),
+		runtime.WithForwardResponseOption(gatewayStatus),
+	)
+
+	if err := orderpb.RegisterOrderServiceHandlerClient(context.Background(), mux, orderpb.NewOrderServiceClient(conn)); err != nil {
+		return err
+	}
+	if err := attendancepb.RegisterAttendanceServiceHandlerClient(context.Background(), mux, attendancepb.NewAttendanceServiceClient(conn)); err != nil {
+		return err
+	}
+
+	gateway := adaptor.HTTPHandler(detachHeaders(mux))
+	handler := func(c *fiber.Ctx) error {
+		// a generated request ID is only in the response headers so far
+		if requestID, ok := c.Locals(responses.RequestIDKey).(string); ok {
+			c.Request().Header.Set(fiber.HeaderXRequestID, requestID)
+		}
+		return gateway(c)
+	}
+	app.Use("/api/v1/orders", handler)
+	app.Use("/api/v1/attendance", handler)
+	return nil
+}
+
+// detachHeaders copies the request headers before they reach the gateway.
+// fasthttpadaptor hands them over as views of fasthttp buffers that are reused
+// by the next request, while the gRPC client keeps header values in its HPACK
+// table, so a later token of the same length would be sent as a stale index.
+func detachHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := make(http.Header, len(r.Header))
+		for key, values := range r.Header {
+			cloned := make([]string, len(values))
+			for i, value := range values {
+				cloned[i] = strings.Clone(value)
+			}
+			header[strings.Clone(key)] = cloned
+		}
+		r.Header = header
+		r.Host = strings.Clone(r.Host)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// gatewayError writes gRPC errors in the same envelope as responses.Error
+func gatewayError(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	st := status.Convert(err)
+	envelope := apperror.FromStatus(st)
+	if envelope.RequestID == "" {
+		// the call failed before reaching the server
+		envelope.RequestID = r.Header.Get(fiber.HeaderXRequestID)
+	}
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		for key, values := range md.HeaderMD {
+			if name, ok := gatewayResponseHeaders(key); ok {
+				for _, value := range values {
+					w.Header().Add(name, value)
+				}
+			}
+		}
+	}
+	statusCode := runtime.HTTPStatusFromCode(st.Code())
+	if envelope.Code == apperror.CodePreconditionRequired {
+		// FailedPrecondition would turn into a 400, REST clients expect 428 like the fiber routes send
+		statusCode = fiber.StatusPreconditionRequired
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(responses.ErrorResponse{Error: envelope})
+}
+
+// gatewayHeaders forwards the request ID to the gRPC server so both log the
+// same one, If-Match so handlers can read it with etag.FromIncomingContext,
+// Idempotency-Key for middleware.GRPCIdempotencyInterceptor and the trace
+// context set by middleware.Tracing, other headers follow the gateway defaults
+func gatewayHeaders(key string) (string, bool) {
+	switch {
+	case strings.EqualFold(key, fiber.HeaderXRequestID):
+		return middleware.RequestIDMetadata, true
+	case strings.EqualFold(key, fiber.HeaderIfMatch):
+		return etag.IfMatchMetadata, true
+	case strings.EqualFold(key, middleware.IdempotencyKeyHeader):
+		return middleware.IdempotencyKeyMetadata, true
+	case strings.EqualFold(key, "traceparent"), strings.EqualFold(key, "tracestate"):
+		return strings.ToLower(key), true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// gatewayResponseHeaders writes the ETag, replay marker and Retry-After as
+// plain headers, other response metadata keeps the Grpc-Metadata- prefix
+func gatewayResponseHeaders(key string) (string, bool) {
+	switch key {
+	case etag.HeaderMetadata:
+		return fiber.HeaderETag, true
+	case middleware.IdempotentReplayedMetadata:
+		return middleware.IdempotentReplayedHeader, true
+	case middleware.RetryAfterMetadata:
+		return fiber.HeaderRetryAfter, true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+func gatewayStatus(ctx context.Context, w http.ResponseWriter, _ proto.Message) error {
+	if method, ok := runtime.RPCMethod(ctx); ok && createdMethods[method] {
+		w.WriteHeader(http.StatusCreated)
+	}
+	return nil
+}
@@ -0,0 +1,410 @@
+package routes_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	GrpcOrderHandler "github.com/ePSA-eJya/Mess_Management/internal/order/handler/grpc"
+	orderUseCase "github.com/ePSA-eJya/Mess_Management/internal/order/usecase"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pagination"
+	"github.com/ePSA-eJya/Mess_Management/pkg/ratelimit"
+	"github.com/ePSA-eJya/Mess_Management/pkg/routes"
+	"github.com/ePSA-eJya/Mess_Management/pkg/token"
+	orderpb "github.com/ePSA-eJya/Mess_Management/proto/order"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"gorm.io/gorm"
+)
+
+// memoryOrders keeps orders in maps so the gateway can be exercised without Postgres
+type memoryOrders struct {
+	orders  map[int]*entities.Order
+	deleted map[int]*entities.Order
+}
+
+func (m *memoryOrders) Save(_ context.Context, order *entities.Order) error {
+	order.ID = uint(len(m.orders) + len(m.deleted) + 1)
+	order.Version = 1
+	m.orders[int(order.ID)] = order
+	return nil
+}
+
+func (m *memoryOrders) FindAll(_ context.Context, query pagination.Query) (*pagination.Page[*entities.Order], error) {
+	page := &pagination.Page[*entities.Order]{Items: []*entities.Order{}}
+	for _, o := range m.orders {
+		if owner, ok := query.Filter["user_id"]; ok && (o.UserID == nil || o.UserID.String() != owner) {
+			continue
+		}
+		page.Items = append(page.Items, o)
+	}
+	page.Total = int64(len(page.Items))
+	return page, nil
+}
+
+func (m *memoryOrders) FindByID(_ context.Context, id int) (*entities.Order, error) {
+	order, ok := m.orders[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return order, nil
+}
+
+func (m *memoryOrders) Patch(_ context.Context, id int, order *entities.Order, version uint) error {
+	if version != 0 && m.orders[id].Version != version {
+		return apperror.ErrStaleVersion
+	}
+	m.orders[id].Total = order.Total
+	m.orders[id].Version++
+	return nil
+}
+
+func (m *memoryOrders) Delete(_ context.Context, id int) error {
+	m.orders[id].DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	m.deleted[id] = m.orders[id]
+	delete(m.orders, id)
+	return nil
+}
+
+func (m *memoryOrders) FindDeleted(_ context.Context, query pagination.Query, since time.Time) (*pagination.Page[*entities.Order], error) {
+	page := &pagination.Page[*entities.Order]{Items: []*entities.Order{}}
+	for _, o := range m.deleted {
+		if o.DeletedAt.Time.After(since) {
+			page.Items = append(page.Items, o)
+		}
+	}
+	page.Total = int64(len(page.Items))
+	return page, nil
+}
+
+func (m *memoryOrders) Restore(_ context.Context, id int, since time.Time) error {
+	order, ok := m.deleted[id]
+	if !ok || !order.DeletedAt.Time.After(since) {
+		return gorm.ErrRecordNotFound
+	}
+	order.DeletedAt = gorm.DeletedAt{}
+	m.orders[id] = order
+	delete(m.deleted, id)
+	return nil
+}
+
+func (m *memoryOrders) Purge(before time.Time) (int64, error) {
+	var purged int64
+	for id, o := range m.deleted {
+		if o.DeletedAt.Time.Before(before) {
+			delete(m.deleted, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// memoryIdempotency keeps idempotency keys in a map, they never expire
+type memoryIdempotency map[string]*middleware.IdempotentResponse
+
+func (m memoryIdempotency) Reserve(userID, key, requestHash string) (*middleware.IdempotentResponse, error) {
+	if entry, ok := m[userID+"/"+key]; ok {
+		return entry, nil
+	}
+	m[userID+"/"+key] = &middleware.IdempotentResponse{RequestHash: requestHash}
+	return nil, nil
+}
+
+func (m memoryIdempotency) Complete(userID, key string, statusCode int, body []byte) error {
+	m[userID+"/"+key] = &middleware.IdempotentResponse{
+		RequestHash: m[userID+"/"+key].RequestHash,
+		Completed:   true,
+		StatusCode:  statusCode,
+		Body:        body,
+	}
+	return nil
+}
+
+func (m memoryIdempotency) Release(userID, key string) error {
+	delete(m, userID+"/"+key)
+	return nil
+}
+
+type noRevocations struct{}
+
+func (noRevocations) IsRevoked(context.Context, *token.Claims) (bool, error) { return false, nil }
+
+func setupGateway(t *testing.T) (*fiber.App, *token.Manager) {
+	return setupLimitedGateway(t, middleware.RateLimits{})
+}
+
+func setupLimitedGateway(t *testing.T, limits middleware.RateLimits) (*fiber.App, *token.Manager) {
+	tokens := token.NewManager("secret", time.Minute)
+
+	server := grpc.NewServer(middleware.GRPCServerOptions(tokens, noRevocations{}, memoryIdempotency{}, limits)...)
+	orders := &memoryOrders{orders: map[int]*entities.Order{}, deleted: map[int]*entities.Order{}}
+	service := orderUseCase.NewOrderService(orders, time.Hour)
+	orderpb.RegisterOrderServiceServer(server, GrpcOrderHandler.NewGrpcOrderHandler(service))
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	app := fiber.New()
+	middleware.FiberMiddleware(app)
+	require.NoError(t, routes.RegisterGatewayRoutes(app, conn))
+	return app, tokens
+}
+
+func call(t *testing.T, app *fiber.App, method, target, accessToken string, body interface{}) (*http.Response, map[string]interface{}) {
+	var reader bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&reader).Encode(body))
+	}
+	req := httptest.NewRequest(method, target, &reader)
+	req.Header.Set("Content-Type", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+
+	var decoded map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&decoded)
+	return resp, decoded
+}
+
+func TestGatewayOrders(t *testing.T) {
+	app, tokens := setupGateway(t)
+	owner, _, err := tokens.Issue("9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", "STUDENT", "s1")
+	require.NoError(t, err)
+	other, _, err := tokens.Issue("0b5c1d4e-2f37-4a8b-9c6d-1e2f3a4b5c6d", "STUDENT", "s2")
+	require.NoError(t, err)
+
+	resp, body := call(t, app, "POST", "/api/v1/orders", owner, map[string]interface{}{"total": -5})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"field": "total", "rule": "gt", "message": "total must be greater than 0",
+	}}, body["error"].(map[string]interface{})["fields"])
+
+	resp, body = call(t, app, "POST", "/api/v1/orders", owner, map[string]interface{}{"total": 300})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, 300.0, body["total"])
+	assert.Equal(t, "9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", body["user_id"])
+
+	resp, body = call(t, app, "GET", "/api/v1/orders?limit=5&filter[user_id]=9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", owner, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, body["items"], 1)
+	assert.Equal(t, 1.0, body["total"])
+	assert.Equal(t, "", body["next_cursor"])
+
+	// students only ever list their own orders, whatever they filter by
+	_, body = call(t, app, "GET", "/api/v1/orders?filter[user_id]=9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", other, nil)
+	assert.Empty(t, body["items"])
+
+	resp, body = call(t, app, "PATCH", "/api/v1/orders/1", owner, map[string]interface{}{"total": 0})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	envelope := body["error"].(map[string]interface{})
+	assert.Equal(t, "INVALID_DATA", envelope["code"])
+	assert.Equal(t, "invalid data: total must be greater than 0", envelope["message"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"field": "total", "rule": "gt", "message": "total must be greater than 0",
+	}}, envelope["fields"])
+	assert.Equal(t, resp.Header.Get("X-Request-ID"), envelope["request_id"])
+
+	resp, _ = call(t, app, "PATCH", "/api/v1/orders/1", other, map[string]interface{}{"total": 10, "version": 1})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp, body = call(t, app, "PATCH", "/api/v1/orders/1", owner, map[string]interface{}{"total": 450, "version": 1})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 450.0, body["total"])
+
+	resp, body = call(t, app, "DELETE", "/api/v1/orders/1", owner, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "order deleted", body["message"])
+
+	resp, _ = call(t, app, "GET", "/api/v1/orders/1", owner, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestGatewayOrders_RestoreDeleted(t *testing.T) {
+	app, tokens := setupGateway(t)
+	owner, _, err := tokens.Issue("9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", "STUDENT", "s1")
+	require.NoError(t, err)
+	admin, _, err := tokens.Issue("0b5c1d4e-2f37-4a8b-9c6d-1e2f3a4b5c6d", "OFFICE_ADMIN", "s2")
+	require.NoError(t, err)
+
+	resp, _ := call(t, app, "POST", "/api/v1/orders", owner, map[string]interface{}{"total": 300})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp, _ = call(t, app, "DELETE", "/api/v1/orders/1", owner, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, _ = call(t, app, "GET", "/api/v1/orders/deleted", owner, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp, _ = call(t, app, "POST", "/api/v1/orders/1/restore", owner, nil)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp, body := call(t, app, "GET", "/api/v1/orders/deleted", admin, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, body["items"], 1)
+	assert.NotEmpty(t, body["items"].([]interface{})[0].(map[string]interface{})["deleted_at"])
+
+	resp, body = call(t, app, "POST", "/api/v1/orders/1/restore", admin, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "", body["deleted_at"])
+
+	resp, _ = call(t, app, "GET", "/api/v1/orders/1", owner, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp, _ = call(t, app, "POST", "/api/v1/orders/1/restore", admin, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestGatewayOrders_StaleVersion(t *testing.T) {
+	app, tokens := setupGateway(t)
+	owner, _, err := tokens.Issue("9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", "STUDENT", "s1")
+	require.NoError(t, err)
+	patch := func(ifMatch string, body map[string]interface{}) (*http.Response, map[string]interface{}) {
+		var reader bytes.Buffer
+		require.NoError(t, json.NewEncoder(&reader).Encode(body))
+		req := httptest.NewRequest("PATCH", "/api/v1/orders/1", &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+owner)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		resp, err := app.Test(req, -1)
+		require.NoError(t, err)
+		var decoded map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&decoded)
+		return resp, decoded
+	}
+
+	resp, _ := call(t, app, "POST", "/api/v1/orders", owner, map[string]interface{}{"total": 300})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp, body := call(t, app, "GET", "/api/v1/orders/1", owner, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"1"`, resp.Header.Get("ETag"))
+	assert.Equal(t, 1.0, body["version"])
+
+	resp, body = patch(`"1"`, map[string]interface{}{"total": 350})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"2"`, resp.Header.Get("ETag"))
+	assert.Equal(t, 2.0, body["version"])
+
+	// a second admin still holding version 1 must not overwrite the change
+	resp, body = patch(`"1"`, map[string]interface{}{"total": 400})
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Equal(t, "STALE_VERSION", body["error"].(map[string]interface{})["code"])
+	resp, _ = patch("", map[string]interface{}{"total": 400, "version": 1})
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, _ = patch("not-an-etag", map[string]interface{}{"total": 400})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// an update that names no version would be a blind overwrite
+	resp, body = patch("", map[string]interface{}{"total": 400})
+	assert.Equal(t, http.StatusPreconditionRequired, resp.StatusCode)
+	assert.Equal(t, "PRECONDITION_REQUIRED", body["error"].(map[string]interface{})["code"])
+	resp, _ = patch("*", map[string]interface{}{"total": 400})
+	assert.Equal(t, http.StatusPreconditionRequired, resp.StatusCode)
+
+	resp, body = patch("", map[string]interface{}{"total": 400, "version": 2})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 400.0, body["total"])
+	assert.Equal(t, 3.0, body["version"])
+}
+
+func TestGatewayOrders_IdempotencyKey(t *testing.T) {
+	app, tokens := setupGateway(t)
+	owner, _, err := tokens.Issue("9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", "STUDENT", "s1")
+	require.NoError(t, err)
+	create := func(total float64) (*http.Response, map[string]interface{}) {
+		var reader bytes.Buffer
+		require.NoError(t, json.NewEncoder(&reader).Encode(map[string]interface{}{"total": total}))
+		req := httptest.NewRequest("POST", "/api/v1/orders", &reader)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+owner)
+		req.Header.Set("Idempotency-Key", "kiosk-7-0001")
+		resp, err := app.Test(req, -1)
+		require.NoError(t, err)
+		var decoded map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&decoded)
+		return resp, decoded
+	}
+
+	resp, first := create(300)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Idempotent-Replayed"))
+
+	// the kiosk retries after a timeout and gets the same order back
+	resp, retried := create(300)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "true", resp.Header.Get("Idempotent-Replayed"))
+	assert.Equal(t, first, retried)
+
+	resp, body := create(450)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "invalid data: Idempotency-Key was already used for a different request", body["error"].(map[string]interface{})["message"])
+
+	_, body = call(t, app, "GET", "/api/v1/orders", owner, nil)
+	assert.Len(t, body["items"], 1)
+}
+
+func TestGatewayOrders_RateLimit(t *testing.T) {
+	app, tokens := setupLimitedGateway(t, middleware.RateLimits{
+		Store: ratelimit.NewMemoryStore(),
+		Read:  ratelimit.PerMinute("read", 2),
+		Write: ratelimit.PerMinute("write", 1),
+	})
+	owner, _, err := tokens.Issue("9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", "STUDENT", "s1")
+	require.NoError(t, err)
+	other, _, err := tokens.Issue("0b5c1d4e-2f37-4a8b-9c6d-1e2f3a4b5c6d", "STUDENT", "s2")
+	require.NoError(t, err)
+
+	resp, _ := call(t, app, "POST", "/api/v1/orders", owner, map[string]interface{}{"total": 300})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp, body := call(t, app, "POST", "/api/v1/orders", owner, map[string]interface{}{"total": 300})
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "60", resp.Header.Get("Retry-After"))
+	assert.Equal(t, "LIMIT_EXCEEDED", body["error"].(map[string]interface{})["code"])
+
+	// reads have their own budget, and every user their own buckets
+	for i := 0; i < 2; i++ {
+		resp, _ = call(t, app, "GET", "/api/v1/orders/1", owner, nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	resp, _ = call(t, app, "GET", "/api/v1/orders/1", owner, nil)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	resp, _ = call(t, app, "POST", "/api/v1/orders", other, map[string]interface{}{"total": 300})
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestGatewayOrders_RequireToken(t *testing.T) {
+	app, _ := setupGateway(t)
+
+	req := httptest.NewRequest("GET", "/api/v1/orders", nil)
+	req.Header.Set("X-Request-ID", "client-chosen-id")
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "client-chosen-id", resp.Header.Get("X-Request-ID"))
+	assert.Equal(t, map[string]interface{}{
+		"code":       "UNAUTHORIZED",
+		"message":    "missing token",
+		"request_id": "client-chosen-id",
+	}, body["error"])
+}
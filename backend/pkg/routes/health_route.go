@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/internal/database"
+	"github.com/ePSA-eJya/Mess_Management/pkg/config"
+	"github.com/ePSA-eJya/Mess_Management/pkg/health"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// NewHealthChecker checks that the database answers and has been migrated to
+// the schema of this build
+func NewHealthChecker(db *gorm.DB, cfg *config.Config) *health.Checker {
+	checker := health.NewChecker(time.Duration(cfg.HealthCheckTimeoutSeconds) * time.Second)
+	checker.Add("database", databaseCheck(db))
+	return checker
+}
+
+func databaseCheck(db *gorm.DB) health.Check {
+	return func(ctx context.Context) (map[string]any, error) {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		if err := sqlDB.PingContext(ctx); err != nil {
+			return nil, err
+		}
+		version, err := database.AppliedSchemaVersion(db.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		stats := sqlDB.Stats()
+		details := map[string]any{
+			"schema_version":          version,
+			"expected_schema_version": database.CurrentSchemaVersion,
+			"open_connections":        stats.OpenConnections,
+			"in_use":                  stats.InUse,
+		}
+		if version < database.CurrentSchemaVersion {
+			return details, fmt.Errorf("schema version %d is behind %d", version, database.CurrentSchemaVersion)
+		}
+		return details, nil
+	}
+}
+
+// HealthRoutes serves the probes of orchestrators, without a token:
+//   - /livez answers as long as the process serves requests
+//   - /healthz reports every dependency, 503 when one is down
+//   - /readyz is /healthz that also turns 503 once the server is draining
+func HealthRoutes(a *fiber.App, checker *health.Checker) {
+	a.Get("/livez", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"status": health.StatusUp})
+	})
+	a.Get("/healthz", func(c *fiber.Ctx) error {
+		return sendReport(c, checker.Check(c.UserContext()))
+	})
+	a.Get("/readyz", func(c *fiber.Ctx) error {
+		return sendReport(c, checker.Ready(c.UserContext()))
+	})
+}
+
+func sendReport(c *fiber.Ctx, report health.Report) error {
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	if !report.Up() {
+		c.Status(fiber.StatusServiceUnavailable)
+	}
+	return c.JSON(report)
+}
@@ -0,0 +1,42 @@
+package routes_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/health"
+	"github.com/ePSA-eJya/Mess_Management/pkg/routes"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthRoutes_DependencyDown(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	checker.Add("database", func(ctx context.Context) (map[string]any, error) {
+		return nil, errors.New("connection refused")
+	})
+	app := fiber.New()
+	routes.HealthRoutes(app, checker)
+
+	// a process whose database is down is alive, it is just not ready
+	resp, err := app.Test(httptest.NewRequest("GET", "/livez", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	for _, target := range []string{"/healthz", "/readyz"} {
+		resp, err := app.Test(httptest.NewRequest("GET", target, nil), -1)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusServiceUnavailable, resp.StatusCode, target)
+		assert.Equal(t, "no-store", resp.Header.Get(fiber.HeaderCacheControl))
+
+		var report health.Report
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+		assert.Equal(t, health.StatusDown, report.Status)
+		assert.Equal(t, "connection refused", report.Checks["database"].Error)
+	}
+}
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/ePSA-eJya/Mess_Management/pkg/metrics"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+)
+
+// MetricsRoute serves Prometheus metrics on /metrics. It needs no token, keep
+// it off the public internet at the proxy.
+func MetricsRoute(a *fiber.App) {
+	a.Get("/metrics", adaptor.HTTPHandler(metrics.Handler()))
+}
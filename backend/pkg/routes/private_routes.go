@@ -1,23 +1,219 @@
 package routes
 
 import (
+	"time"
+
+	adminRepository "github.com/ePSA-eJya/Mess_Management/internal/admin/repository"
+	attendanceRepository "github.com/ePSA-eJya/Mess_Management/internal/attendance/repository"
+	attendanceUseCase "github.com/ePSA-eJya/Mess_Management/internal/attendance/usecase"
+	auditHandler "github.com/ePSA-eJya/Mess_Management/internal/audit/handler/rest"
+	auditRepository "github.com/ePSA-eJya/Mess_Management/internal/audit/repository"
+	auditUseCase "github.com/ePSA-eJya/Mess_Management/internal/audit/usecase"
+	authHandler "github.com/ePSA-eJya/Mess_Management/internal/auth/handler/rest"
+	billingHandler "github.com/ePSA-eJya/Mess_Management/internal/billing/handler/rest"
+	billingRepository "github.com/ePSA-eJya/Mess_Management/internal/billing/repository"
+	billingUseCase "github.com/ePSA-eJya/Mess_Management/internal/billing/usecase"
+	cancellationHandler "github.com/ePSA-eJya/Mess_Management/internal/cancellation/handler/rest"
+	cancellationRepository "github.com/ePSA-eJya/Mess_Management/internal/cancellation/repository"
+	cancellationUseCase "github.com/ePSA-eJya/Mess_Management/internal/cancellation/usecase"
+	complaintHandler "github.com/ePSA-eJya/Mess_Management/internal/complaint/handler/rest"
+	complaintRepository "github.com/ePSA-eJya/Mess_Management/internal/complaint/repository"
+	complaintUseCase "github.com/ePSA-eJya/Mess_Management/internal/complaint/usecase"
+	dashboardHandler "github.com/ePSA-eJya/Mess_Management/internal/dashboard/handler/rest"
+	dashboardUseCase "github.com/ePSA-eJya/Mess_Management/internal/dashboard/usecase"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	idempotencyRepository "github.com/ePSA-eJya/Mess_Management/internal/idempotency/repository"
+	notificationHandler "github.com/ePSA-eJya/Mess_Management/internal/notification/handler/rest"
+	"github.com/ePSA-eJya/Mess_Management/internal/notification/notifier"
+	notificationRepository "github.com/ePSA-eJya/Mess_Management/internal/notification/repository"
+	notificationUseCase "github.com/ePSA-eJya/Mess_Management/internal/notification/usecase"
+	outboxHandler "github.com/ePSA-eJya/Mess_Management/internal/outbox/handler/rest"
+	outboxRepository "github.com/ePSA-eJya/Mess_Management/internal/outbox/repository"
+	outboxUseCase "github.com/ePSA-eJya/Mess_Management/internal/outbox/usecase"
+	studentHandler "github.com/ePSA-eJya/Mess_Management/internal/student/handler/rest"
+	studentRepository "github.com/ePSA-eJya/Mess_Management/internal/student/repository"
+	studentUseCase "github.com/ePSA-eJya/Mess_Management/internal/student/usecase"
 	userHandler "github.com/ePSA-eJya/Mess_Management/internal/user/handler/rest"
 	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
 	userUseCase "github.com/ePSA-eJya/Mess_Management/internal/user/usecase"
+	"github.com/ePSA-eJya/Mess_Management/pkg/config"
+	"github.com/ePSA-eJya/Mess_Management/pkg/filestore"
 	middleware "github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pubsub"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
-func RegisterPrivateRoutes(app fiber.Router, db *gorm.DB) {
+func RegisterPrivateRoutes(app fiber.Router, db *gorm.DB, cfg *config.Config, broker pubsub.Broker) {
+
+	authService, tokens := NewAuthService(db, cfg)
+	authHandler := authHandler.NewHttpAuthHandler(authService)
 
-	route := app.Group("/api/v1", middleware.JWTMiddleware())
+	// EventSource cannot set headers, the dashboard stream also takes the token from the URL
+	app.Use("/api/v1/dashboard/stream", middleware.TokenFromQuery("access_token"))
+	idempotencyRepo := idempotencyRepository.NewGormIdempotencyRepository(db, time.Duration(cfg.IdempotencyKeyTTLHours)*time.Hour)
+	route := app.Group("/api/v1",
+		middleware.JWTMiddleware(tokens, authService),
+		middleware.RateLimitByMethod(NewRateLimits(db, cfg)),
+		middleware.Idempotency(idempotencyRepo),
+	)
 
 	userRepo := userRepository.NewGormUserRepository(db)
-	userService := userUseCase.NewUserService(userRepo)
+	retention := time.Duration(cfg.SoftDeleteRetentionDays) * 24 * time.Hour
+	userService := userUseCase.NewUserService(userRepo, retention)
 	userHandler := userHandler.NewHttpUserHandler(userService)
 
+	// Notification
+	notificationRepo := notificationRepository.NewGormNotificationRepository(db)
+	notificationService := notificationUseCase.NewNotificationService(
+		notificationRepo,
+		userRepo,
+		notifier.FromConfig(notificationRepo, cfg)...,
+	)
+	notificationHandler := notificationHandler.NewHttpNotificationHandler(notificationService)
+
+	// Complaint
+	adminRepo := adminRepository.NewGormAdminRepository(db)
+	complaintRepo := complaintRepository.NewGormComplaintRepository(db)
+	complaintService := complaintUseCase.NewComplaintService(
+		complaintRepo,
+		adminRepo,
+		userRepo,
+		filestore.NewLocalFileStore(cfg.FileStoreDir),
+		notificationService,
+		broker,
+		complaintUseCase.SLA{
+			Acknowledge: time.Duration(cfg.ComplaintAckSLAHours) * time.Hour,
+			Resolve:     time.Duration(cfg.ComplaintResolveSLAHours) * time.Hour,
+		},
+	)
+	complaintHandler := complaintHandler.NewHttpComplaintHandler(complaintService)
+
+	// Billing
+	studentRepo := studentRepository.NewGormStudentRepository(db)
+	cancellationRepo := cancellationRepository.NewGormCancellationRepository(db)
+	billingService := billingUseCase.NewBillingService(
+		billingRepository.NewGormBillingRepository(db),
+		studentRepo,
+		cancellationRepo,
+		billingUseCase.Rates{
+			Breakfast: cfg.MealRateBreakfast,
+			Lunch:     cfg.MealRateLunch,
+			Dinner:    cfg.MealRateDinner,
+		},
+	)
+	billingHandler := billingHandler.NewHttpBillingHandler(billingService)
+
+	// Cancellation
+	cancellationService := cancellationUseCase.NewCancellationService(cancellationRepo, studentRepo, userRepo, broker, cfg.CancellationCutoffHour)
+	cancellationHandler := cancellationHandler.NewHttpCancellationHandler(cancellationService)
+
+	// Students
+	studentService := studentUseCase.NewStudentService(studentRepo, userRepo, retention)
+	studentHandler := studentHandler.NewHttpStudentHandler(studentService)
+
+	// Dashboard
+	dashboardService := dashboardUseCase.NewDashboardService(
+		adminRepo,
+		userRepo,
+		cancellationRepo,
+		attendanceUseCase.NewAttendanceService(
+			attendanceRepository.NewGormAttendanceRepository(db),
+			studentRepo,
+			cancellationRepo,
+			broker,
+		),
+		broker,
+		dashboardUseCase.SpikeAlert{
+			Threshold: cfg.CancellationSpikeThreshold,
+			Window:    time.Duration(cfg.CancellationSpikeWindowMinutes) * time.Minute,
+		},
+	)
+	dashboardHandler := dashboardHandler.NewHttpDashboardHandler(dashboardService, authService)
+
+	// Webhooks
+	outboxService := outboxUseCase.NewOutboxService(outboxRepository.NewGormOutboxRepository(db))
+	outboxHandler := outboxHandler.NewHttpOutboxHandler(outboxService)
+
+	// Audit log
+	auditService := auditUseCase.NewAuditService(auditRepository.NewGormAuditRepository(db))
+	auditHandler := auditHandler.NewHttpAuditHandler(auditService)
+
+	officeAdmin := middleware.RequireRole(string(entities.RoleOfficeAdmin))
+	anyAdmin := middleware.RequireRole(string(entities.RoleOfficeAdmin), string(entities.RoleMessAdmin))
+
 	route.Get("/me", userHandler.GetUser)
 
+	// User routes, everyone manages their own account and admins any account
+	userGroup := route.Group("/users")
+	userGroup.Get("/", anyAdmin, userHandler.FindAllUsers)
+	userGroup.Get("/deleted", officeAdmin, userHandler.FindDeletedUsers)
+	userGroup.Get("/:id", userHandler.FindUserByID)
+	userGroup.Patch("/:id", userHandler.PatchUser)
+	userGroup.Delete("/:id", userHandler.DeleteUser)
+	userGroup.Post("/:id/unlock", officeAdmin, authHandler.UnlockAccount)
+	userGroup.Post("/:id/restore", officeAdmin, userHandler.RestoreUser)
+
+	// Student routes, deleted students come back within the retention window
+	studentGroup := route.Group("/students", officeAdmin)
+	studentGroup.Get("/deleted", studentHandler.FindDeletedStudents)
+	studentGroup.Post("/:roll/restore", studentHandler.RestoreStudent)
+
+	// Order routes are generated from proto/order, see RegisterGatewayRoutes
+
+	// Auth routes
+	authGroup := route.Group("/auth")
+	authGroup.Post("/logout", authHandler.Logout)
+	authGroup.Post("/logout-all", authHandler.LogoutAll)
+	authGroup.Get("/sessions", authHandler.FindSessions)
+	authGroup.Delete("/sessions/:id", authHandler.RevokeSession)
+
+	// Complaint routes
+	complaintGroup := route.Group("/complaints")
+	complaintGroup.Get("/", complaintHandler.FindComplaints)
+	complaintGroup.Get("/:id", complaintHandler.FindComplaintByID)
+	complaintGroup.Post("/", complaintHandler.FileComplaint)
+	complaintGroup.Patch("/:id/status", complaintHandler.UpdateStatus)
+	complaintGroup.Post("/:id/attachments", complaintHandler.AddAttachment)
+	complaintGroup.Get("/:id/attachments/:attachmentId", complaintHandler.DownloadAttachment)
+
+	// Notification routes
+	notificationGroup := route.Group("/notifications")
+	notificationGroup.Get("/", notificationHandler.FindInbox)
+	notificationGroup.Get("/unread-count", notificationHandler.CountUnread)
+	notificationGroup.Post("/read-all", notificationHandler.MarkAllRead)
+	notificationGroup.Get("/preferences", notificationHandler.FindPreferences)
+	notificationGroup.Put("/preferences", notificationHandler.UpdatePreferences)
+	notificationGroup.Patch("/:id/read", notificationHandler.MarkRead)
+
+	// Billing routes
+	billGroup := route.Group("/bills", anyAdmin)
+	billGroup.Get("/", billingHandler.FindMonthlyBills)
+	billGroup.Get("/:id", billingHandler.FindMonthlyBillByID)
+	billGroup.Get("/:id/payments", billingHandler.FindPayments)
+	billGroup.Post("/:id/payments", officeAdmin, billingHandler.RecordPayment)
+
+	// Cancellation routes
+	cancellationGroup := route.Group("/cancellations")
+	cancellationGroup.Get("/", cancellationHandler.FindCancellations)
+	cancellationGroup.Post("/", cancellationHandler.CancelMeal)
+
+	// Dashboard routes
+	dashboardGroup := route.Group("/dashboard", anyAdmin)
+	dashboardGroup.Get("/stream", dashboardHandler.Stream)
+
+	// Webhook routes
+	webhookGroup := route.Group("/webhooks", officeAdmin)
+	webhookGroup.Get("/", outboxHandler.FindWebhooks)
+	webhookGroup.Post("/", outboxHandler.RegisterWebhook)
+	webhookGroup.Delete("/:id", outboxHandler.DeleteWebhook)
+	webhookGroup.Get("/dead-letters", outboxHandler.FindDeadLetters)
+	webhookGroup.Post("/dead-letters/:id/retry", outboxHandler.RetryEvent)
+
+	// Audit log routes
+	auditGroup := route.Group("/audit-logs", officeAdmin)
+	auditGroup.Get("/", auditHandler.FindLogs)
+	auditGroup.Get("/verify", auditHandler.Verify)
+
 }
@@ -1,55 +1,56 @@
 package routes
 
 import (
+	"strings"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 
-	// Order
-	orderHandler "github.com/ePSA-eJya/Mess_Management/internal/order/handler/rest"
-	orderRepository "github.com/ePSA-eJya/Mess_Management/internal/order/repository"
-	orderUseCase "github.com/ePSA-eJya/Mess_Management/internal/order/usecase"
+	// Auth
+	authHandler "github.com/ePSA-eJya/Mess_Management/internal/auth/handler/rest"
 
 	// User
-	userHandler "github.com/ePSA-eJya/Mess_Management/internal/user/handler/rest"
 	userRepository "github.com/ePSA-eJya/Mess_Management/internal/user/repository"
 	userUseCase "github.com/ePSA-eJya/Mess_Management/internal/user/usecase"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/config"
 )
 
-func RegisterPublicRoutes(app fiber.Router, db *gorm.DB) {
+func RegisterPublicRoutes(app fiber.Router, db *gorm.DB, cfg *config.Config) {
 
 	api := app.Group("/api/v1")
 
 	// === Dependency Wiring ===
 
-	// Order
-	orderRepo := orderRepository.NewGormOrderRepository(db)
-	orderService := orderUseCase.NewOrderService(orderRepo)
-	orderHandler := orderHandler.NewHttpOrderHandler(orderService)
-
 	// User
 	userRepo := userRepository.NewGormUserRepository(db)
-	userService := userUseCase.NewUserService(userRepo)
-	userHandler := userHandler.NewHttpUserHandler(userService)
+	userService := userUseCase.NewUserService(userRepo, time.Duration(cfg.SoftDeleteRetentionDays)*24*time.Hour)
+
+	// Auth
+	authService, _ := NewAuthService(db, cfg)
+	accountHandler := authHandler.NewHttpAccountHandler(newAccountService(db, cfg, userService))
+	ssoHandler := authHandler.NewHttpSSOHandler(
+		newSSOService(db, cfg, authService),
+		strings.HasPrefix(cfg.OIDCRedirectURL, "https://"),
+	)
+	authHandler := authHandler.NewHttpAuthHandler(authService)
 
 	// === Public Routes ===
 
-	// Auth routes (separated from /users)
-	authGroup := api.Group("/auth")
-	authGroup.Post("/signup", userHandler.Register)
-	authGroup.Post("/signin", userHandler.Login)
-
-	// User routes
-	userGroup := api.Group("/users")
-	userGroup.Get("/", userHandler.FindAllUsers)
-	userGroup.Get("/:id", userHandler.FindUserByID)
-	userGroup.Patch("/:id", userHandler.PatchUser)
-	userGroup.Delete("/:id", userHandler.DeleteUser)
-
-	// Order routes
-	orderGroup := api.Group("/orders")
-	orderGroup.Get("/", orderHandler.FindAllOrders)
-	orderGroup.Get("/:id", orderHandler.FindOrderByID)
-	orderGroup.Post("/", orderHandler.CreateOrder)
-	orderGroup.Patch("/:id", orderHandler.PatchOrder)
-	orderGroup.Delete("/:id", orderHandler.DeleteOrder)
+	// Auth routes (separated from /users), the limit also covers the private ones
+	authGroup := api.Group("/auth", authRateLimit(db, cfg))
+	authGroup.Post("/signup", accountHandler.Register)
+	authGroup.Post("/signin", authHandler.Login)
+	authGroup.Post("/refresh", authHandler.Refresh)
+	authGroup.Post("/verify-email", accountHandler.VerifyEmail)
+	authGroup.Post("/resend-verification", accountHandler.ResendVerification)
+	authGroup.Post("/forgot-password", accountHandler.ForgotPassword)
+	authGroup.Post("/reset-password", accountHandler.ResetPassword)
+
+	// Campus sign-in, only when an identity provider is configured
+	if cfg.OIDCIssuerURL != "" {
+		authGroup.Get("/oidc/login", ssoHandler.Begin)
+		authGroup.Get("/oidc/callback", ssoHandler.Callback)
+	}
 }
@@ -3,23 +3,35 @@ package routes_test
 import (
 	"bytes"
 	"encoding/json"
+	"net"
+	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ePSA-eJya/Mess_Management/internal/database"
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
 
 	"github.com/ePSA-eJya/Mess_Management/internal/app"
+	"github.com/ePSA-eJya/Mess_Management/internal/entities"
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
 	"github.com/ePSA-eJya/Mess_Management/pkg/config"
+	"github.com/ePSA-eJya/Mess_Management/pkg/health"
+	"github.com/ePSA-eJya/Mess_Management/pkg/pubsub"
+	"github.com/ePSA-eJya/Mess_Management/pkg/responses"
+	"github.com/ePSA-eJya/Mess_Management/pkg/routes"
 )
 
 type PublicRoutesTestSuite struct {
 	suite.Suite
 	db      *gorm.DB
 	app     *fiber.App
+	grpc    *grpc.Server
 	cfg     *config.Config
+	checker *health.Checker
 	cleanup func()
 }
 
@@ -30,13 +42,25 @@ func (s *PublicRoutesTestSuite) SetupTest() {
 	// Load config for dev environment
 	s.cfg = config.LoadConfig("dev")
 
+	// The order routes are served through the gRPC server, both share one broker
+	broker := pubsub.NewMemoryBroker()
+	s.checker = routes.NewHealthChecker(s.db, s.cfg)
+	lis, err := net.Listen("tcp", "localhost:0")
+	s.Require().NoError(err)
+	_, s.cfg.GrpcPort, _ = net.SplitHostPort(lis.Addr().String())
+	s.grpc, err = app.SetupGrpcServer(s.db, s.cfg, broker, s.checker)
+	s.Require().NoError(err, "Failed to setup gRPC server")
+	go func() { _ = s.grpc.Serve(lis) }()
+
 	// Setup REST server with test database (For registering routes and middleware)
-	var err error
-	s.app, err = app.SetupRestServer(s.db, s.cfg)
+	s.app, err = app.SetupRestServer(s.db, s.cfg, broker, s.checker)
 	s.NoError(err, "Failed to setup REST server")
 }
 
 func (s *PublicRoutesTestSuite) TearDownTest() {
+	if s.grpc != nil {
+		s.grpc.Stop()
+	}
 	// Clean up database after each test
 	if s.cleanup != nil {
 		s.cleanup()
@@ -47,20 +71,117 @@ func TestPublicRoutesTestSuite(t *testing.T) {
 	suite.Run(t, new(PublicRoutesTestSuite))
 }
 
-// === USER ROUTES ===
+// verifyEmail stands in for following the emailed verification link
+func (s *PublicRoutesTestSuite) verifyEmail(email string) {
+	s.Require().NoError(s.db.Model(&entities.User{}).
+		Where("email = ?", email).
+		Update("email_verified_at", time.Now()).Error)
+}
 
-func (s *PublicRoutesTestSuite) TestGetUsers() {
-	req := httptest.NewRequest("GET", "/api/v1/users", nil)
+// signIn creates a verified account and returns its access token
+func (s *PublicRoutesTestSuite) signIn(email string) string {
+	signupBody, _ := json.Marshal(map[string]string{"email": email, "password": "securepassword123", "name": "Route User"})
+	signupReq := httptest.NewRequest("POST", "/api/v1/auth/signup", bytes.NewBuffer(signupBody))
+	signupReq.Header.Set("Content-Type", "application/json")
+	signupResp, err := s.app.Test(signupReq, -1)
+	s.Require().NoError(err)
+	s.Require().Equal(fiber.StatusCreated, signupResp.StatusCode)
+	s.verifyEmail(email)
+
+	signinBody, _ := json.Marshal(map[string]string{"email": email, "password": "securepassword123"})
+	signinReq := httptest.NewRequest("POST", "/api/v1/auth/signin", bytes.NewBuffer(signinBody))
+	signinReq.Header.Set("Content-Type", "application/json")
+	signinResp, err := s.app.Test(signinReq, -1)
+	s.Require().NoError(err)
+	s.Require().Equal(fiber.StatusOK, signinResp.StatusCode)
+
+	var pair struct {
+		Token string `json:"token"`
+	}
+	s.Require().NoError(json.NewDecoder(signinResp.Body).Decode(&pair))
+	return pair.Token
+}
+
+func (s *PublicRoutesTestSuite) request(method, target, accessToken string, body interface{}) *http.Response {
+	var reader *bytes.Buffer
+	if body != nil {
+		jsonBody, _ := json.Marshal(body)
+		reader = bytes.NewBuffer(jsonBody)
+	} else {
+		reader = &bytes.Buffer{}
+	}
+
+	req := httptest.NewRequest(method, target, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
 	resp, err := s.app.Test(req, -1)
-	s.NoError(err)
-	s.Equal(fiber.StatusOK, resp.StatusCode)
+	s.Require().NoError(err)
+	return resp
 }
 
-func (s *PublicRoutesTestSuite) TestGetUserByID_NotFound() {
-	req := httptest.NewRequest("GET", "/api/v1/users/9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", nil)
+// patch sends a PATCH with If-Match, which the user routes require
+func (s *PublicRoutesTestSuite) patch(target, accessToken, ifMatch string, body interface{}) *http.Response {
+	jsonBody, _ := json.Marshal(body)
+	req := httptest.NewRequest("PATCH", target, bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
 	resp, err := s.app.Test(req, -1)
-	s.NoError(err)
-	s.NotEqual(fiber.StatusInternalServerError, resp.StatusCode)
+	s.Require().NoError(err)
+	return resp
+}
+
+// === HEALTH ROUTES ===
+
+func (s *PublicRoutesTestSuite) TestHealthProbes() {
+	s.Equal(fiber.StatusOK, s.request("GET", "/livez", "", nil).StatusCode)
+
+	resp := s.request("GET", "/readyz", "", nil)
+	s.Equal(fiber.StatusOK, resp.StatusCode)
+	var report health.Report
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&report))
+	s.Equal(health.StatusUp, report.Status)
+	s.Equal(health.StatusUp, report.Checks["database"].Status)
+	s.EqualValues(database.CurrentSchemaVersion, report.Checks["database"].Details["schema_version"])
+
+	// draining fails readiness only, the dependencies are still healthy
+	s.checker.Drain()
+	resp = s.request("GET", "/readyz", "", nil)
+	s.Equal(fiber.StatusServiceUnavailable, resp.StatusCode)
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&report))
+	s.Equal(health.StatusDraining, report.Status)
+	s.Equal(fiber.StatusOK, s.request("GET", "/healthz", "", nil).StatusCode)
+}
+
+// === USER ROUTES ===
+
+func (s *PublicRoutesTestSuite) TestUserRoutes_RequireToken() {
+	s.Equal(fiber.StatusUnauthorized, s.request("GET", "/api/v1/users", "", nil).StatusCode)
+	s.Equal(fiber.StatusUnauthorized, s.request("DELETE", "/api/v1/users/9a176ca5-f3e0-4994-869c-fac0e8c9d5dc", "", nil).StatusCode)
+}
+
+func (s *PublicRoutesTestSuite) TestGetUsers_AdminOnly() {
+	accessToken := s.signIn("lister@example.com")
+	s.Equal(fiber.StatusForbidden, s.request("GET", "/api/v1/users", accessToken, nil).StatusCode)
+}
+
+func (s *PublicRoutesTestSuite) TestDeleteUser_OnlySelf() {
+	victim := s.signIn("victim@example.com")
+	attacker := s.signIn("attacker@example.com")
+
+	var me struct {
+		ID string `json:"id"`
+	}
+	s.Require().NoError(json.NewDecoder(s.request("GET", "/api/v1/me", victim, nil).Body).Decode(&me))
+
+	s.Equal(fiber.StatusForbidden, s.request("DELETE", "/api/v1/users/"+me.ID, attacker, nil).StatusCode)
+	s.Equal(fiber.StatusForbidden, s.patch("/api/v1/users/"+me.ID, attacker, `"1"`, map[string]string{"name": "Pwned"}).StatusCode)
+	s.Equal(fiber.StatusPreconditionRequired, s.patch("/api/v1/users/"+me.ID, victim, "", map[string]string{"name": "Renamed"}).StatusCode)
+	s.Equal(fiber.StatusOK, s.patch("/api/v1/users/"+me.ID, victim, `"1"`, map[string]string{"name": "Renamed"}).StatusCode)
 }
 
 // === AUTH ROUTES ===
@@ -69,6 +190,7 @@ func (s *PublicRoutesTestSuite) TestSignup() {
 	body := map[string]string{
 		"email":    "testuser@example.com",
 		"password": "securepassword123",
+		"name":     "Test User",
 	}
 	jsonBody, _ := json.Marshal(body)
 
@@ -80,11 +202,32 @@ func (s *PublicRoutesTestSuite) TestSignup() {
 	s.True(resp.StatusCode == fiber.StatusOK || resp.StatusCode == fiber.StatusCreated)
 }
 
+func (s *PublicRoutesTestSuite) TestSignup_ValidationErrors() {
+	body, _ := json.Marshal(map[string]string{"email": "not-an-email", "password": "short"})
+	req := httptest.NewRequest("POST", "/api/v1/auth/signup", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.app.Test(req, -1)
+	s.NoError(err)
+	s.Equal(fiber.StatusBadRequest, resp.StatusCode)
+
+	var decoded responses.ErrorResponse
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(&decoded))
+	s.Equal(apperror.CodeInvalidData, decoded.Error.Code)
+	s.NotEmpty(decoded.Error.RequestID)
+	s.Equal([]apperror.FieldError{
+		{Field: "email", Rule: "email", Message: "email must be a valid email address"},
+		{Field: "password", Rule: "min", Message: "password must be at least 6 characters"},
+		{Field: "name", Rule: "required", Message: "name is required"},
+	}, decoded.Error.Fields)
+}
+
 func (s *PublicRoutesTestSuite) TestSignin() {
 	// First signup to create a user
 	signupBody := map[string]string{
 		"email":    "signinuser@example.com",
 		"password": "securepassword123",
+		"name":     "Signin User",
 	}
 	jsonSignupBody, _ := json.Marshal(signupBody)
 	signupReq := httptest.NewRequest("POST", "/api/v1/auth/signup", bytes.NewBuffer(jsonSignupBody))
@@ -101,9 +244,19 @@ func (s *PublicRoutesTestSuite) TestSignin() {
 	req := httptest.NewRequest("POST", "/api/v1/auth/signin", bytes.NewBuffer(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
 
+	// the address is not verified yet
 	resp, err := s.app.Test(req, -1)
 	s.NoError(err)
-	s.True(resp.StatusCode == fiber.StatusOK || resp.StatusCode == fiber.StatusUnauthorized)
+	s.Equal(fiber.StatusForbidden, resp.StatusCode)
+
+	s.verifyEmail("signinuser@example.com")
+
+	req = httptest.NewRequest("POST", "/api/v1/auth/signin", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err = s.app.Test(req, -1)
+	s.NoError(err)
+	s.Equal(fiber.StatusOK, resp.StatusCode)
 }
 
 func (s *PublicRoutesTestSuite) TestSignin_InvalidCredentials() {
@@ -121,75 +274,135 @@ func (s *PublicRoutesTestSuite) TestSignin_InvalidCredentials() {
 	s.Equal(fiber.StatusUnauthorized, resp.StatusCode)
 }
 
-// === ORDER ROUTES ===
+func (s *PublicRoutesTestSuite) TestRefreshAndLogout() {
+	signupBody, _ := json.Marshal(map[string]string{
+		"email":    "refreshuser@example.com",
+		"password": "securepassword123",
+		"name":     "Refresh User",
+	})
+	signupReq := httptest.NewRequest("POST", "/api/v1/auth/signup", bytes.NewBuffer(signupBody))
+	signupReq.Header.Set("Content-Type", "application/json")
+	_, _ = s.app.Test(signupReq, -1)
+	s.verifyEmail("refreshuser@example.com")
 
-func (s *PublicRoutesTestSuite) TestGetOrders() {
-	req := httptest.NewRequest("GET", "/api/v1/orders", nil)
-	resp, err := s.app.Test(req, -1)
+	signinBody, _ := json.Marshal(map[string]string{
+		"email":    "refreshuser@example.com",
+		"password": "securepassword123",
+	})
+	signinReq := httptest.NewRequest("POST", "/api/v1/auth/signin", bytes.NewBuffer(signinBody))
+	signinReq.Header.Set("Content-Type", "application/json")
+	signinResp, err := s.app.Test(signinReq, -1)
 	s.NoError(err)
-	s.Equal(fiber.StatusOK, resp.StatusCode)
+	s.Require().Equal(fiber.StatusOK, signinResp.StatusCode)
+
+	var pair struct {
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	s.NoError(json.NewDecoder(signinResp.Body).Decode(&pair))
+
+	refreshBody, _ := json.Marshal(map[string]string{"refresh_token": pair.RefreshToken})
+	refreshReq := httptest.NewRequest("POST", "/api/v1/auth/refresh", bytes.NewBuffer(refreshBody))
+	refreshReq.Header.Set("Content-Type", "application/json")
+	refreshResp, err := s.app.Test(refreshReq, -1)
+	s.NoError(err)
+	s.Equal(fiber.StatusOK, refreshResp.StatusCode)
+	s.NoError(json.NewDecoder(refreshResp.Body).Decode(&pair))
+
+	logoutReq := httptest.NewRequest("POST", "/api/v1/auth/logout", nil)
+	logoutReq.Header.Set("Authorization", "Bearer "+pair.Token)
+	logoutResp, err := s.app.Test(logoutReq, -1)
+	s.NoError(err)
+	s.Equal(fiber.StatusOK, logoutResp.StatusCode)
+
+	// the access token is revoked along with its session
+	meReq := httptest.NewRequest("GET", "/api/v1/me", nil)
+	meReq.Header.Set("Authorization", "Bearer "+pair.Token)
+	meResp, err := s.app.Test(meReq, -1)
+	s.NoError(err)
+	s.Equal(fiber.StatusUnauthorized, meResp.StatusCode)
 }
 
-func (s *PublicRoutesTestSuite) TestGetOrderByID_NotFound() {
-	req := httptest.NewRequest("GET", "/api/v1/orders/999", nil)
+func (s *PublicRoutesTestSuite) TestRefresh_InvalidToken() {
+	body, _ := json.Marshal(map[string]string{"refresh_token": "not-a-refresh-token"})
+	req := httptest.NewRequest("POST", "/api/v1/auth/refresh", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
 	resp, err := s.app.Test(req, -1)
 	s.NoError(err)
-	s.NotEqual(fiber.StatusInternalServerError, resp.StatusCode)
+	s.Equal(fiber.StatusUnauthorized, resp.StatusCode)
 }
 
-func (s *PublicRoutesTestSuite) TestCreateOrder() {
-	body := map[string]interface{}{
-		"total": 300,
-	}
-	jsonBody, _ := json.Marshal(body)
-
-	req := httptest.NewRequest("POST", "/api/v1/orders", bytes.NewBuffer(jsonBody))
+func (s *PublicRoutesTestSuite) TestForgotPassword_UnknownEmail() {
+	body, _ := json.Marshal(map[string]string{"email": "nobody@example.com"})
+	req := httptest.NewRequest("POST", "/api/v1/auth/forgot-password", bytes.NewBuffer(body))
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := s.app.Test(req, -1)
 	s.NoError(err)
-	s.True(resp.StatusCode == fiber.StatusOK || resp.StatusCode == fiber.StatusCreated)
+	s.Equal(fiber.StatusAccepted, resp.StatusCode)
 }
 
-func (s *PublicRoutesTestSuite) TestPatchOrder() {
-	// First create an order
-	createBody := map[string]interface{}{
-		"total": 300,
-	}
-	createJsonBody, _ := json.Marshal(createBody)
-	createReq := httptest.NewRequest("POST", "/api/v1/orders", bytes.NewBuffer(createJsonBody))
-	createReq.Header.Set("Content-Type", "application/json")
-	createResp, _ := s.app.Test(createReq, -1)
-	s.True(createResp.StatusCode == fiber.StatusOK || createResp.StatusCode == fiber.StatusCreated)
-
-	// Then try to patch it
-	body := map[string]interface{}{
-		"total": 3001,
-	}
-	jsonBody, _ := json.Marshal(body)
-
-	req := httptest.NewRequest("PATCH", "/api/v1/orders/1", bytes.NewBuffer(jsonBody))
+func (s *PublicRoutesTestSuite) TestVerifyEmail_InvalidToken() {
+	body, _ := json.Marshal(map[string]string{"token": "not-a-token"})
+	req := httptest.NewRequest("POST", "/api/v1/auth/verify-email", bytes.NewBuffer(body))
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := s.app.Test(req, -1)
 	s.NoError(err)
-	s.True(resp.StatusCode >= 200 && resp.StatusCode < 500)
+	s.Equal(fiber.StatusBadRequest, resp.StatusCode)
+}
+
+// === ORDER ROUTES ===
+
+func (s *PublicRoutesTestSuite) TestOrderRoutes_RequireToken() {
+	s.Equal(fiber.StatusUnauthorized, s.request("GET", "/api/v1/orders", "", nil).StatusCode)
+	s.Equal(fiber.StatusUnauthorized, s.request("POST", "/api/v1/orders", "", map[string]interface{}{"total": 300}).StatusCode)
+}
+
+func (s *PublicRoutesTestSuite) TestGetOrders() {
+	accessToken := s.signIn("orders@example.com")
+	s.Equal(fiber.StatusOK, s.request("GET", "/api/v1/orders", accessToken, nil).StatusCode)
+}
+
+func (s *PublicRoutesTestSuite) TestGetOrderByID_NotFound() {
+	accessToken := s.signIn("orders@example.com")
+	resp := s.request("GET", "/api/v1/orders/999", accessToken, nil)
+	s.NotEqual(fiber.StatusInternalServerError, resp.StatusCode)
+}
+
+func (s *PublicRoutesTestSuite) TestCreateOrder() {
+	accessToken := s.signIn("orders@example.com")
+	resp := s.request("POST", "/api/v1/orders", accessToken, map[string]interface{}{"total": 300})
+	s.Equal(fiber.StatusCreated, resp.StatusCode)
+}
+
+func (s *PublicRoutesTestSuite) TestPatchOrder() {
+	owner := s.signIn("orders@example.com")
+	createResp := s.request("POST", "/api/v1/orders", owner, map[string]interface{}{"total": 300})
+	s.Require().Equal(fiber.StatusCreated, createResp.StatusCode)
+	s.Equal(fiber.StatusBadRequest, s.request("PATCH", "/api/v1/orders/1", owner, map[string]interface{}{"total": 0}).StatusCode)
+
+	other := s.signIn("someone@example.com")
+	s.Equal(fiber.StatusForbidden, s.request("PATCH", "/api/v1/orders/1", other, map[string]interface{}{"total": 1, "version": 1}).StatusCode)
+	s.Equal(fiber.StatusOK, s.request("PATCH", "/api/v1/orders/1", owner, map[string]interface{}{"total": 3001, "version": 1}).StatusCode)
 }
 
 func (s *PublicRoutesTestSuite) TestDeleteOrder() {
-	// First create an order
-	createBody := map[string]interface{}{
-		"total": 300,
-	}
-	createJsonBody, _ := json.Marshal(createBody)
-	createReq := httptest.NewRequest("POST", "/api/v1/orders", bytes.NewBuffer(createJsonBody))
-	createReq.Header.Set("Content-Type", "application/json")
-	createResp, _ := s.app.Test(createReq, -1)
-	s.True(createResp.StatusCode == fiber.StatusOK || createResp.StatusCode == fiber.StatusCreated)
-
-	// Then try to delete it
-	req := httptest.NewRequest("DELETE", "/api/v1/orders/1", nil)
-	resp, err := s.app.Test(req, -1)
-	s.NoError(err)
-	s.True(resp.StatusCode >= 200 && resp.StatusCode < 500)
+	owner := s.signIn("orders@example.com")
+	createResp := s.request("POST", "/api/v1/orders", owner, map[string]interface{}{"total": 300})
+	s.Require().Equal(fiber.StatusCreated, createResp.StatusCode)
+
+	other := s.signIn("someone@example.com")
+	s.Equal(fiber.StatusForbidden, s.request("DELETE", "/api/v1/orders/1", other, nil).StatusCode)
+	s.Equal(fiber.StatusOK, s.request("DELETE", "/api/v1/orders/1", owner, nil).StatusCode)
+}
+
+func (s *PublicRoutesTestSuite) TestDashboardStream_AdminsOnly() {
+	s.Equal(fiber.StatusUnauthorized, s.request("GET", "/api/v1/dashboard/stream", "", nil).StatusCode)
+
+	// the token is also accepted from the URL for EventSource clients
+	student := s.signIn("student@example.com")
+	s.Equal(fiber.StatusForbidden, s.request("GET", "/api/v1/dashboard/stream", student, nil).StatusCode)
+	s.Equal(fiber.StatusForbidden, s.request("GET", "/api/v1/dashboard/stream?access_token="+student, "", nil).StatusCode)
 }
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	rateLimitRepository "github.com/ePSA-eJya/Mess_Management/internal/ratelimit/repository"
+	"github.com/ePSA-eJya/Mess_Management/pkg/config"
+	"github.com/ePSA-eJya/Mess_Management/pkg/middleware"
+	"github.com/ePSA-eJya/Mess_Management/pkg/ratelimit"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// NewRateLimits wires the read and write limits of the REST and gRPC servers.
+// With the memory store every server keeps its own buckets, the postgres
+// store shares them between servers and replicas.
+func NewRateLimits(db *gorm.DB, cfg *config.Config) middleware.RateLimits {
+	return middleware.RateLimits{
+		Store: newRateLimitStore(db, cfg),
+		Read:  ratelimit.PerMinute("read", cfg.RateLimitReadPerMinute),
+		Write: ratelimit.PerMinute("write", cfg.RateLimitWritePerMinute),
+	}
+}
+
+// authRateLimit is the stricter limit of the /auth routes, keyed by IP address
+func authRateLimit(db *gorm.DB, cfg *config.Config) fiber.Handler {
+	return middleware.RateLimit(newRateLimitStore(db, cfg), ratelimit.PerMinute("auth", cfg.RateLimitAuthPerMinute))
+}
+
+func newRateLimitStore(db *gorm.DB, cfg *config.Config) ratelimit.Store {
+	if cfg.RateLimitStore == "postgres" {
+		return rateLimitRepository.NewGormRateLimitRepository(db)
+	}
+	return ratelimit.NewMemoryStore()
+}
@@ -0,0 +1,155 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/logger"
+)
+
+var log = logger.For("scheduler")
+
+var ErrDuplicateJob = errors.New("scheduler: job already registered")
+
+// parser accepts standard 5-field expressions, an optional leading seconds
+// field and descriptors such as @daily
+var parser = cron.NewParser(
+	cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+)
+
+// Job is a unit of scheduled work
+type Job struct {
+	Name string
+	Spec string
+	Run  func(ctx context.Context) error
+}
+
+// RunStore persists job runs and decides which replica executes a tick
+type RunStore interface {
+	// Claim records the run of job for scheduledAt, claimed is false when
+	// the tick was already taken by this or another replica
+	Claim(job string, scheduledAt time.Time) (runID uint, claimed bool, err error)
+	Finish(runID uint, runErr error) error
+}
+
+type entry struct {
+	job      Job
+	schedule cron.Schedule
+}
+
+// Scheduler runs registered jobs in-process on their cron schedules
+type Scheduler struct {
+	store   RunStore
+	entries []entry
+
+	mu      sync.Mutex
+	started bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+func New(store RunStore) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{store: store, ctx: ctx, cancel: cancel}
+}
+
+// Register adds a job, it must be called before Start
+func (s *Scheduler) Register(job Job) error {
+	schedule, err := parser.Parse(job.Spec)
+	if err != nil {
+		return fmt.Errorf("scheduler: job %q: %w", job.Name, err)
+	}
+	for _, e := range s.entries {
+		if e.job.Name == job.Name {
+			return ErrDuplicateJob
+		}
+	}
+	s.entries = append(s.entries, entry{job: job, schedule: schedule})
+	return nil
+}
+
+// Start launches one timer loop per job
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return
+	}
+	s.started = true
+
+	for _, e := range s.entries {
+		s.wg.Add(1)
+		go s.loop(e)
+	}
+}
+
+// Stop cancels pending ticks and the context of running jobs, then waits
+// for them to return or for ctx to expire
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) loop(e entry) {
+	defer s.wg.Done()
+
+	for {
+		next := e.schedule.Next(time.Now())
+		timer := time.NewTimer(time.Until(next))
+
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.execute(e.job, next)
+		}
+	}
+}
+
+func (s *Scheduler) execute(job Job, scheduledAt time.Time) {
+	runID, claimed, err := s.store.Claim(job.Name, scheduledAt)
+	if err != nil {
+		log.Error("Claiming job run failed", "job", job.Name, "error", err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	runErr := s.run(job)
+	if runErr != nil {
+		log.Error("Job failed", "job", job.Name, "error", runErr)
+	}
+	if err := s.store.Finish(runID, runErr); err != nil {
+		log.Error("Recording job run failed", "job", job.Name, "error", err)
+	}
+}
+
+// run shields the loop from panicking jobs
+func (s *Scheduler) run(job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return job.Run(s.ctx)
+}
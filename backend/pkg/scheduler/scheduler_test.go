@@ -0,0 +1,146 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/scheduler"
+	"github.com/stretchr/testify/suite"
+)
+
+// memoryStore mimics the database store: each (job, tick) is claimed once
+type memoryStore struct {
+	mu       sync.Mutex
+	claimed  map[string]bool
+	finished map[uint]error
+	nextID   uint
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{claimed: map[string]bool{}, finished: map[uint]error{}}
+}
+
+func (m *memoryStore) Claim(job string, scheduledAt time.Time) (uint, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := job + scheduledAt.String()
+	if m.claimed[key] {
+		return 0, false, nil
+	}
+	m.claimed[key] = true
+	m.nextID++
+	return m.nextID, true, nil
+}
+
+func (m *memoryStore) Finish(runID uint, runErr error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.finished[runID] = runErr
+	return nil
+}
+
+func (m *memoryStore) results() map[uint]error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make(map[uint]error, len(m.finished))
+	for k, v := range m.finished {
+		out[k] = v
+	}
+	return out
+}
+
+type SchedulerTestSuite struct {
+	suite.Suite
+	store *memoryStore
+}
+
+func (s *SchedulerTestSuite) SetupTest() {
+	s.store = newMemoryStore()
+}
+
+func TestSchedulerTestSuite(t *testing.T) {
+	suite.Run(t, new(SchedulerTestSuite))
+}
+
+func (s *SchedulerTestSuite) TestRegister_InvalidSpec() {
+	sched := scheduler.New(s.store)
+	err := sched.Register(scheduler.Job{Name: "bad", Spec: "every tuesday", Run: func(context.Context) error { return nil }})
+	s.Error(err)
+}
+
+func (s *SchedulerTestSuite) TestRegister_Duplicate() {
+	sched := scheduler.New(s.store)
+	job := scheduler.Job{Name: "billing", Spec: "@daily", Run: func(context.Context) error { return nil }}
+	s.NoError(sched.Register(job))
+	s.ErrorIs(sched.Register(job), scheduler.ErrDuplicateJob)
+}
+
+func (s *SchedulerTestSuite) TestReplicasExecuteEachTickOnce() {
+	var mu sync.Mutex
+	runs := 0
+	job := scheduler.Job{Name: "billing", Spec: "* * * * * *", Run: func(context.Context) error {
+		mu.Lock()
+		runs++
+		mu.Unlock()
+		return nil
+	}}
+
+	// two schedulers sharing one store behave like two replicas
+	a, b := scheduler.New(s.store), scheduler.New(s.store)
+	s.NoError(a.Register(job))
+	s.NoError(b.Register(job))
+	a.Start()
+	b.Start()
+
+	s.Eventually(func() bool { return len(s.store.results()) >= 1 }, 3*time.Second, 50*time.Millisecond)
+	s.NoError(a.Stop(context.Background()))
+	s.NoError(b.Stop(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	s.Equal(len(s.store.results()), runs)
+}
+
+func (s *SchedulerTestSuite) TestFailuresAndPanicsAreRecorded() {
+	sched := scheduler.New(s.store)
+	s.NoError(sched.Register(scheduler.Job{Name: "fails", Spec: "* * * * * *", Run: func(context.Context) error {
+		return errors.New("smtp down")
+	}}))
+	s.NoError(sched.Register(scheduler.Job{Name: "panics", Spec: "* * * * * *", Run: func(context.Context) error {
+		panic("nil map")
+	}}))
+	sched.Start()
+
+	s.Eventually(func() bool { return len(s.store.results()) >= 2 }, 3*time.Second, 50*time.Millisecond)
+	s.NoError(sched.Stop(context.Background()))
+
+	for _, err := range s.store.results() {
+		s.Error(err)
+	}
+}
+
+func (s *SchedulerTestSuite) TestStopWaitsForRunningJob() {
+	started := make(chan struct{})
+	finished := make(chan struct{})
+
+	sched := scheduler.New(s.store)
+	s.NoError(sched.Register(scheduler.Job{Name: "slow", Spec: "* * * * * *", Run: func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		close(finished)
+		return ctx.Err()
+	}}))
+	sched.Start()
+
+	<-started
+	s.NoError(sched.Stop(context.Background()))
+
+	select {
+	case <-finished:
+	default:
+		s.Fail("Stop returned before the running job finished")
+	}
+}
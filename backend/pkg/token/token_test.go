@@ -0,0 +1,63 @@
+package token_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/token"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueAndParse(t *testing.T) {
+	m := token.NewManager("secret", time.Minute)
+
+	signed, issued, err := m.Issue("user-1", "STUDENT", "session-1")
+	require.NoError(t, err)
+
+	claims, err := m.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.UserID)
+	assert.Equal(t, "STUDENT", claims.Role)
+	assert.Equal(t, "session-1", claims.SessionID)
+	assert.Equal(t, issued.ID, claims.ID)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), claims.ExpiresAt.Time, 2*time.Second)
+}
+
+func TestParse_Rejects(t *testing.T) {
+	m := token.NewManager("secret", time.Minute)
+
+	expired, _, err := token.NewManager("secret", -time.Minute).Issue("user-1", "STUDENT", "s")
+	require.NoError(t, err)
+	otherKey, _, err := token.NewManager("other", time.Minute).Issue("user-1", "STUDENT", "s")
+	require.NoError(t, err)
+	noneAlg, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{
+		"user_id": "user-1", "exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+	noExpiry, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"user_id": "user-1"}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	for name, tok := range map[string]string{
+		"expired":   expired,
+		"other key": otherKey,
+		"none alg":  noneAlg,
+		"no expiry": noExpiry,
+		"garbage":   "not-a-token",
+	} {
+		_, err := m.Parse(tok)
+		assert.ErrorIs(t, err, token.ErrInvalidToken, name)
+	}
+}
+
+func TestOpaqueAndHash(t *testing.T) {
+	a, err := token.NewOpaque()
+	require.NoError(t, err)
+	b, err := token.NewOpaque()
+	require.NoError(t, err)
+
+	assert.NotEqual(t, a, b)
+	assert.Len(t, token.Hash(a), 64)
+	assert.Equal(t, token.Hash(a), token.Hash(a))
+}
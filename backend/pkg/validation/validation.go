@@ -0,0 +1,129 @@
+// Package validation evaluates the validate struct tags of request DTOs and
+// reports every failed field, so REST and gRPC handlers reject bad input the
+// same way before it reaches a usecase. The fields end up in the error
+// envelope built by apperror.From.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Error lists the fields of a request that failed validation. It matches
+// apperror.ErrInvalidData so it maps to 400 and InvalidArgument.
+type Error struct {
+	Fields []apperror.FieldError
+}
+
+func (e *Error) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Message
+	}
+	return fmt.Sprintf("%s: %s", apperror.ErrInvalidData, strings.Join(messages, "; "))
+}
+
+func (e *Error) Unwrap() error {
+	return apperror.ErrInvalidData
+}
+
+func (e *Error) FieldErrors() []apperror.FieldError {
+	return e.Fields
+}
+
+var validate = newValidate()
+
+func newValidate() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	// report fields by the name clients send them with
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			return ""
+		case "":
+			return field.Name
+		}
+		return name
+	})
+	return v
+}
+
+// Struct validates v by its validate tags, returning an *Error when a field fails
+func Struct(v any) error {
+	err := validate.Struct(v)
+	if err == nil {
+		return nil
+	}
+
+	var failed validator.ValidationErrors
+	if !errors.As(err, &failed) {
+		// v is not a struct, a programming error rather than bad input
+		return err
+	}
+
+	fields := make([]apperror.FieldError, len(failed))
+	for i, fe := range failed {
+		field := fieldPath(fe)
+		fields[i] = apperror.FieldError{Field: field, Rule: fe.Tag(), Message: field + " " + describe(fe)}
+	}
+	return &Error{Fields: fields}
+}
+
+// ParseBody decodes the request body into out and validates it
+func ParseBody(c *fiber.Ctx, out any) error {
+	if err := c.BodyParser(out); err != nil {
+		return fmt.Errorf("%w: malformed request body", apperror.ErrInvalidData)
+	}
+	return Struct(out)
+}
+
+// fieldPath drops the struct name from the namespace, e.g. preferences[0].event
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+	return path
+}
+
+func describe(fe validator.FieldError) string {
+	isString := fe.Kind() == reflect.String
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "url":
+		return "must be a valid URL"
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "datetime":
+		return "must be formatted as " + fe.Param()
+	case "min":
+		if isString {
+			return fmt.Sprintf("must be at least %s characters", fe.Param())
+		}
+		return "must be at least " + fe.Param()
+	case "max":
+		if isString {
+			return fmt.Sprintf("must be at most %s characters", fe.Param())
+		}
+		return "must be at most " + fe.Param()
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "gte":
+		return "must be at least " + fe.Param()
+	case "lt":
+		return "must be less than " + fe.Param()
+	case "lte":
+		return "must be at most " + fe.Param()
+	}
+	return fmt.Sprintf("failed the %s rule", fe.Tag())
+}
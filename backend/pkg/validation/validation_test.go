@@ -0,0 +1,44 @@
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/ePSA-eJya/Mess_Management/pkg/apperror"
+	"github.com/ePSA-eJya/Mess_Management/pkg/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type preference struct {
+	Channel string `json:"channel" validate:"required,oneof=EMAIL IN_APP"`
+}
+
+type request struct {
+	Email       string       `json:"email" validate:"required,email"`
+	Password    string       `json:"password" validate:"required,min=6"`
+	Amount      float64      `json:"amount" validate:"gt=0"`
+	Preferences []preference `json:"preferences" validate:"dive"`
+}
+
+func TestStruct_Valid(t *testing.T) {
+	err := validation.Struct(&request{Email: "a@example.com", Password: "secret", Amount: 1})
+	assert.NoError(t, err)
+}
+
+func TestStruct_ReportsEveryField(t *testing.T) {
+	err := validation.Struct(&request{
+		Email:       "not-an-email",
+		Password:    "short",
+		Preferences: []preference{{Channel: "SMS"}},
+	})
+
+	assert.ErrorIs(t, err, apperror.ErrInvalidData)
+	var invalid *validation.Error
+	require.ErrorAs(t, err, &invalid)
+	assert.Equal(t, []apperror.FieldError{
+		{Field: "email", Rule: "email", Message: "email must be a valid email address"},
+		{Field: "password", Rule: "min", Message: "password must be at least 6 characters"},
+		{Field: "amount", Rule: "gt", Message: "amount must be greater than 0"},
+		{Field: "preferences[0].channel", Rule: "oneof", Message: "preferences[0].channel must be one of EMAIL, IN_APP"},
+	}, invalid.Fields)
+}
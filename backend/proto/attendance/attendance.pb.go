@@ -0,0 +1,501 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: proto/attendance/attendance.proto
+
+package attendancepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MealAttendance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Roll          uint32                 `protobuf:"varint,2,opt,name=roll,proto3" json:"roll,omitempty"`
+	MessNo        uint32                 `protobuf:"varint,3,opt,name=mess_no,json=messNo,proto3" json:"mess_no,omitempty"`
+	MealType      string                 `protobuf:"bytes,4,opt,name=meal_type,json=mealType,proto3" json:"meal_type,omitempty"` // BREAKFAST, LUNCH or DINNER
+	Date          string                 `protobuf:"bytes,5,opt,name=date,proto3" json:"date,omitempty"`                         // YYYY-MM-DD
+	RecordedBy    string                 `protobuf:"bytes,6,opt,name=recorded_by,json=recordedBy,proto3" json:"recorded_by,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC 3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MealAttendance) Reset() {
+	*x = MealAttendance{}
+	mi := &file_proto_attendance_attendance_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MealAttendance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MealAttendance) ProtoMessage() {}
+
+func (x *MealAttendance) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attendance_attendance_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MealAttendance.ProtoReflect.Descriptor instead.
+func (*MealAttendance) Descriptor() ([]byte, []int) {
+	return file_proto_attendance_attendance_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MealAttendance) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MealAttendance) GetRoll() uint32 {
+	if x != nil {
+		return x.Roll
+	}
+	return 0
+}
+
+func (x *MealAttendance) GetMessNo() uint32 {
+	if x != nil {
+		return x.MessNo
+	}
+	return 0
+}
+
+func (x *MealAttendance) GetMealType() string {
+	if x != nil {
+		return x.MealType
+	}
+	return ""
+}
+
+func (x *MealAttendance) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *MealAttendance) GetRecordedBy() string {
+	if x != nil {
+		return x.RecordedBy
+	}
+	return ""
+}
+
+func (x *MealAttendance) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type MealCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessNo        uint32                 `protobuf:"varint,1,opt,name=mess_no,json=messNo,proto3" json:"mess_no,omitempty"`
+	MealType      string                 `protobuf:"bytes,2,opt,name=meal_type,json=mealType,proto3" json:"meal_type,omitempty"`
+	Date          string                 `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"` // YYYY-MM-DD
+	Served        int64                  `protobuf:"varint,4,opt,name=served,proto3" json:"served,omitempty"`
+	Expected      int64                  `protobuf:"varint,5,opt,name=expected,proto3" json:"expected,omitempty"` // active students of the mess who did not cancel the meal
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MealCount) Reset() {
+	*x = MealCount{}
+	mi := &file_proto_attendance_attendance_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MealCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MealCount) ProtoMessage() {}
+
+func (x *MealCount) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attendance_attendance_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MealCount.ProtoReflect.Descriptor instead.
+func (*MealCount) Descriptor() ([]byte, []int) {
+	return file_proto_attendance_attendance_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MealCount) GetMessNo() uint32 {
+	if x != nil {
+		return x.MessNo
+	}
+	return 0
+}
+
+func (x *MealCount) GetMealType() string {
+	if x != nil {
+		return x.MealType
+	}
+	return ""
+}
+
+func (x *MealCount) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *MealCount) GetServed() int64 {
+	if x != nil {
+		return x.Served
+	}
+	return 0
+}
+
+func (x *MealCount) GetExpected() int64 {
+	if x != nil {
+		return x.Expected
+	}
+	return 0
+}
+
+type RecordAttendanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roll          uint32                 `protobuf:"varint,1,opt,name=roll,proto3" json:"roll,omitempty"`
+	MealType      string                 `protobuf:"bytes,2,opt,name=meal_type,json=mealType,proto3" json:"meal_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordAttendanceRequest) Reset() {
+	*x = RecordAttendanceRequest{}
+	mi := &file_proto_attendance_attendance_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordAttendanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordAttendanceRequest) ProtoMessage() {}
+
+func (x *RecordAttendanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attendance_attendance_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordAttendanceRequest.ProtoReflect.Descriptor instead.
+func (*RecordAttendanceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_attendance_attendance_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RecordAttendanceRequest) GetRoll() uint32 {
+	if x != nil {
+		return x.Roll
+	}
+	return 0
+}
+
+func (x *RecordAttendanceRequest) GetMealType() string {
+	if x != nil {
+		return x.MealType
+	}
+	return ""
+}
+
+type RecordAttendanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attendance    *MealAttendance        `protobuf:"bytes,1,opt,name=attendance,proto3" json:"attendance,omitempty"`
+	Count         *MealCount             `protobuf:"bytes,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordAttendanceResponse) Reset() {
+	*x = RecordAttendanceResponse{}
+	mi := &file_proto_attendance_attendance_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordAttendanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordAttendanceResponse) ProtoMessage() {}
+
+func (x *RecordAttendanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attendance_attendance_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordAttendanceResponse.ProtoReflect.Descriptor instead.
+func (*RecordAttendanceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_attendance_attendance_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RecordAttendanceResponse) GetAttendance() *MealAttendance {
+	if x != nil {
+		return x.Attendance
+	}
+	return nil
+}
+
+func (x *RecordAttendanceResponse) GetCount() *MealCount {
+	if x != nil {
+		return x.Count
+	}
+	return nil
+}
+
+type MealCountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessNo        uint32                 `protobuf:"varint,1,opt,name=mess_no,json=messNo,proto3" json:"mess_no,omitempty"`
+	MealType      string                 `protobuf:"bytes,2,opt,name=meal_type,json=mealType,proto3" json:"meal_type,omitempty"`
+	Date          string                 `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"` // YYYY-MM-DD, today when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MealCountRequest) Reset() {
+	*x = MealCountRequest{}
+	mi := &file_proto_attendance_attendance_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MealCountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MealCountRequest) ProtoMessage() {}
+
+func (x *MealCountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attendance_attendance_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MealCountRequest.ProtoReflect.Descriptor instead.
+func (*MealCountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_attendance_attendance_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MealCountRequest) GetMessNo() uint32 {
+	if x != nil {
+		return x.MessNo
+	}
+	return 0
+}
+
+func (x *MealCountRequest) GetMealType() string {
+	if x != nil {
+		return x.MealType
+	}
+	return ""
+}
+
+func (x *MealCountRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type GetMealCountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         *MealCount             `protobuf:"bytes,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMealCountResponse) Reset() {
+	*x = GetMealCountResponse{}
+	mi := &file_proto_attendance_attendance_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMealCountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMealCountResponse) ProtoMessage() {}
+
+func (x *GetMealCountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_attendance_attendance_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMealCountResponse.ProtoReflect.Descriptor instead.
+func (*GetMealCountResponse) Descriptor() ([]byte, []int) {
+	return file_proto_attendance_attendance_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetMealCountResponse) GetCount() *MealCount {
+	if x != nil {
+		return x.Count
+	}
+	return nil
+}
+
+var File_proto_attendance_attendance_proto protoreflect.FileDescriptor
+
+const file_proto_attendance_attendance_proto_rawDesc = "" +
+	"\n" +
+	"!proto/attendance/attendance.proto\x12\n" +
+	"attendance\"\xbe\x01\n" +
+	"\x0eMealAttendance\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04roll\x18\x02 \x01(\rR\x04roll\x12\x17\n" +
+	"\amess_no\x18\x03 \x01(\rR\x06messNo\x12\x1b\n" +
+	"\tmeal_type\x18\x04 \x01(\tR\bmealType\x12\x12\n" +
+	"\x04date\x18\x05 \x01(\tR\x04date\x12\x1f\n" +
+	"\vrecorded_by\x18\x06 \x01(\tR\n" +
+	"recordedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"\x89\x01\n" +
+	"\tMealCount\x12\x17\n" +
+	"\amess_no\x18\x01 \x01(\rR\x06messNo\x12\x1b\n" +
+	"\tmeal_type\x18\x02 \x01(\tR\bmealType\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\x12\x16\n" +
+	"\x06served\x18\x04 \x01(\x03R\x06served\x12\x1a\n" +
+	"\bexpected\x18\x05 \x01(\x03R\bexpected\"J\n" +
+	"\x17RecordAttendanceRequest\x12\x12\n" +
+	"\x04roll\x18\x01 \x01(\rR\x04roll\x12\x1b\n" +
+	"\tmeal_type\x18\x02 \x01(\tR\bmealType\"\x83\x01\n" +
+	"\x18RecordAttendanceResponse\x12:\n" +
+	"\n" +
+	"attendance\x18\x01 \x01(\v2\x1a.attendance.MealAttendanceR\n" +
+	"attendance\x12+\n" +
+	"\x05count\x18\x02 \x01(\v2\x15.attendance.MealCountR\x05count\"\\\n" +
+	"\x10MealCountRequest\x12\x17\n" +
+	"\amess_no\x18\x01 \x01(\rR\x06messNo\x12\x1b\n" +
+	"\tmeal_type\x18\x02 \x01(\tR\bmealType\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\"C\n" +
+	"\x14GetMealCountResponse\x12+\n" +
+	"\x05count\x18\x01 \x01(\v2\x15.attendance.MealCountR\x05count2\x8b\x02\n" +
+	"\x11AttendanceService\x12]\n" +
+	"\x10RecordAttendance\x12#.attendance.RecordAttendanceRequest\x1a$.attendance.RecordAttendanceResponse\x12N\n" +
+	"\fGetMealCount\x12\x1c.attendance.MealCountRequest\x1a .attendance.GetMealCountResponse\x12G\n" +
+	"\x0eWatchMealCount\x12\x1c.attendance.MealCountRequest\x1a\x15.attendance.MealCount0\x01B9Z7github.com/ePSA-eJya/Mess_Management/proto/attendancepbb\x06proto3"
+
+var (
+	file_proto_attendance_attendance_proto_rawDescOnce sync.Once
+	file_proto_attendance_attendance_proto_rawDescData []byte
+)
+
+func file_proto_attendance_attendance_proto_rawDescGZIP() []byte {
+	file_proto_attendance_attendance_proto_rawDescOnce.Do(func() {
+		file_proto_attendance_attendance_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_attendance_attendance_proto_rawDesc), len(file_proto_attendance_attendance_proto_rawDesc)))
+	})
+	return file_proto_attendance_attendance_proto_rawDescData
+}
+
+var file_proto_attendance_attendance_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_attendance_attendance_proto_goTypes = []any{
+	(*MealAttendance)(nil),           // 0: attendance.MealAttendance
+	(*MealCount)(nil),                // 1: attendance.MealCount
+	(*RecordAttendanceRequest)(nil),  // 2: attendance.RecordAttendanceRequest
+	(*RecordAttendanceResponse)(nil), // 3: attendance.RecordAttendanceResponse
+	(*MealCountRequest)(nil),         // 4: attendance.MealCountRequest
+	(*GetMealCountResponse)(nil),     // 5: attendance.GetMealCountResponse
+}
+var file_proto_attendance_attendance_proto_depIdxs = []int32{
+	0, // 0: attendance.RecordAttendanceResponse.attendance:type_name -> attendance.MealAttendance
+	1, // 1: attendance.RecordAttendanceResponse.count:type_name -> attendance.MealCount
+	1, // 2: attendance.GetMealCountResponse.count:type_name -> attendance.MealCount
+	2, // 3: attendance.AttendanceService.RecordAttendance:input_type -> attendance.RecordAttendanceRequest
+	4, // 4: attendance.AttendanceService.GetMealCount:input_type -> attendance.MealCountRequest
+	4, // 5: attendance.AttendanceService.WatchMealCount:input_type -> attendance.MealCountRequest
+	3, // 6: attendance.AttendanceService.RecordAttendance:output_type -> attendance.RecordAttendanceResponse
+	5, // 7: attendance.AttendanceService.GetMealCount:output_type -> attendance.GetMealCountResponse
+	1, // 8: attendance.AttendanceService.WatchMealCount:output_type -> attendance.MealCount
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_proto_attendance_attendance_proto_init() }
+func file_proto_attendance_attendance_proto_init() {
+	if File_proto_attendance_attendance_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_attendance_attendance_proto_rawDesc), len(file_proto_attendance_attendance_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_attendance_attendance_proto_goTypes,
+		DependencyIndexes: file_proto_attendance_attendance_proto_depIdxs,
+		MessageInfos:      file_proto_attendance_attendance_proto_msgTypes,
+	}.Build()
+	File_proto_attendance_attendance_proto = out.File
+	file_proto_attendance_attendance_proto_goTypes = nil
+	file_proto_attendance_attendance_proto_depIdxs = nil
+}
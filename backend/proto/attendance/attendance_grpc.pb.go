@@ -0,0 +1,209 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: proto/attendance/attendance.proto
+
+package attendancepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AttendanceService_RecordAttendance_FullMethodName = "/attendance.AttendanceService/RecordAttendance"
+	AttendanceService_GetMealCount_FullMethodName     = "/attendance.AttendanceService/GetMealCount"
+	AttendanceService_WatchMealCount_FullMethodName   = "/attendance.AttendanceService/WatchMealCount"
+)
+
+// AttendanceServiceClient is the client API for AttendanceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AttendanceService is limited to admins, kiosks record meals as they are
+// served and mess displays watch the resulting counters
+type AttendanceServiceClient interface {
+	RecordAttendance(ctx context.Context, in *RecordAttendanceRequest, opts ...grpc.CallOption) (*RecordAttendanceResponse, error)
+	GetMealCount(ctx context.Context, in *MealCountRequest, opts ...grpc.CallOption) (*GetMealCountResponse, error)
+	// WatchMealCount sends the current count, then a new one after every recorded attendance
+	WatchMealCount(ctx context.Context, in *MealCountRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MealCount], error)
+}
+
+type attendanceServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAttendanceServiceClient(cc grpc.ClientConnInterface) AttendanceServiceClient {
+	return &attendanceServiceClient{cc}
+}
+
+func (c *attendanceServiceClient) RecordAttendance(ctx context.Context, in *RecordAttendanceRequest, opts ...grpc.CallOption) (*RecordAttendanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordAttendanceResponse)
+	err := c.cc.Invoke(ctx, AttendanceService_RecordAttendance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attendanceServiceClient) GetMealCount(ctx context.Context, in *MealCountRequest, opts ...grpc.CallOption) (*GetMealCountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMealCountResponse)
+	err := c.cc.Invoke(ctx, AttendanceService_GetMealCount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *attendanceServiceClient) WatchMealCount(ctx context.Context, in *MealCountRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MealCount], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AttendanceService_ServiceDesc.Streams[0], AttendanceService_WatchMealCount_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[MealCountRequest, MealCount]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AttendanceService_WatchMealCountClient = grpc.ServerStreamingClient[MealCount]
+
+// AttendanceServiceServer is the server API for AttendanceService service.
+// All implementations must embed UnimplementedAttendanceServiceServer
+// for forward compatibility.
+//
+// AttendanceService is limited to admins, kiosks record meals as they are
+// served and mess displays watch the resulting counters
+type AttendanceServiceServer interface {
+	RecordAttendance(context.Context, *RecordAttendanceRequest) (*RecordAttendanceResponse, error)
+	GetMealCount(context.Context, *MealCountRequest) (*GetMealCountResponse, error)
+	// WatchMealCount sends the current count, then a new one after every recorded attendance
+	WatchMealCount(*MealCountRequest, grpc.ServerStreamingServer[MealCount]) error
+	mustEmbedUnimplementedAttendanceServiceServer()
+}
+
+// UnimplementedAttendanceServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAttendanceServiceServer struct{}
+
+func (UnimplementedAttendanceServiceServer) RecordAttendance(context.Context, *RecordAttendanceRequest) (*RecordAttendanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordAttendance not implemented")
+}
+func (UnimplementedAttendanceServiceServer) GetMealCount(context.Context, *MealCountRequest) (*GetMealCountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMealCount not implemented")
+}
+func (UnimplementedAttendanceServiceServer) WatchMealCount(*MealCountRequest, grpc.ServerStreamingServer[MealCount]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMealCount not implemented")
+}
+func (UnimplementedAttendanceServiceServer) mustEmbedUnimplementedAttendanceServiceServer() {}
+func (UnimplementedAttendanceServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeAttendanceServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AttendanceServiceServer will
+// result in compilation errors.
+type UnsafeAttendanceServiceServer interface {
+	mustEmbedUnimplementedAttendanceServiceServer()
+}
+
+func RegisterAttendanceServiceServer(s grpc.ServiceRegistrar, srv AttendanceServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAttendanceServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AttendanceService_ServiceDesc, srv)
+}
+
+func _AttendanceService_RecordAttendance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordAttendanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttendanceServiceServer).RecordAttendance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttendanceService_RecordAttendance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttendanceServiceServer).RecordAttendance(ctx, req.(*RecordAttendanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttendanceService_GetMealCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MealCountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AttendanceServiceServer).GetMealCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AttendanceService_GetMealCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AttendanceServiceServer).GetMealCount(ctx, req.(*MealCountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AttendanceService_WatchMealCount_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MealCountRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AttendanceServiceServer).WatchMealCount(m, &grpc.GenericServerStream[MealCountRequest, MealCount]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AttendanceService_WatchMealCountServer = grpc.ServerStreamingServer[MealCount]
+
+// AttendanceService_ServiceDesc is the grpc.ServiceDesc for AttendanceService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AttendanceService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "attendance.AttendanceService",
+	HandlerType: (*AttendanceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RecordAttendance",
+			Handler:    _AttendanceService_RecordAttendance_Handler,
+		},
+		{
+			MethodName: "GetMealCount",
+			Handler:    _AttendanceService_GetMealCount_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchMealCount",
+			Handler:       _AttendanceService_WatchMealCount_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/attendance/attendance.proto",
+}
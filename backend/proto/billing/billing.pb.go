@@ -0,0 +1,739 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: proto/billing/billing.proto
+
+package billingpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MonthlyBill struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BillId         string                 `protobuf:"bytes,1,opt,name=bill_id,json=billId,proto3" json:"bill_id,omitempty"`
+	Roll           uint32                 `protobuf:"varint,2,opt,name=roll,proto3" json:"roll,omitempty"`
+	Month          string                 `protobuf:"bytes,3,opt,name=month,proto3" json:"month,omitempty"` // YYYY-MM
+	SemesterId     uint32                 `protobuf:"varint,4,opt,name=semester_id,json=semesterId,proto3" json:"semester_id,omitempty"`
+	BreakfastCount uint32                 `protobuf:"varint,5,opt,name=breakfast_count,json=breakfastCount,proto3" json:"breakfast_count,omitempty"`
+	LunchCount     uint32                 `protobuf:"varint,6,opt,name=lunch_count,json=lunchCount,proto3" json:"lunch_count,omitempty"`
+	DinnerCount    uint32                 `protobuf:"varint,7,opt,name=dinner_count,json=dinnerCount,proto3" json:"dinner_count,omitempty"`
+	TotalBill      float64                `protobuf:"fixed64,8,opt,name=total_bill,json=totalBill,proto3" json:"total_bill,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC 3339
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MonthlyBill) Reset() {
+	*x = MonthlyBill{}
+	mi := &file_proto_billing_billing_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MonthlyBill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonthlyBill) ProtoMessage() {}
+
+func (x *MonthlyBill) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_billing_billing_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonthlyBill.ProtoReflect.Descriptor instead.
+func (*MonthlyBill) Descriptor() ([]byte, []int) {
+	return file_proto_billing_billing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MonthlyBill) GetBillId() string {
+	if x != nil {
+		return x.BillId
+	}
+	return ""
+}
+
+func (x *MonthlyBill) GetRoll() uint32 {
+	if x != nil {
+		return x.Roll
+	}
+	return 0
+}
+
+func (x *MonthlyBill) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *MonthlyBill) GetSemesterId() uint32 {
+	if x != nil {
+		return x.SemesterId
+	}
+	return 0
+}
+
+func (x *MonthlyBill) GetBreakfastCount() uint32 {
+	if x != nil {
+		return x.BreakfastCount
+	}
+	return 0
+}
+
+func (x *MonthlyBill) GetLunchCount() uint32 {
+	if x != nil {
+		return x.LunchCount
+	}
+	return 0
+}
+
+func (x *MonthlyBill) GetDinnerCount() uint32 {
+	if x != nil {
+		return x.DinnerCount
+	}
+	return 0
+}
+
+func (x *MonthlyBill) GetTotalBill() float64 {
+	if x != nil {
+		return x.TotalBill
+	}
+	return 0
+}
+
+func (x *MonthlyBill) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type Payment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	BillId        string                 `protobuf:"bytes,2,opt,name=bill_id,json=billId,proto3" json:"bill_id,omitempty"`
+	Roll          uint32                 `protobuf:"varint,3,opt,name=roll,proto3" json:"roll,omitempty"`
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Method        string                 `protobuf:"bytes,5,opt,name=method,proto3" json:"method,omitempty"` // CASH, UPI, CARD or BANK_TRANSFER
+	Reference     string                 `protobuf:"bytes,6,opt,name=reference,proto3" json:"reference,omitempty"`
+	RecordedBy    string                 `protobuf:"bytes,7,opt,name=recorded_by,json=recordedBy,proto3" json:"recorded_by,omitempty"`
+	PaidAt        string                 `protobuf:"bytes,8,opt,name=paid_at,json=paidAt,proto3" json:"paid_at,omitempty"` // RFC 3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_proto_billing_billing_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_billing_billing_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_proto_billing_billing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Payment) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *Payment) GetBillId() string {
+	if x != nil {
+		return x.BillId
+	}
+	return ""
+}
+
+func (x *Payment) GetRoll() uint32 {
+	if x != nil {
+		return x.Roll
+	}
+	return 0
+}
+
+func (x *Payment) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Payment) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *Payment) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *Payment) GetRecordedBy() string {
+	if x != nil {
+		return x.RecordedBy
+	}
+	return ""
+}
+
+func (x *Payment) GetPaidAt() string {
+	if x != nil {
+		return x.PaidAt
+	}
+	return ""
+}
+
+type FindMonthlyBillsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Month         string                 `protobuf:"bytes,1,opt,name=month,proto3" json:"month,omitempty"` // YYYY-MM, last month when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindMonthlyBillsRequest) Reset() {
+	*x = FindMonthlyBillsRequest{}
+	mi := &file_proto_billing_billing_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindMonthlyBillsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindMonthlyBillsRequest) ProtoMessage() {}
+
+func (x *FindMonthlyBillsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_billing_billing_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindMonthlyBillsRequest.ProtoReflect.Descriptor instead.
+func (*FindMonthlyBillsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_billing_billing_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FindMonthlyBillsRequest) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+type FindMonthlyBillsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bills         []*MonthlyBill         `protobuf:"bytes,1,rep,name=bills,proto3" json:"bills,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindMonthlyBillsResponse) Reset() {
+	*x = FindMonthlyBillsResponse{}
+	mi := &file_proto_billing_billing_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindMonthlyBillsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindMonthlyBillsResponse) ProtoMessage() {}
+
+func (x *FindMonthlyBillsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_billing_billing_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindMonthlyBillsResponse.ProtoReflect.Descriptor instead.
+func (*FindMonthlyBillsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_billing_billing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FindMonthlyBillsResponse) GetBills() []*MonthlyBill {
+	if x != nil {
+		return x.Bills
+	}
+	return nil
+}
+
+type FindMonthlyBillByIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BillId        string                 `protobuf:"bytes,1,opt,name=bill_id,json=billId,proto3" json:"bill_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindMonthlyBillByIDRequest) Reset() {
+	*x = FindMonthlyBillByIDRequest{}
+	mi := &file_proto_billing_billing_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindMonthlyBillByIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindMonthlyBillByIDRequest) ProtoMessage() {}
+
+func (x *FindMonthlyBillByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_billing_billing_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindMonthlyBillByIDRequest.ProtoReflect.Descriptor instead.
+func (*FindMonthlyBillByIDRequest) Descriptor() ([]byte, []int) {
+	return file_proto_billing_billing_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FindMonthlyBillByIDRequest) GetBillId() string {
+	if x != nil {
+		return x.BillId
+	}
+	return ""
+}
+
+type FindMonthlyBillByIDResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bill          *MonthlyBill           `protobuf:"bytes,1,opt,name=bill,proto3" json:"bill,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindMonthlyBillByIDResponse) Reset() {
+	*x = FindMonthlyBillByIDResponse{}
+	mi := &file_proto_billing_billing_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindMonthlyBillByIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindMonthlyBillByIDResponse) ProtoMessage() {}
+
+func (x *FindMonthlyBillByIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_billing_billing_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindMonthlyBillByIDResponse.ProtoReflect.Descriptor instead.
+func (*FindMonthlyBillByIDResponse) Descriptor() ([]byte, []int) {
+	return file_proto_billing_billing_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FindMonthlyBillByIDResponse) GetBill() *MonthlyBill {
+	if x != nil {
+		return x.Bill
+	}
+	return nil
+}
+
+type RecordPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BillId        string                 `protobuf:"bytes,1,opt,name=bill_id,json=billId,proto3" json:"bill_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Method        string                 `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	Reference     string                 `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty"`
+	PaidAt        string                 `protobuf:"bytes,5,opt,name=paid_at,json=paidAt,proto3" json:"paid_at,omitempty"` // RFC 3339, now when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordPaymentRequest) Reset() {
+	*x = RecordPaymentRequest{}
+	mi := &file_proto_billing_billing_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordPaymentRequest) ProtoMessage() {}
+
+func (x *RecordPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_billing_billing_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordPaymentRequest.ProtoReflect.Descriptor instead.
+func (*RecordPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_billing_billing_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RecordPaymentRequest) GetBillId() string {
+	if x != nil {
+		return x.BillId
+	}
+	return ""
+}
+
+func (x *RecordPaymentRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RecordPaymentRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *RecordPaymentRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *RecordPaymentRequest) GetPaidAt() string {
+	if x != nil {
+		return x.PaidAt
+	}
+	return ""
+}
+
+type RecordPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payment       *Payment               `protobuf:"bytes,1,opt,name=payment,proto3" json:"payment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordPaymentResponse) Reset() {
+	*x = RecordPaymentResponse{}
+	mi := &file_proto_billing_billing_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordPaymentResponse) ProtoMessage() {}
+
+func (x *RecordPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_billing_billing_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordPaymentResponse.ProtoReflect.Descriptor instead.
+func (*RecordPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_billing_billing_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RecordPaymentResponse) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+type FindPaymentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BillId        string                 `protobuf:"bytes,1,opt,name=bill_id,json=billId,proto3" json:"bill_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindPaymentsRequest) Reset() {
+	*x = FindPaymentsRequest{}
+	mi := &file_proto_billing_billing_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindPaymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindPaymentsRequest) ProtoMessage() {}
+
+func (x *FindPaymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_billing_billing_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindPaymentsRequest.ProtoReflect.Descriptor instead.
+func (*FindPaymentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_billing_billing_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FindPaymentsRequest) GetBillId() string {
+	if x != nil {
+		return x.BillId
+	}
+	return ""
+}
+
+type FindPaymentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payments      []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindPaymentsResponse) Reset() {
+	*x = FindPaymentsResponse{}
+	mi := &file_proto_billing_billing_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindPaymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindPaymentsResponse) ProtoMessage() {}
+
+func (x *FindPaymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_billing_billing_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindPaymentsResponse.ProtoReflect.Descriptor instead.
+func (*FindPaymentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_billing_billing_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FindPaymentsResponse) GetPayments() []*Payment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+var File_proto_billing_billing_proto protoreflect.FileDescriptor
+
+const file_proto_billing_billing_proto_rawDesc = "" +
+	"\n" +
+	"\x1bproto/billing/billing.proto\x12\abilling\"\x9c\x02\n" +
+	"\vMonthlyBill\x12\x17\n" +
+	"\abill_id\x18\x01 \x01(\tR\x06billId\x12\x12\n" +
+	"\x04roll\x18\x02 \x01(\rR\x04roll\x12\x14\n" +
+	"\x05month\x18\x03 \x01(\tR\x05month\x12\x1f\n" +
+	"\vsemester_id\x18\x04 \x01(\rR\n" +
+	"semesterId\x12'\n" +
+	"\x0fbreakfast_count\x18\x05 \x01(\rR\x0ebreakfastCount\x12\x1f\n" +
+	"\vlunch_count\x18\x06 \x01(\rR\n" +
+	"lunchCount\x12!\n" +
+	"\fdinner_count\x18\a \x01(\rR\vdinnerCount\x12\x1d\n" +
+	"\n" +
+	"total_bill\x18\b \x01(\x01R\ttotalBill\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\"\xdd\x01\n" +
+	"\aPayment\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x17\n" +
+	"\abill_id\x18\x02 \x01(\tR\x06billId\x12\x12\n" +
+	"\x04roll\x18\x03 \x01(\rR\x04roll\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x16\n" +
+	"\x06method\x18\x05 \x01(\tR\x06method\x12\x1c\n" +
+	"\treference\x18\x06 \x01(\tR\treference\x12\x1f\n" +
+	"\vrecorded_by\x18\a \x01(\tR\n" +
+	"recordedBy\x12\x17\n" +
+	"\apaid_at\x18\b \x01(\tR\x06paidAt\"/\n" +
+	"\x17FindMonthlyBillsRequest\x12\x14\n" +
+	"\x05month\x18\x01 \x01(\tR\x05month\"F\n" +
+	"\x18FindMonthlyBillsResponse\x12*\n" +
+	"\x05bills\x18\x01 \x03(\v2\x14.billing.MonthlyBillR\x05bills\"5\n" +
+	"\x1aFindMonthlyBillByIDRequest\x12\x17\n" +
+	"\abill_id\x18\x01 \x01(\tR\x06billId\"G\n" +
+	"\x1bFindMonthlyBillByIDResponse\x12(\n" +
+	"\x04bill\x18\x01 \x01(\v2\x14.billing.MonthlyBillR\x04bill\"\x96\x01\n" +
+	"\x14RecordPaymentRequest\x12\x17\n" +
+	"\abill_id\x18\x01 \x01(\tR\x06billId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12\x1c\n" +
+	"\treference\x18\x04 \x01(\tR\treference\x12\x17\n" +
+	"\apaid_at\x18\x05 \x01(\tR\x06paidAt\"C\n" +
+	"\x15RecordPaymentResponse\x12*\n" +
+	"\apayment\x18\x01 \x01(\v2\x10.billing.PaymentR\apayment\".\n" +
+	"\x13FindPaymentsRequest\x12\x17\n" +
+	"\abill_id\x18\x01 \x01(\tR\x06billId\"D\n" +
+	"\x14FindPaymentsResponse\x12,\n" +
+	"\bpayments\x18\x01 \x03(\v2\x10.billing.PaymentR\bpayments2\xe8\x02\n" +
+	"\x0eBillingService\x12W\n" +
+	"\x10FindMonthlyBills\x12 .billing.FindMonthlyBillsRequest\x1a!.billing.FindMonthlyBillsResponse\x12`\n" +
+	"\x13FindMonthlyBillByID\x12#.billing.FindMonthlyBillByIDRequest\x1a$.billing.FindMonthlyBillByIDResponse\x12N\n" +
+	"\rRecordPayment\x12\x1d.billing.RecordPaymentRequest\x1a\x1e.billing.RecordPaymentResponse\x12K\n" +
+	"\fFindPayments\x12\x1c.billing.FindPaymentsRequest\x1a\x1d.billing.FindPaymentsResponseB6Z4github.com/ePSA-eJya/Mess_Management/proto/billingpbb\x06proto3"
+
+var (
+	file_proto_billing_billing_proto_rawDescOnce sync.Once
+	file_proto_billing_billing_proto_rawDescData []byte
+)
+
+func file_proto_billing_billing_proto_rawDescGZIP() []byte {
+	file_proto_billing_billing_proto_rawDescOnce.Do(func() {
+		file_proto_billing_billing_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_billing_billing_proto_rawDesc), len(file_proto_billing_billing_proto_rawDesc)))
+	})
+	return file_proto_billing_billing_proto_rawDescData
+}
+
+var file_proto_billing_billing_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_proto_billing_billing_proto_goTypes = []any{
+	(*MonthlyBill)(nil),                 // 0: billing.MonthlyBill
+	(*Payment)(nil),                     // 1: billing.Payment
+	(*FindMonthlyBillsRequest)(nil),     // 2: billing.FindMonthlyBillsRequest
+	(*FindMonthlyBillsResponse)(nil),    // 3: billing.FindMonthlyBillsResponse
+	(*FindMonthlyBillByIDRequest)(nil),  // 4: billing.FindMonthlyBillByIDRequest
+	(*FindMonthlyBillByIDResponse)(nil), // 5: billing.FindMonthlyBillByIDResponse
+	(*RecordPaymentRequest)(nil),        // 6: billing.RecordPaymentRequest
+	(*RecordPaymentResponse)(nil),       // 7: billing.RecordPaymentResponse
+	(*FindPaymentsRequest)(nil),         // 8: billing.FindPaymentsRequest
+	(*FindPaymentsResponse)(nil),        // 9: billing.FindPaymentsResponse
+}
+var file_proto_billing_billing_proto_depIdxs = []int32{
+	0, // 0: billing.FindMonthlyBillsResponse.bills:type_name -> billing.MonthlyBill
+	0, // 1: billing.FindMonthlyBillByIDResponse.bill:type_name -> billing.MonthlyBill
+	1, // 2: billing.RecordPaymentResponse.payment:type_name -> billing.Payment
+	1, // 3: billing.FindPaymentsResponse.payments:type_name -> billing.Payment
+	2, // 4: billing.BillingService.FindMonthlyBills:input_type -> billing.FindMonthlyBillsRequest
+	4, // 5: billing.BillingService.FindMonthlyBillByID:input_type -> billing.FindMonthlyBillByIDRequest
+	6, // 6: billing.BillingService.RecordPayment:input_type -> billing.RecordPaymentRequest
+	8, // 7: billing.BillingService.FindPayments:input_type -> billing.FindPaymentsRequest
+	3, // 8: billing.BillingService.FindMonthlyBills:output_type -> billing.FindMonthlyBillsResponse
+	5, // 9: billing.BillingService.FindMonthlyBillByID:output_type -> billing.FindMonthlyBillByIDResponse
+	7, // 10: billing.BillingService.RecordPayment:output_type -> billing.RecordPaymentResponse
+	9, // 11: billing.BillingService.FindPayments:output_type -> billing.FindPaymentsResponse
+	8, // [8:12] is the sub-list for method output_type
+	4, // [4:8] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_proto_billing_billing_proto_init() }
+func file_proto_billing_billing_proto_init() {
+	if File_proto_billing_billing_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_billing_billing_proto_rawDesc), len(file_proto_billing_billing_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_billing_billing_proto_goTypes,
+		DependencyIndexes: file_proto_billing_billing_proto_depIdxs,
+		MessageInfos:      file_proto_billing_billing_proto_msgTypes,
+	}.Build()
+	File_proto_billing_billing_proto = out.File
+	file_proto_billing_billing_proto_goTypes = nil
+	file_proto_billing_billing_proto_depIdxs = nil
+}
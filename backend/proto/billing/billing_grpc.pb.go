@@ -0,0 +1,239 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: proto/billing/billing.proto
+
+package billingpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BillingService_FindMonthlyBills_FullMethodName    = "/billing.BillingService/FindMonthlyBills"
+	BillingService_FindMonthlyBillByID_FullMethodName = "/billing.BillingService/FindMonthlyBillByID"
+	BillingService_RecordPayment_FullMethodName       = "/billing.BillingService/RecordPayment"
+	BillingService_FindPayments_FullMethodName        = "/billing.BillingService/FindPayments"
+)
+
+// BillingServiceClient is the client API for BillingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BillingService is limited to admins, recording payments to office admins
+type BillingServiceClient interface {
+	FindMonthlyBills(ctx context.Context, in *FindMonthlyBillsRequest, opts ...grpc.CallOption) (*FindMonthlyBillsResponse, error)
+	FindMonthlyBillByID(ctx context.Context, in *FindMonthlyBillByIDRequest, opts ...grpc.CallOption) (*FindMonthlyBillByIDResponse, error)
+	RecordPayment(ctx context.Context, in *RecordPaymentRequest, opts ...grpc.CallOption) (*RecordPaymentResponse, error)
+	FindPayments(ctx context.Context, in *FindPaymentsRequest, opts ...grpc.CallOption) (*FindPaymentsResponse, error)
+}
+
+type billingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBillingServiceClient(cc grpc.ClientConnInterface) BillingServiceClient {
+	return &billingServiceClient{cc}
+}
+
+func (c *billingServiceClient) FindMonthlyBills(ctx context.Context, in *FindMonthlyBillsRequest, opts ...grpc.CallOption) (*FindMonthlyBillsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindMonthlyBillsResponse)
+	err := c.cc.Invoke(ctx, BillingService_FindMonthlyBills_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingServiceClient) FindMonthlyBillByID(ctx context.Context, in *FindMonthlyBillByIDRequest, opts ...grpc.CallOption) (*FindMonthlyBillByIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindMonthlyBillByIDResponse)
+	err := c.cc.Invoke(ctx, BillingService_FindMonthlyBillByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingServiceClient) RecordPayment(ctx context.Context, in *RecordPaymentRequest, opts ...grpc.CallOption) (*RecordPaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordPaymentResponse)
+	err := c.cc.Invoke(ctx, BillingService_RecordPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingServiceClient) FindPayments(ctx context.Context, in *FindPaymentsRequest, opts ...grpc.CallOption) (*FindPaymentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindPaymentsResponse)
+	err := c.cc.Invoke(ctx, BillingService_FindPayments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BillingServiceServer is the server API for BillingService service.
+// All implementations must embed UnimplementedBillingServiceServer
+// for forward compatibility.
+//
+// BillingService is limited to admins, recording payments to office admins
+type BillingServiceServer interface {
+	FindMonthlyBills(context.Context, *FindMonthlyBillsRequest) (*FindMonthlyBillsResponse, error)
+	FindMonthlyBillByID(context.Context, *FindMonthlyBillByIDRequest) (*FindMonthlyBillByIDResponse, error)
+	RecordPayment(context.Context, *RecordPaymentRequest) (*RecordPaymentResponse, error)
+	FindPayments(context.Context, *FindPaymentsRequest) (*FindPaymentsResponse, error)
+	mustEmbedUnimplementedBillingServiceServer()
+}
+
+// UnimplementedBillingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBillingServiceServer struct{}
+
+func (UnimplementedBillingServiceServer) FindMonthlyBills(context.Context, *FindMonthlyBillsRequest) (*FindMonthlyBillsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindMonthlyBills not implemented")
+}
+func (UnimplementedBillingServiceServer) FindMonthlyBillByID(context.Context, *FindMonthlyBillByIDRequest) (*FindMonthlyBillByIDResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindMonthlyBillByID not implemented")
+}
+func (UnimplementedBillingServiceServer) RecordPayment(context.Context, *RecordPaymentRequest) (*RecordPaymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordPayment not implemented")
+}
+func (UnimplementedBillingServiceServer) FindPayments(context.Context, *FindPaymentsRequest) (*FindPaymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindPayments not implemented")
+}
+func (UnimplementedBillingServiceServer) mustEmbedUnimplementedBillingServiceServer() {}
+func (UnimplementedBillingServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeBillingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BillingServiceServer will
+// result in compilation errors.
+type UnsafeBillingServiceServer interface {
+	mustEmbedUnimplementedBillingServiceServer()
+}
+
+func RegisterBillingServiceServer(s grpc.ServiceRegistrar, srv BillingServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBillingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BillingService_ServiceDesc, srv)
+}
+
+func _BillingService_FindMonthlyBills_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindMonthlyBillsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingServiceServer).FindMonthlyBills(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BillingService_FindMonthlyBills_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingServiceServer).FindMonthlyBills(ctx, req.(*FindMonthlyBillsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingService_FindMonthlyBillByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindMonthlyBillByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingServiceServer).FindMonthlyBillByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BillingService_FindMonthlyBillByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingServiceServer).FindMonthlyBillByID(ctx, req.(*FindMonthlyBillByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingService_RecordPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingServiceServer).RecordPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BillingService_RecordPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingServiceServer).RecordPayment(ctx, req.(*RecordPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingService_FindPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindPaymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingServiceServer).FindPayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BillingService_FindPayments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingServiceServer).FindPayments(ctx, req.(*FindPaymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BillingService_ServiceDesc is the grpc.ServiceDesc for BillingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BillingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "billing.BillingService",
+	HandlerType: (*BillingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FindMonthlyBills",
+			Handler:    _BillingService_FindMonthlyBills_Handler,
+		},
+		{
+			MethodName: "FindMonthlyBillByID",
+			Handler:    _BillingService_FindMonthlyBillByID_Handler,
+		},
+		{
+			MethodName: "RecordPayment",
+			Handler:    _BillingService_RecordPayment_Handler,
+		},
+		{
+			MethodName: "FindPayments",
+			Handler:    _BillingService_FindPayments_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/billing/billing.proto",
+}
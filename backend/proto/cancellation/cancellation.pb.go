@@ -0,0 +1,385 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: proto/cancellation/cancellation.proto
+
+package cancellationpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MealCancellation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Roll          uint32                 `protobuf:"varint,2,opt,name=roll,proto3" json:"roll,omitempty"`
+	SemesterId    uint32                 `protobuf:"varint,3,opt,name=semester_id,json=semesterId,proto3" json:"semester_id,omitempty"`
+	MealType      string                 `protobuf:"bytes,4,opt,name=meal_type,json=mealType,proto3" json:"meal_type,omitempty"`    // BREAKFAST, LUNCH or DINNER
+	Date          string                 `protobuf:"bytes,5,opt,name=date,proto3" json:"date,omitempty"`                            // YYYY-MM-DD
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC 3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MealCancellation) Reset() {
+	*x = MealCancellation{}
+	mi := &file_proto_cancellation_cancellation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MealCancellation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MealCancellation) ProtoMessage() {}
+
+func (x *MealCancellation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cancellation_cancellation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MealCancellation.ProtoReflect.Descriptor instead.
+func (*MealCancellation) Descriptor() ([]byte, []int) {
+	return file_proto_cancellation_cancellation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MealCancellation) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MealCancellation) GetRoll() uint32 {
+	if x != nil {
+		return x.Roll
+	}
+	return 0
+}
+
+func (x *MealCancellation) GetSemesterId() uint32 {
+	if x != nil {
+		return x.SemesterId
+	}
+	return 0
+}
+
+func (x *MealCancellation) GetMealType() string {
+	if x != nil {
+		return x.MealType
+	}
+	return ""
+}
+
+func (x *MealCancellation) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *MealCancellation) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type CancelMealRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"` // YYYY-MM-DD
+	MealType      string                 `protobuf:"bytes,2,opt,name=meal_type,json=mealType,proto3" json:"meal_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelMealRequest) Reset() {
+	*x = CancelMealRequest{}
+	mi := &file_proto_cancellation_cancellation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelMealRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelMealRequest) ProtoMessage() {}
+
+func (x *CancelMealRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cancellation_cancellation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelMealRequest.ProtoReflect.Descriptor instead.
+func (*CancelMealRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cancellation_cancellation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CancelMealRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *CancelMealRequest) GetMealType() string {
+	if x != nil {
+		return x.MealType
+	}
+	return ""
+}
+
+type CancelMealResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cancellation  *MealCancellation      `protobuf:"bytes,1,opt,name=cancellation,proto3" json:"cancellation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelMealResponse) Reset() {
+	*x = CancelMealResponse{}
+	mi := &file_proto_cancellation_cancellation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelMealResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelMealResponse) ProtoMessage() {}
+
+func (x *CancelMealResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cancellation_cancellation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelMealResponse.ProtoReflect.Descriptor instead.
+func (*CancelMealResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cancellation_cancellation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CancelMealResponse) GetCancellation() *MealCancellation {
+	if x != nil {
+		return x.Cancellation
+	}
+	return nil
+}
+
+type FindCancellationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"` // YYYY-MM-DD, first day of this month when empty
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`     // YYYY-MM-DD exclusive, a month after from when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindCancellationsRequest) Reset() {
+	*x = FindCancellationsRequest{}
+	mi := &file_proto_cancellation_cancellation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindCancellationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindCancellationsRequest) ProtoMessage() {}
+
+func (x *FindCancellationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cancellation_cancellation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindCancellationsRequest.ProtoReflect.Descriptor instead.
+func (*FindCancellationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_cancellation_cancellation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FindCancellationsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *FindCancellationsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type FindCancellationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cancellations []*MealCancellation    `protobuf:"bytes,1,rep,name=cancellations,proto3" json:"cancellations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindCancellationsResponse) Reset() {
+	*x = FindCancellationsResponse{}
+	mi := &file_proto_cancellation_cancellation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindCancellationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindCancellationsResponse) ProtoMessage() {}
+
+func (x *FindCancellationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_cancellation_cancellation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindCancellationsResponse.ProtoReflect.Descriptor instead.
+func (*FindCancellationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_cancellation_cancellation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FindCancellationsResponse) GetCancellations() []*MealCancellation {
+	if x != nil {
+		return x.Cancellations
+	}
+	return nil
+}
+
+var File_proto_cancellation_cancellation_proto protoreflect.FileDescriptor
+
+const file_proto_cancellation_cancellation_proto_rawDesc = "" +
+	"\n" +
+	"%proto/cancellation/cancellation.proto\x12\fcancellation\"\xa7\x01\n" +
+	"\x10MealCancellation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04roll\x18\x02 \x01(\rR\x04roll\x12\x1f\n" +
+	"\vsemester_id\x18\x03 \x01(\rR\n" +
+	"semesterId\x12\x1b\n" +
+	"\tmeal_type\x18\x04 \x01(\tR\bmealType\x12\x12\n" +
+	"\x04date\x18\x05 \x01(\tR\x04date\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\"D\n" +
+	"\x11CancelMealRequest\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x1b\n" +
+	"\tmeal_type\x18\x02 \x01(\tR\bmealType\"X\n" +
+	"\x12CancelMealResponse\x12B\n" +
+	"\fcancellation\x18\x01 \x01(\v2\x1e.cancellation.MealCancellationR\fcancellation\">\n" +
+	"\x18FindCancellationsRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\"a\n" +
+	"\x19FindCancellationsResponse\x12D\n" +
+	"\rcancellations\x18\x01 \x03(\v2\x1e.cancellation.MealCancellationR\rcancellations2\xd0\x01\n" +
+	"\x17MealCancellationService\x12O\n" +
+	"\n" +
+	"CancelMeal\x12\x1f.cancellation.CancelMealRequest\x1a .cancellation.CancelMealResponse\x12d\n" +
+	"\x11FindCancellations\x12&.cancellation.FindCancellationsRequest\x1a'.cancellation.FindCancellationsResponseB;Z9github.com/ePSA-eJya/Mess_Management/proto/cancellationpbb\x06proto3"
+
+var (
+	file_proto_cancellation_cancellation_proto_rawDescOnce sync.Once
+	file_proto_cancellation_cancellation_proto_rawDescData []byte
+)
+
+func file_proto_cancellation_cancellation_proto_rawDescGZIP() []byte {
+	file_proto_cancellation_cancellation_proto_rawDescOnce.Do(func() {
+		file_proto_cancellation_cancellation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_cancellation_cancellation_proto_rawDesc), len(file_proto_cancellation_cancellation_proto_rawDesc)))
+	})
+	return file_proto_cancellation_cancellation_proto_rawDescData
+}
+
+var file_proto_cancellation_cancellation_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_cancellation_cancellation_proto_goTypes = []any{
+	(*MealCancellation)(nil),          // 0: cancellation.MealCancellation
+	(*CancelMealRequest)(nil),         // 1: cancellation.CancelMealRequest
+	(*CancelMealResponse)(nil),        // 2: cancellation.CancelMealResponse
+	(*FindCancellationsRequest)(nil),  // 3: cancellation.FindCancellationsRequest
+	(*FindCancellationsResponse)(nil), // 4: cancellation.FindCancellationsResponse
+}
+var file_proto_cancellation_cancellation_proto_depIdxs = []int32{
+	0, // 0: cancellation.CancelMealResponse.cancellation:type_name -> cancellation.MealCancellation
+	0, // 1: cancellation.FindCancellationsResponse.cancellations:type_name -> cancellation.MealCancellation
+	1, // 2: cancellation.MealCancellationService.CancelMeal:input_type -> cancellation.CancelMealRequest
+	3, // 3: cancellation.MealCancellationService.FindCancellations:input_type -> cancellation.FindCancellationsRequest
+	2, // 4: cancellation.MealCancellationService.CancelMeal:output_type -> cancellation.CancelMealResponse
+	4, // 5: cancellation.MealCancellationService.FindCancellations:output_type -> cancellation.FindCancellationsResponse
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_proto_cancellation_cancellation_proto_init() }
+func file_proto_cancellation_cancellation_proto_init() {
+	if File_proto_cancellation_cancellation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_cancellation_cancellation_proto_rawDesc), len(file_proto_cancellation_cancellation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_cancellation_cancellation_proto_goTypes,
+		DependencyIndexes: file_proto_cancellation_cancellation_proto_depIdxs,
+		MessageInfos:      file_proto_cancellation_cancellation_proto_msgTypes,
+	}.Build()
+	File_proto_cancellation_cancellation_proto = out.File
+	file_proto_cancellation_cancellation_proto_goTypes = nil
+	file_proto_cancellation_cancellation_proto_depIdxs = nil
+}
@@ -0,0 +1,164 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: proto/cancellation/cancellation.proto
+
+package cancellationpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MealCancellationService_CancelMeal_FullMethodName        = "/cancellation.MealCancellationService/CancelMeal"
+	MealCancellationService_FindCancellations_FullMethodName = "/cancellation.MealCancellationService/FindCancellations"
+)
+
+// MealCancellationServiceClient is the client API for MealCancellationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MealCancellationService acts on the caller's own student record
+type MealCancellationServiceClient interface {
+	CancelMeal(ctx context.Context, in *CancelMealRequest, opts ...grpc.CallOption) (*CancelMealResponse, error)
+	FindCancellations(ctx context.Context, in *FindCancellationsRequest, opts ...grpc.CallOption) (*FindCancellationsResponse, error)
+}
+
+type mealCancellationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMealCancellationServiceClient(cc grpc.ClientConnInterface) MealCancellationServiceClient {
+	return &mealCancellationServiceClient{cc}
+}
+
+func (c *mealCancellationServiceClient) CancelMeal(ctx context.Context, in *CancelMealRequest, opts ...grpc.CallOption) (*CancelMealResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelMealResponse)
+	err := c.cc.Invoke(ctx, MealCancellationService_CancelMeal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mealCancellationServiceClient) FindCancellations(ctx context.Context, in *FindCancellationsRequest, opts ...grpc.CallOption) (*FindCancellationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindCancellationsResponse)
+	err := c.cc.Invoke(ctx, MealCancellationService_FindCancellations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MealCancellationServiceServer is the server API for MealCancellationService service.
+// All implementations must embed UnimplementedMealCancellationServiceServer
+// for forward compatibility.
+//
+// MealCancellationService acts on the caller's own student record
+type MealCancellationServiceServer interface {
+	CancelMeal(context.Context, *CancelMealRequest) (*CancelMealResponse, error)
+	FindCancellations(context.Context, *FindCancellationsRequest) (*FindCancellationsResponse, error)
+	mustEmbedUnimplementedMealCancellationServiceServer()
+}
+
+// UnimplementedMealCancellationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMealCancellationServiceServer struct{}
+
+func (UnimplementedMealCancellationServiceServer) CancelMeal(context.Context, *CancelMealRequest) (*CancelMealResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelMeal not implemented")
+}
+func (UnimplementedMealCancellationServiceServer) FindCancellations(context.Context, *FindCancellationsRequest) (*FindCancellationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindCancellations not implemented")
+}
+func (UnimplementedMealCancellationServiceServer) mustEmbedUnimplementedMealCancellationServiceServer() {
+}
+func (UnimplementedMealCancellationServiceServer) testEmbeddedByValue() {}
+
+// UnsafeMealCancellationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MealCancellationServiceServer will
+// result in compilation errors.
+type UnsafeMealCancellationServiceServer interface {
+	mustEmbedUnimplementedMealCancellationServiceServer()
+}
+
+func RegisterMealCancellationServiceServer(s grpc.ServiceRegistrar, srv MealCancellationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMealCancellationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MealCancellationService_ServiceDesc, srv)
+}
+
+func _MealCancellationService_CancelMeal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelMealRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MealCancellationServiceServer).CancelMeal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MealCancellationService_CancelMeal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MealCancellationServiceServer).CancelMeal(ctx, req.(*CancelMealRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MealCancellationService_FindCancellations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindCancellationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MealCancellationServiceServer).FindCancellations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MealCancellationService_FindCancellations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MealCancellationServiceServer).FindCancellations(ctx, req.(*FindCancellationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MealCancellationService_ServiceDesc is the grpc.ServiceDesc for MealCancellationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MealCancellationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cancellation.MealCancellationService",
+	HandlerType: (*MealCancellationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CancelMeal",
+			Handler:    _MealCancellationService_CancelMeal_Handler,
+		},
+		{
+			MethodName: "FindCancellations",
+			Handler:    _MealCancellationService_FindCancellations_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/cancellation/cancellation.proto",
+}
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.36.11
-// 	protoc        v7.34.0--rc2
+// 	protoc        (unknown)
 // source: proto/order/order.proto
 
 package orderpb
@@ -25,6 +25,9 @@ type Order struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Total         float64                `protobuf:"fixed64,2,opt,name=total,proto3" json:"total,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`          // owner, empty on orders placed before ownership existed
+	DeletedAt     string                 `protobuf:"bytes,4,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"` // RFC 3339, only set on deleted orders
+	Version       uint32                 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`                     // grows with every update, send it back with PatchOrder
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -73,6 +76,27 @@ func (x *Order) GetTotal() float64 {
 	return 0
 }
 
+func (x *Order) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Order) GetDeletedAt() string {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return ""
+}
+
+func (x *Order) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type CreateOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Total         float64                `protobuf:"fixed64,1,opt,name=total,proto3" json:"total,omitempty"`
@@ -249,8 +273,14 @@ func (x *FindOrderByIDResponse) GetOrder() *Order {
 	return nil
 }
 
+// FindAllOrdersRequest selects a page of orders, over REST as
+// ?cursor=&limit=&sort=-total&filter[user_id]=
 type FindAllOrdersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`                                                                           // next_cursor of the previous page
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                                                                            // page size, 20 by default and at most 100
+	Sort          string                 `protobuf:"bytes,3,opt,name=sort,proto3" json:"sort,omitempty"`                                                                               // id or total, "-" prefix for descending
+	Filter        map[string]string      `protobuf:"bytes,4,rep,name=filter,proto3" json:"filter,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // by user_id, students always get their own orders
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -285,9 +315,39 @@ func (*FindAllOrdersRequest) Descriptor() ([]byte, []int) {
 	return file_proto_order_order_proto_rawDescGZIP(), []int{5}
 }
 
+func (x *FindAllOrdersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *FindAllOrdersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *FindAllOrdersRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *FindAllOrdersRequest) GetFilter() map[string]string {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
 type FindAllOrdersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	Items         []*Order               `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // empty on the last page
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`                            // orders matching the filter
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -322,17 +382,35 @@ func (*FindAllOrdersResponse) Descriptor() ([]byte, []int) {
 	return file_proto_order_order_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *FindAllOrdersResponse) GetOrders() []*Order {
+func (x *FindAllOrdersResponse) GetItems() []*Order {
 	if x != nil {
-		return x.Orders
+		return x.Items
 	}
 	return nil
 }
 
+func (x *FindAllOrdersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *FindAllOrdersResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
 type PatchOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Total         float64                `protobuf:"fixed64,2,opt,name=total,proto3" json:"total,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Total float64                `protobuf:"fixed64,2,opt,name=total,proto3" json:"total,omitempty"`
+	// version the update is based on, FailedPrecondition when 0. The call is
+	// aborted when the order has changed since. Over REST the If-Match header
+	// can carry it instead.
+	Version       uint32 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -381,6 +459,13 @@ func (x *PatchOrderRequest) GetTotal() float64 {
 	return 0
 }
 
+func (x *PatchOrderRequest) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type PatchOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
@@ -513,14 +598,106 @@ func (x *DeleteOrderResponse) GetMessage() string {
 	return ""
 }
 
+type RestoreOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreOrderRequest) Reset() {
+	*x = RestoreOrderRequest{}
+	mi := &file_proto_order_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreOrderRequest) ProtoMessage() {}
+
+func (x *RestoreOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreOrderRequest.ProtoReflect.Descriptor instead.
+func (*RestoreOrderRequest) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RestoreOrderRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type RestoreOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreOrderResponse) Reset() {
+	*x = RestoreOrderResponse{}
+	mi := &file_proto_order_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreOrderResponse) ProtoMessage() {}
+
+func (x *RestoreOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_order_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreOrderResponse.ProtoReflect.Descriptor instead.
+func (*RestoreOrderResponse) Descriptor() ([]byte, []int) {
+	return file_proto_order_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RestoreOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
 var File_proto_order_order_proto protoreflect.FileDescriptor
 
 const file_proto_order_order_proto_rawDesc = "" +
 	"\n" +
-	"\x17proto/order/order.proto\x12\x05order\"-\n" +
+	"\x17proto/order/order.proto\x12\x05order\"\x7f\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x01R\x05total\"*\n" +
+	"\x05total\x18\x02 \x01(\x01R\x05total\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\x04 \x01(\tR\tdeletedAt\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\rR\aversion\"*\n" +
 	"\x12CreateOrderRequest\x12\x14\n" +
 	"\x05total\x18\x01 \x01(\x01R\x05total\"9\n" +
 	"\x13CreateOrderResponse\x12\"\n" +
@@ -528,26 +705,43 @@ const file_proto_order_order_proto_rawDesc = "" +
 	"\x14FindOrderByIDRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\";\n" +
 	"\x15FindOrderByIDResponse\x12\"\n" +
-	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\"\x16\n" +
-	"\x14FindAllOrdersRequest\"=\n" +
-	"\x15FindAllOrdersResponse\x12$\n" +
-	"\x06orders\x18\x01 \x03(\v2\f.order.OrderR\x06orders\"9\n" +
+	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\"\xd4\x01\n" +
+	"\x14FindAllOrdersRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x12\n" +
+	"\x04sort\x18\x03 \x01(\tR\x04sort\x12?\n" +
+	"\x06filter\x18\x04 \x03(\v2'.order.FindAllOrdersRequest.FilterEntryR\x06filter\x1a9\n" +
+	"\vFilterEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"r\n" +
+	"\x15FindAllOrdersResponse\x12\"\n" +
+	"\x05items\x18\x01 \x03(\v2\f.order.OrderR\x05items\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\"S\n" +
 	"\x11PatchOrderRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x01R\x05total\"8\n" +
+	"\x05total\x18\x02 \x01(\x01R\x05total\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\rR\aversion\"8\n" +
 	"\x12PatchOrderResponse\x12\"\n" +
 	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order\"$\n" +
 	"\x12DeleteOrderRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\"/\n" +
 	"\x13DeleteOrderResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage2\xf5\x02\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"%\n" +
+	"\x13RestoreOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\":\n" +
+	"\x14RestoreOrderResponse\x12\"\n" +
+	"\x05order\x18\x01 \x01(\v2\f.order.OrderR\x05order2\x8e\x04\n" +
 	"\fOrderService\x12D\n" +
 	"\vCreateOrder\x12\x19.order.CreateOrderRequest\x1a\x1a.order.CreateOrderResponse\x12J\n" +
 	"\rFindOrderByID\x12\x1b.order.FindOrderByIDRequest\x1a\x1c.order.FindOrderByIDResponse\x12J\n" +
 	"\rFindAllOrders\x12\x1b.order.FindAllOrdersRequest\x1a\x1c.order.FindAllOrdersResponse\x12A\n" +
 	"\n" +
 	"PatchOrder\x12\x18.order.PatchOrderRequest\x1a\x19.order.PatchOrderResponse\x12D\n" +
-	"\vDeleteOrder\x12\x19.order.DeleteOrderRequest\x1a\x1a.order.DeleteOrderResponseB4Z2github.com/ePSA-eJya/Mess_Management/proto/orderpbb\x06proto3"
+	"\vDeleteOrder\x12\x19.order.DeleteOrderRequest\x1a\x1a.order.DeleteOrderResponse\x12N\n" +
+	"\x11FindDeletedOrders\x12\x1b.order.FindAllOrdersRequest\x1a\x1c.order.FindAllOrdersResponse\x12G\n" +
+	"\fRestoreOrder\x12\x1a.order.RestoreOrderRequest\x1a\x1b.order.RestoreOrderResponseB4Z2github.com/ePSA-eJya/Mess_Management/proto/orderpbb\x06proto3"
 
 var (
 	file_proto_order_order_proto_rawDescOnce sync.Once
@@ -561,7 +755,7 @@ func file_proto_order_order_proto_rawDescGZIP() []byte {
 	return file_proto_order_order_proto_rawDescData
 }
 
-var file_proto_order_order_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_order_order_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_proto_order_order_proto_goTypes = []any{
 	(*Order)(nil),                 // 0: order.Order
 	(*CreateOrderRequest)(nil),    // 1: order.CreateOrderRequest
@@ -574,27 +768,36 @@ var file_proto_order_order_proto_goTypes = []any{
 	(*PatchOrderResponse)(nil),    // 8: order.PatchOrderResponse
 	(*DeleteOrderRequest)(nil),    // 9: order.DeleteOrderRequest
 	(*DeleteOrderResponse)(nil),   // 10: order.DeleteOrderResponse
+	(*RestoreOrderRequest)(nil),   // 11: order.RestoreOrderRequest
+	(*RestoreOrderResponse)(nil),  // 12: order.RestoreOrderResponse
+	nil,                           // 13: order.FindAllOrdersRequest.FilterEntry
 }
 var file_proto_order_order_proto_depIdxs = []int32{
 	0,  // 0: order.CreateOrderResponse.order:type_name -> order.Order
 	0,  // 1: order.FindOrderByIDResponse.order:type_name -> order.Order
-	0,  // 2: order.FindAllOrdersResponse.orders:type_name -> order.Order
-	0,  // 3: order.PatchOrderResponse.order:type_name -> order.Order
-	1,  // 4: order.OrderService.CreateOrder:input_type -> order.CreateOrderRequest
-	3,  // 5: order.OrderService.FindOrderByID:input_type -> order.FindOrderByIDRequest
-	5,  // 6: order.OrderService.FindAllOrders:input_type -> order.FindAllOrdersRequest
-	7,  // 7: order.OrderService.PatchOrder:input_type -> order.PatchOrderRequest
-	9,  // 8: order.OrderService.DeleteOrder:input_type -> order.DeleteOrderRequest
-	2,  // 9: order.OrderService.CreateOrder:output_type -> order.CreateOrderResponse
-	4,  // 10: order.OrderService.FindOrderByID:output_type -> order.FindOrderByIDResponse
-	6,  // 11: order.OrderService.FindAllOrders:output_type -> order.FindAllOrdersResponse
-	8,  // 12: order.OrderService.PatchOrder:output_type -> order.PatchOrderResponse
-	10, // 13: order.OrderService.DeleteOrder:output_type -> order.DeleteOrderResponse
-	9,  // [9:14] is the sub-list for method output_type
-	4,  // [4:9] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	13, // 2: order.FindAllOrdersRequest.filter:type_name -> order.FindAllOrdersRequest.FilterEntry
+	0,  // 3: order.FindAllOrdersResponse.items:type_name -> order.Order
+	0,  // 4: order.PatchOrderResponse.order:type_name -> order.Order
+	0,  // 5: order.RestoreOrderResponse.order:type_name -> order.Order
+	1,  // 6: order.OrderService.CreateOrder:input_type -> order.CreateOrderRequest
+	3,  // 7: order.OrderService.FindOrderByID:input_type -> order.FindOrderByIDRequest
+	5,  // 8: order.OrderService.FindAllOrders:input_type -> order.FindAllOrdersRequest
+	7,  // 9: order.OrderService.PatchOrder:input_type -> order.PatchOrderRequest
+	9,  // 10: order.OrderService.DeleteOrder:input_type -> order.DeleteOrderRequest
+	5,  // 11: order.OrderService.FindDeletedOrders:input_type -> order.FindAllOrdersRequest
+	11, // 12: order.OrderService.RestoreOrder:input_type -> order.RestoreOrderRequest
+	2,  // 13: order.OrderService.CreateOrder:output_type -> order.CreateOrderResponse
+	4,  // 14: order.OrderService.FindOrderByID:output_type -> order.FindOrderByIDResponse
+	6,  // 15: order.OrderService.FindAllOrders:output_type -> order.FindAllOrdersResponse
+	8,  // 16: order.OrderService.PatchOrder:output_type -> order.PatchOrderResponse
+	10, // 17: order.OrderService.DeleteOrder:output_type -> order.DeleteOrderResponse
+	6,  // 18: order.OrderService.FindDeletedOrders:output_type -> order.FindAllOrdersResponse
+	12, // 19: order.OrderService.RestoreOrder:output_type -> order.RestoreOrderResponse
+	13, // [13:20] is the sub-list for method output_type
+	6,  // [6:13] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_proto_order_order_proto_init() }
@@ -608,7 +811,7 @@ func file_proto_order_order_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_order_order_proto_rawDesc), len(file_proto_order_order_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
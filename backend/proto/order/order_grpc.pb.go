@@ -1,8 +1,8 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.5.1
-// - protoc             v5.29.3
-// source: proto/orderpb/order.proto
+// - protoc             (unknown)
+// source: proto/order/order.proto
 
 package orderpb
 
@@ -19,11 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	OrderService_CreateOrder_FullMethodName   = "/order.OrderService/CreateOrder"
-	OrderService_FindOrderByID_FullMethodName = "/order.OrderService/FindOrderByID"
-	OrderService_FindAllOrders_FullMethodName = "/order.OrderService/FindAllOrders"
-	OrderService_PatchOrder_FullMethodName    = "/order.OrderService/PatchOrder"
-	OrderService_DeleteOrder_FullMethodName   = "/order.OrderService/DeleteOrder"
+	OrderService_CreateOrder_FullMethodName       = "/order.OrderService/CreateOrder"
+	OrderService_FindOrderByID_FullMethodName     = "/order.OrderService/FindOrderByID"
+	OrderService_FindAllOrders_FullMethodName     = "/order.OrderService/FindAllOrders"
+	OrderService_PatchOrder_FullMethodName        = "/order.OrderService/PatchOrder"
+	OrderService_DeleteOrder_FullMethodName       = "/order.OrderService/DeleteOrder"
+	OrderService_FindDeletedOrders_FullMethodName = "/order.OrderService/FindDeletedOrders"
+	OrderService_RestoreOrder_FullMethodName      = "/order.OrderService/RestoreOrder"
 )
 
 // OrderServiceClient is the client API for OrderService service.
@@ -35,6 +37,9 @@ type OrderServiceClient interface {
 	FindAllOrders(ctx context.Context, in *FindAllOrdersRequest, opts ...grpc.CallOption) (*FindAllOrdersResponse, error)
 	PatchOrder(ctx context.Context, in *PatchOrderRequest, opts ...grpc.CallOption) (*PatchOrderResponse, error)
 	DeleteOrder(ctx context.Context, in *DeleteOrderRequest, opts ...grpc.CallOption) (*DeleteOrderResponse, error)
+	// orders deleted within the retention window, office admins only
+	FindDeletedOrders(ctx context.Context, in *FindAllOrdersRequest, opts ...grpc.CallOption) (*FindAllOrdersResponse, error)
+	RestoreOrder(ctx context.Context, in *RestoreOrderRequest, opts ...grpc.CallOption) (*RestoreOrderResponse, error)
 }
 
 type orderServiceClient struct {
@@ -95,6 +100,26 @@ func (c *orderServiceClient) DeleteOrder(ctx context.Context, in *DeleteOrderReq
 	return out, nil
 }
 
+func (c *orderServiceClient) FindDeletedOrders(ctx context.Context, in *FindAllOrdersRequest, opts ...grpc.CallOption) (*FindAllOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindAllOrdersResponse)
+	err := c.cc.Invoke(ctx, OrderService_FindDeletedOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) RestoreOrder(ctx context.Context, in *RestoreOrderRequest, opts ...grpc.CallOption) (*RestoreOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreOrderResponse)
+	err := c.cc.Invoke(ctx, OrderService_RestoreOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrderServiceServer is the server API for OrderService service.
 // All implementations must embed UnimplementedOrderServiceServer
 // for forward compatibility.
@@ -104,6 +129,9 @@ type OrderServiceServer interface {
 	FindAllOrders(context.Context, *FindAllOrdersRequest) (*FindAllOrdersResponse, error)
 	PatchOrder(context.Context, *PatchOrderRequest) (*PatchOrderResponse, error)
 	DeleteOrder(context.Context, *DeleteOrderRequest) (*DeleteOrderResponse, error)
+	// orders deleted within the retention window, office admins only
+	FindDeletedOrders(context.Context, *FindAllOrdersRequest) (*FindAllOrdersResponse, error)
+	RestoreOrder(context.Context, *RestoreOrderRequest) (*RestoreOrderResponse, error)
 	mustEmbedUnimplementedOrderServiceServer()
 }
 
@@ -129,6 +157,12 @@ func (UnimplementedOrderServiceServer) PatchOrder(context.Context, *PatchOrderRe
 func (UnimplementedOrderServiceServer) DeleteOrder(context.Context, *DeleteOrderRequest) (*DeleteOrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteOrder not implemented")
 }
+func (UnimplementedOrderServiceServer) FindDeletedOrders(context.Context, *FindAllOrdersRequest) (*FindAllOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindDeletedOrders not implemented")
+}
+func (UnimplementedOrderServiceServer) RestoreOrder(context.Context, *RestoreOrderRequest) (*RestoreOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreOrder not implemented")
+}
 func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
 func (UnimplementedOrderServiceServer) testEmbeddedByValue()                      {}
 
@@ -240,6 +274,42 @@ func _OrderService_DeleteOrder_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_FindDeletedOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindAllOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).FindDeletedOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_FindDeletedOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).FindDeletedOrders(ctx, req.(*FindAllOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_RestoreOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).RestoreOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_RestoreOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).RestoreOrder(ctx, req.(*RestoreOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,7 +337,15 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteOrder",
 			Handler:    _OrderService_DeleteOrder_Handler,
 		},
+		{
+			MethodName: "FindDeletedOrders",
+			Handler:    _OrderService_FindDeletedOrders_Handler,
+		},
+		{
+			MethodName: "RestoreOrder",
+			Handler:    _OrderService_RestoreOrder_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "proto/orderpb/order.proto",
+	Metadata: "proto/order/order.proto",
 }
@@ -0,0 +1,462 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: proto/student/student.proto
+
+package studentpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Student struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roll          uint32                 `protobuf:"varint,1,opt,name=roll,proto3" json:"roll,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Hostel        string                 `protobuf:"bytes,3,opt,name=hostel,proto3" json:"hostel,omitempty"`
+	RoomNo        uint32                 `protobuf:"varint,4,opt,name=room_no,json=roomNo,proto3" json:"room_no,omitempty"`
+	MessNo        uint32                 `protobuf:"varint,5,opt,name=mess_no,json=messNo,proto3" json:"mess_no,omitempty"`
+	Phone         string                 `protobuf:"bytes,6,opt,name=phone,proto3" json:"phone,omitempty"`
+	Email         string                 `protobuf:"bytes,7,opt,name=email,proto3" json:"email,omitempty"`
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"` // ACTIVE or INACTIVE
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Student) Reset() {
+	*x = Student{}
+	mi := &file_proto_student_student_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Student) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Student) ProtoMessage() {}
+
+func (x *Student) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_student_student_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Student.ProtoReflect.Descriptor instead.
+func (*Student) Descriptor() ([]byte, []int) {
+	return file_proto_student_student_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Student) GetRoll() uint32 {
+	if x != nil {
+		return x.Roll
+	}
+	return 0
+}
+
+func (x *Student) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Student) GetHostel() string {
+	if x != nil {
+		return x.Hostel
+	}
+	return ""
+}
+
+func (x *Student) GetRoomNo() uint32 {
+	if x != nil {
+		return x.RoomNo
+	}
+	return 0
+}
+
+func (x *Student) GetMessNo() uint32 {
+	if x != nil {
+		return x.MessNo
+	}
+	return 0
+}
+
+func (x *Student) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Student) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Student) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type GetMyStudentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMyStudentRequest) Reset() {
+	*x = GetMyStudentRequest{}
+	mi := &file_proto_student_student_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMyStudentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyStudentRequest) ProtoMessage() {}
+
+func (x *GetMyStudentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_student_student_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyStudentRequest.ProtoReflect.Descriptor instead.
+func (*GetMyStudentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_student_student_proto_rawDescGZIP(), []int{1}
+}
+
+type GetMyStudentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Student       *Student               `protobuf:"bytes,1,opt,name=student,proto3" json:"student,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMyStudentResponse) Reset() {
+	*x = GetMyStudentResponse{}
+	mi := &file_proto_student_student_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMyStudentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyStudentResponse) ProtoMessage() {}
+
+func (x *GetMyStudentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_student_student_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyStudentResponse.ProtoReflect.Descriptor instead.
+func (*GetMyStudentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_student_student_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetMyStudentResponse) GetStudent() *Student {
+	if x != nil {
+		return x.Student
+	}
+	return nil
+}
+
+type FindStudentByRollRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roll          uint32                 `protobuf:"varint,1,opt,name=roll,proto3" json:"roll,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindStudentByRollRequest) Reset() {
+	*x = FindStudentByRollRequest{}
+	mi := &file_proto_student_student_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindStudentByRollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindStudentByRollRequest) ProtoMessage() {}
+
+func (x *FindStudentByRollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_student_student_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindStudentByRollRequest.ProtoReflect.Descriptor instead.
+func (*FindStudentByRollRequest) Descriptor() ([]byte, []int) {
+	return file_proto_student_student_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FindStudentByRollRequest) GetRoll() uint32 {
+	if x != nil {
+		return x.Roll
+	}
+	return 0
+}
+
+type FindStudentByRollResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Student       *Student               `protobuf:"bytes,1,opt,name=student,proto3" json:"student,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindStudentByRollResponse) Reset() {
+	*x = FindStudentByRollResponse{}
+	mi := &file_proto_student_student_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindStudentByRollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindStudentByRollResponse) ProtoMessage() {}
+
+func (x *FindStudentByRollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_student_student_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindStudentByRollResponse.ProtoReflect.Descriptor instead.
+func (*FindStudentByRollResponse) Descriptor() ([]byte, []int) {
+	return file_proto_student_student_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FindStudentByRollResponse) GetStudent() *Student {
+	if x != nil {
+		return x.Student
+	}
+	return nil
+}
+
+type FindActiveStudentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindActiveStudentsRequest) Reset() {
+	*x = FindActiveStudentsRequest{}
+	mi := &file_proto_student_student_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindActiveStudentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindActiveStudentsRequest) ProtoMessage() {}
+
+func (x *FindActiveStudentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_student_student_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindActiveStudentsRequest.ProtoReflect.Descriptor instead.
+func (*FindActiveStudentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_student_student_proto_rawDescGZIP(), []int{5}
+}
+
+type FindActiveStudentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Students      []*Student             `protobuf:"bytes,1,rep,name=students,proto3" json:"students,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindActiveStudentsResponse) Reset() {
+	*x = FindActiveStudentsResponse{}
+	mi := &file_proto_student_student_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindActiveStudentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindActiveStudentsResponse) ProtoMessage() {}
+
+func (x *FindActiveStudentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_student_student_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindActiveStudentsResponse.ProtoReflect.Descriptor instead.
+func (*FindActiveStudentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_student_student_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FindActiveStudentsResponse) GetStudents() []*Student {
+	if x != nil {
+		return x.Students
+	}
+	return nil
+}
+
+var File_proto_student_student_proto protoreflect.FileDescriptor
+
+const file_proto_student_student_proto_rawDesc = "" +
+	"\n" +
+	"\x1bproto/student/student.proto\x12\astudent\"\xbf\x01\n" +
+	"\aStudent\x12\x12\n" +
+	"\x04roll\x18\x01 \x01(\rR\x04roll\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06hostel\x18\x03 \x01(\tR\x06hostel\x12\x17\n" +
+	"\aroom_no\x18\x04 \x01(\rR\x06roomNo\x12\x17\n" +
+	"\amess_no\x18\x05 \x01(\rR\x06messNo\x12\x14\n" +
+	"\x05phone\x18\x06 \x01(\tR\x05phone\x12\x14\n" +
+	"\x05email\x18\a \x01(\tR\x05email\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\"\x15\n" +
+	"\x13GetMyStudentRequest\"B\n" +
+	"\x14GetMyStudentResponse\x12*\n" +
+	"\astudent\x18\x01 \x01(\v2\x10.student.StudentR\astudent\".\n" +
+	"\x18FindStudentByRollRequest\x12\x12\n" +
+	"\x04roll\x18\x01 \x01(\rR\x04roll\"G\n" +
+	"\x19FindStudentByRollResponse\x12*\n" +
+	"\astudent\x18\x01 \x01(\v2\x10.student.StudentR\astudent\"\x1b\n" +
+	"\x19FindActiveStudentsRequest\"J\n" +
+	"\x1aFindActiveStudentsResponse\x12,\n" +
+	"\bstudents\x18\x01 \x03(\v2\x10.student.StudentR\bstudents2\x98\x02\n" +
+	"\x0eStudentService\x12K\n" +
+	"\fGetMyStudent\x12\x1c.student.GetMyStudentRequest\x1a\x1d.student.GetMyStudentResponse\x12Z\n" +
+	"\x11FindStudentByRoll\x12!.student.FindStudentByRollRequest\x1a\".student.FindStudentByRollResponse\x12]\n" +
+	"\x12FindActiveStudents\x12\".student.FindActiveStudentsRequest\x1a#.student.FindActiveStudentsResponseB6Z4github.com/ePSA-eJya/Mess_Management/proto/studentpbb\x06proto3"
+
+var (
+	file_proto_student_student_proto_rawDescOnce sync.Once
+	file_proto_student_student_proto_rawDescData []byte
+)
+
+func file_proto_student_student_proto_rawDescGZIP() []byte {
+	file_proto_student_student_proto_rawDescOnce.Do(func() {
+		file_proto_student_student_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_student_student_proto_rawDesc), len(file_proto_student_student_proto_rawDesc)))
+	})
+	return file_proto_student_student_proto_rawDescData
+}
+
+var file_proto_student_student_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_student_student_proto_goTypes = []any{
+	(*Student)(nil),                    // 0: student.Student
+	(*GetMyStudentRequest)(nil),        // 1: student.GetMyStudentRequest
+	(*GetMyStudentResponse)(nil),       // 2: student.GetMyStudentResponse
+	(*FindStudentByRollRequest)(nil),   // 3: student.FindStudentByRollRequest
+	(*FindStudentByRollResponse)(nil),  // 4: student.FindStudentByRollResponse
+	(*FindActiveStudentsRequest)(nil),  // 5: student.FindActiveStudentsRequest
+	(*FindActiveStudentsResponse)(nil), // 6: student.FindActiveStudentsResponse
+}
+var file_proto_student_student_proto_depIdxs = []int32{
+	0, // 0: student.GetMyStudentResponse.student:type_name -> student.Student
+	0, // 1: student.FindStudentByRollResponse.student:type_name -> student.Student
+	0, // 2: student.FindActiveStudentsResponse.students:type_name -> student.Student
+	1, // 3: student.StudentService.GetMyStudent:input_type -> student.GetMyStudentRequest
+	3, // 4: student.StudentService.FindStudentByRoll:input_type -> student.FindStudentByRollRequest
+	5, // 5: student.StudentService.FindActiveStudents:input_type -> student.FindActiveStudentsRequest
+	2, // 6: student.StudentService.GetMyStudent:output_type -> student.GetMyStudentResponse
+	4, // 7: student.StudentService.FindStudentByRoll:output_type -> student.FindStudentByRollResponse
+	6, // 8: student.StudentService.FindActiveStudents:output_type -> student.FindActiveStudentsResponse
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_proto_student_student_proto_init() }
+func file_proto_student_student_proto_init() {
+	if File_proto_student_student_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_student_student_proto_rawDesc), len(file_proto_student_student_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_student_student_proto_goTypes,
+		DependencyIndexes: file_proto_student_student_proto_depIdxs,
+		MessageInfos:      file_proto_student_student_proto_msgTypes,
+	}.Build()
+	File_proto_student_student_proto = out.File
+	file_proto_student_student_proto_goTypes = nil
+	file_proto_student_student_proto_depIdxs = nil
+}
@@ -0,0 +1,203 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: proto/student/student.proto
+
+package studentpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StudentService_GetMyStudent_FullMethodName       = "/student.StudentService/GetMyStudent"
+	StudentService_FindStudentByRoll_FullMethodName  = "/student.StudentService/FindStudentByRoll"
+	StudentService_FindActiveStudents_FullMethodName = "/student.StudentService/FindActiveStudents"
+)
+
+// StudentServiceClient is the client API for StudentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// GetMyStudent returns the record linked to the caller's email, the lookups
+// by roll and the active list are limited to admins
+type StudentServiceClient interface {
+	GetMyStudent(ctx context.Context, in *GetMyStudentRequest, opts ...grpc.CallOption) (*GetMyStudentResponse, error)
+	FindStudentByRoll(ctx context.Context, in *FindStudentByRollRequest, opts ...grpc.CallOption) (*FindStudentByRollResponse, error)
+	FindActiveStudents(ctx context.Context, in *FindActiveStudentsRequest, opts ...grpc.CallOption) (*FindActiveStudentsResponse, error)
+}
+
+type studentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStudentServiceClient(cc grpc.ClientConnInterface) StudentServiceClient {
+	return &studentServiceClient{cc}
+}
+
+func (c *studentServiceClient) GetMyStudent(ctx context.Context, in *GetMyStudentRequest, opts ...grpc.CallOption) (*GetMyStudentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMyStudentResponse)
+	err := c.cc.Invoke(ctx, StudentService_GetMyStudent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *studentServiceClient) FindStudentByRoll(ctx context.Context, in *FindStudentByRollRequest, opts ...grpc.CallOption) (*FindStudentByRollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindStudentByRollResponse)
+	err := c.cc.Invoke(ctx, StudentService_FindStudentByRoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *studentServiceClient) FindActiveStudents(ctx context.Context, in *FindActiveStudentsRequest, opts ...grpc.CallOption) (*FindActiveStudentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindActiveStudentsResponse)
+	err := c.cc.Invoke(ctx, StudentService_FindActiveStudents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StudentServiceServer is the server API for StudentService service.
+// All implementations must embed UnimplementedStudentServiceServer
+// for forward compatibility.
+//
+// GetMyStudent returns the record linked to the caller's email, the lookups
+// by roll and the active list are limited to admins
+type StudentServiceServer interface {
+	GetMyStudent(context.Context, *GetMyStudentRequest) (*GetMyStudentResponse, error)
+	FindStudentByRoll(context.Context, *FindStudentByRollRequest) (*FindStudentByRollResponse, error)
+	FindActiveStudents(context.Context, *FindActiveStudentsRequest) (*FindActiveStudentsResponse, error)
+	mustEmbedUnimplementedStudentServiceServer()
+}
+
+// UnimplementedStudentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStudentServiceServer struct{}
+
+func (UnimplementedStudentServiceServer) GetMyStudent(context.Context, *GetMyStudentRequest) (*GetMyStudentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMyStudent not implemented")
+}
+func (UnimplementedStudentServiceServer) FindStudentByRoll(context.Context, *FindStudentByRollRequest) (*FindStudentByRollResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindStudentByRoll not implemented")
+}
+func (UnimplementedStudentServiceServer) FindActiveStudents(context.Context, *FindActiveStudentsRequest) (*FindActiveStudentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindActiveStudents not implemented")
+}
+func (UnimplementedStudentServiceServer) mustEmbedUnimplementedStudentServiceServer() {}
+func (UnimplementedStudentServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeStudentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StudentServiceServer will
+// result in compilation errors.
+type UnsafeStudentServiceServer interface {
+	mustEmbedUnimplementedStudentServiceServer()
+}
+
+func RegisterStudentServiceServer(s grpc.ServiceRegistrar, srv StudentServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStudentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StudentService_ServiceDesc, srv)
+}
+
+func _StudentService_GetMyStudent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMyStudentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StudentServiceServer).GetMyStudent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StudentService_GetMyStudent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StudentServiceServer).GetMyStudent(ctx, req.(*GetMyStudentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StudentService_FindStudentByRoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindStudentByRollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StudentServiceServer).FindStudentByRoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StudentService_FindStudentByRoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StudentServiceServer).FindStudentByRoll(ctx, req.(*FindStudentByRollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StudentService_FindActiveStudents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindActiveStudentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StudentServiceServer).FindActiveStudents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StudentService_FindActiveStudents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StudentServiceServer).FindActiveStudents(ctx, req.(*FindActiveStudentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StudentService_ServiceDesc is the grpc.ServiceDesc for StudentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StudentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "student.StudentService",
+	HandlerType: (*StudentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMyStudent",
+			Handler:    _StudentService_GetMyStudent_Handler,
+		},
+		{
+			MethodName: "FindStudentByRoll",
+			Handler:    _StudentService_FindStudentByRoll_Handler,
+		},
+		{
+			MethodName: "FindActiveStudents",
+			Handler:    _StudentService_FindActiveStudents_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/student/student.proto",
+}
@@ -1,33 +1,55 @@
 package utils
 
 import (
-	"log"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/ePSA-eJya/Mess_Management/pkg/config"
+	"github.com/ePSA-eJya/Mess_Management/pkg/logger"
 
 	"github.com/gofiber/fiber/v2"
 	"google.golang.org/grpc"
 )
 
+var log = logger.For("server")
+
 func StartRestServer(app *fiber.App, cfg *config.Config) {
-	log.Println("Starting REST server on port:", cfg.AppPort)
+	log.Info("Starting REST server", "port", cfg.AppPort)
 	if err := app.Listen(":" + cfg.AppPort); err != nil {
-		log.Fatalf("REST server error: %v", err)
+		log.Error("REST server stopped", "error", err)
+		os.Exit(1)
 	}
 }
 
 func StartGrpcServer(grpcServer *grpc.Server, cfg *config.Config) {
-	log.Println("Starting gRPC server on port:", cfg.GrpcPort)
+	log.Info("Starting gRPC server", "port", cfg.GrpcPort)
 	lis, err := net.Listen("tcp", ":"+cfg.GrpcPort)
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		log.Error("gRPC server cannot listen", "port", cfg.GrpcPort, "error", err)
+		os.Exit(1)
 	}
 	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("gRPC server error: %v", err)
+		log.Error("gRPC server stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+// StopGrpcServer lets calls in flight finish, then cancels the ones still
+// running after timeout, such as open watch streams
+func StopGrpcServer(grpcServer *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		log.Warn("gRPC calls still running after the shutdown timeout, cancelling them")
+		grpcServer.Stop()
 	}
 }
 
@@ -36,11 +58,11 @@ func WaitForShutdown(cleanups []func()) {
 	signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
 
 	<-c // Wait for signal
-	log.Println("Shutting down...")
+	log.Info("Shutting down")
 
 	for _, cleanup := range cleanups {
 		cleanup()
 	}
 
-	log.Println("Shutdown complete.")
+	log.Info("Shutdown complete")
 }